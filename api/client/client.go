@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"time"
 
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/mr-tron/base58"
 	ma "github.com/multiformats/go-multiaddr"
 	pb "github.com/textileio/go-threads/api/pb"
@@ -207,6 +209,36 @@ func (c *Client) GetStoreLink(ctx context.Context, storeID string) ([]string, er
 	return res, nil
 }
 
+// GetStoreInvite returns an invite to a store for the given peer. The store
+// keys are sealed to the peer, which starts the store with StartFromInvite.
+// A zero ttl uses the default invite lifetime.
+func (c *Client) GetStoreInvite(ctx context.Context, storeID string, invitee peer.ID, ttl time.Duration) ([]byte, error) {
+	inviteeb, err := invitee.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.GetStoreInviteRequest{
+		StoreID: storeID,
+		Invitee: inviteeb,
+		Ttl:     int64(ttl),
+	}
+	resp, err := c.c.GetStoreInvite(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetInvite(), nil
+}
+
+// StartFromInvite starts a store from an invite created for the server's host.
+func (c *Client) StartFromInvite(ctx context.Context, storeID string, invite []byte) error {
+	req := &pb.StartFromInviteRequest{
+		StoreID: storeID,
+		Invite:  invite,
+	}
+	_, err := c.c.StartFromInvite(ctx, req)
+	return err
+}
+
 // ModelHas checks if the specified entities exist
 func (c *Client) ModelHas(ctx context.Context, storeID, modelName string, entityIDs ...string) (bool, error) {
 	req := &pb.ModelHasRequest{
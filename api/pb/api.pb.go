@@ -52,7 +52,7 @@ func (x ListenRequest_Filter_Action) String() string {
 }
 
 func (ListenRequest_Filter_Action) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{27, 0, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31, 0, 0}
 }
 
 type ListenReply_Action int32
@@ -80,7 +80,7 @@ func (x ListenReply_Action) String() string {
 }
 
 func (ListenReply_Action) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{28, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32, 0}
 }
 
 type NewStoreRequest struct {
@@ -552,6 +552,178 @@ func (m *GetStoreLinkReply) GetReadKey() []byte {
 	return nil
 }
 
+type GetStoreInviteRequest struct {
+	StoreID              string   `protobuf:"bytes,1,opt,name=storeID,proto3" json:"storeID,omitempty"`
+	Invitee              []byte   `protobuf:"bytes,2,opt,name=invitee,proto3" json:"invitee,omitempty"`
+	Ttl                  int64    `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStoreInviteRequest) Reset()         { *m = GetStoreInviteRequest{} }
+func (m *GetStoreInviteRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStoreInviteRequest) ProtoMessage()    {}
+func (*GetStoreInviteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+}
+
+func (m *GetStoreInviteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStoreInviteRequest.Unmarshal(m, b)
+}
+func (m *GetStoreInviteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStoreInviteRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStoreInviteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStoreInviteRequest.Merge(m, src)
+}
+func (m *GetStoreInviteRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStoreInviteRequest.Size(m)
+}
+func (m *GetStoreInviteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStoreInviteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStoreInviteRequest proto.InternalMessageInfo
+
+func (m *GetStoreInviteRequest) GetStoreID() string {
+	if m != nil {
+		return m.StoreID
+	}
+	return ""
+}
+
+func (m *GetStoreInviteRequest) GetInvitee() []byte {
+	if m != nil {
+		return m.Invitee
+	}
+	return nil
+}
+
+func (m *GetStoreInviteRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type GetStoreInviteReply struct {
+	Invite               []byte   `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStoreInviteReply) Reset()         { *m = GetStoreInviteReply{} }
+func (m *GetStoreInviteReply) String() string { return proto.CompactTextString(m) }
+func (*GetStoreInviteReply) ProtoMessage()    {}
+func (*GetStoreInviteReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+}
+
+func (m *GetStoreInviteReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStoreInviteReply.Unmarshal(m, b)
+}
+func (m *GetStoreInviteReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStoreInviteReply.Marshal(b, m, deterministic)
+}
+func (m *GetStoreInviteReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStoreInviteReply.Merge(m, src)
+}
+func (m *GetStoreInviteReply) XXX_Size() int {
+	return xxx_messageInfo_GetStoreInviteReply.Size(m)
+}
+func (m *GetStoreInviteReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStoreInviteReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStoreInviteReply proto.InternalMessageInfo
+
+func (m *GetStoreInviteReply) GetInvite() []byte {
+	if m != nil {
+		return m.Invite
+	}
+	return nil
+}
+
+type StartFromInviteRequest struct {
+	StoreID              string   `protobuf:"bytes,1,opt,name=storeID,proto3" json:"storeID,omitempty"`
+	Invite               []byte   `protobuf:"bytes,2,opt,name=invite,proto3" json:"invite,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartFromInviteRequest) Reset()         { *m = StartFromInviteRequest{} }
+func (m *StartFromInviteRequest) String() string { return proto.CompactTextString(m) }
+func (*StartFromInviteRequest) ProtoMessage()    {}
+func (*StartFromInviteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+}
+
+func (m *StartFromInviteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartFromInviteRequest.Unmarshal(m, b)
+}
+func (m *StartFromInviteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartFromInviteRequest.Marshal(b, m, deterministic)
+}
+func (m *StartFromInviteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartFromInviteRequest.Merge(m, src)
+}
+func (m *StartFromInviteRequest) XXX_Size() int {
+	return xxx_messageInfo_StartFromInviteRequest.Size(m)
+}
+func (m *StartFromInviteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartFromInviteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartFromInviteRequest proto.InternalMessageInfo
+
+func (m *StartFromInviteRequest) GetStoreID() string {
+	if m != nil {
+		return m.StoreID
+	}
+	return ""
+}
+
+func (m *StartFromInviteRequest) GetInvite() []byte {
+	if m != nil {
+		return m.Invite
+	}
+	return nil
+}
+
+type StartFromInviteReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartFromInviteReply) Reset()         { *m = StartFromInviteReply{} }
+func (m *StartFromInviteReply) String() string { return proto.CompactTextString(m) }
+func (*StartFromInviteReply) ProtoMessage()    {}
+func (*StartFromInviteReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+}
+
+func (m *StartFromInviteReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartFromInviteReply.Unmarshal(m, b)
+}
+func (m *StartFromInviteReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartFromInviteReply.Marshal(b, m, deterministic)
+}
+func (m *StartFromInviteReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartFromInviteReply.Merge(m, src)
+}
+func (m *StartFromInviteReply) XXX_Size() int {
+	return xxx_messageInfo_StartFromInviteReply.Size(m)
+}
+func (m *StartFromInviteReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartFromInviteReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartFromInviteReply proto.InternalMessageInfo
+
 type ModelCreateRequest struct {
 	StoreID              string   `protobuf:"bytes,1,opt,name=storeID,proto3" json:"storeID,omitempty"`
 	ModelName            string   `protobuf:"bytes,2,opt,name=modelName,proto3" json:"modelName,omitempty"`
@@ -565,7 +737,7 @@ func (m *ModelCreateRequest) Reset()         { *m = ModelCreateRequest{} }
 func (m *ModelCreateRequest) String() string { return proto.CompactTextString(m) }
 func (*ModelCreateRequest) ProtoMessage()    {}
 func (*ModelCreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
 }
 
 func (m *ModelCreateRequest) XXX_Unmarshal(b []byte) error {
@@ -618,7 +790,7 @@ func (m *ModelCreateReply) Reset()         { *m = ModelCreateReply{} }
 func (m *ModelCreateReply) String() string { return proto.CompactTextString(m) }
 func (*ModelCreateReply) ProtoMessage()    {}
 func (*ModelCreateReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
 }
 
 func (m *ModelCreateReply) XXX_Unmarshal(b []byte) error {
@@ -659,7 +831,7 @@ func (m *ModelSaveRequest) Reset()         { *m = ModelSaveRequest{} }
 func (m *ModelSaveRequest) String() string { return proto.CompactTextString(m) }
 func (*ModelSaveRequest) ProtoMessage()    {}
 func (*ModelSaveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
 }
 
 func (m *ModelSaveRequest) XXX_Unmarshal(b []byte) error {
@@ -711,7 +883,7 @@ func (m *ModelSaveReply) Reset()         { *m = ModelSaveReply{} }
 func (m *ModelSaveReply) String() string { return proto.CompactTextString(m) }
 func (*ModelSaveReply) ProtoMessage()    {}
 func (*ModelSaveReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
 }
 
 func (m *ModelSaveReply) XXX_Unmarshal(b []byte) error {
@@ -745,7 +917,7 @@ func (m *ModelDeleteRequest) Reset()         { *m = ModelDeleteRequest{} }
 func (m *ModelDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*ModelDeleteRequest) ProtoMessage()    {}
 func (*ModelDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
 }
 
 func (m *ModelDeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -797,7 +969,7 @@ func (m *ModelDeleteReply) Reset()         { *m = ModelDeleteReply{} }
 func (m *ModelDeleteReply) String() string { return proto.CompactTextString(m) }
 func (*ModelDeleteReply) ProtoMessage()    {}
 func (*ModelDeleteReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
 }
 
 func (m *ModelDeleteReply) XXX_Unmarshal(b []byte) error {
@@ -831,7 +1003,7 @@ func (m *ModelHasRequest) Reset()         { *m = ModelHasRequest{} }
 func (m *ModelHasRequest) String() string { return proto.CompactTextString(m) }
 func (*ModelHasRequest) ProtoMessage()    {}
 func (*ModelHasRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
 }
 
 func (m *ModelHasRequest) XXX_Unmarshal(b []byte) error {
@@ -884,7 +1056,7 @@ func (m *ModelHasReply) Reset()         { *m = ModelHasReply{} }
 func (m *ModelHasReply) String() string { return proto.CompactTextString(m) }
 func (*ModelHasReply) ProtoMessage()    {}
 func (*ModelHasReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
 }
 
 func (m *ModelHasReply) XXX_Unmarshal(b []byte) error {
@@ -925,7 +1097,7 @@ func (m *ModelFindRequest) Reset()         { *m = ModelFindRequest{} }
 func (m *ModelFindRequest) String() string { return proto.CompactTextString(m) }
 func (*ModelFindRequest) ProtoMessage()    {}
 func (*ModelFindRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
 }
 
 func (m *ModelFindRequest) XXX_Unmarshal(b []byte) error {
@@ -978,7 +1150,7 @@ func (m *ModelFindReply) Reset()         { *m = ModelFindReply{} }
 func (m *ModelFindReply) String() string { return proto.CompactTextString(m) }
 func (*ModelFindReply) ProtoMessage()    {}
 func (*ModelFindReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
 }
 
 func (m *ModelFindReply) XXX_Unmarshal(b []byte) error {
@@ -1019,7 +1191,7 @@ func (m *ModelFindByIDRequest) Reset()         { *m = ModelFindByIDRequest{} }
 func (m *ModelFindByIDRequest) String() string { return proto.CompactTextString(m) }
 func (*ModelFindByIDRequest) ProtoMessage()    {}
 func (*ModelFindByIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
 }
 
 func (m *ModelFindByIDRequest) XXX_Unmarshal(b []byte) error {
@@ -1072,7 +1244,7 @@ func (m *ModelFindByIDReply) Reset()         { *m = ModelFindByIDReply{} }
 func (m *ModelFindByIDReply) String() string { return proto.CompactTextString(m) }
 func (*ModelFindByIDReply) ProtoMessage()    {}
 func (*ModelFindByIDReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
 }
 
 func (m *ModelFindByIDReply) XXX_Unmarshal(b []byte) error {
@@ -1112,7 +1284,7 @@ func (m *StartTransactionRequest) Reset()         { *m = StartTransactionRequest
 func (m *StartTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*StartTransactionRequest) ProtoMessage()    {}
 func (*StartTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
 }
 
 func (m *StartTransactionRequest) XXX_Unmarshal(b []byte) error {
@@ -1163,7 +1335,7 @@ func (m *ReadTransactionRequest) Reset()         { *m = ReadTransactionRequest{}
 func (m *ReadTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadTransactionRequest) ProtoMessage()    {}
 func (*ReadTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
 }
 
 func (m *ReadTransactionRequest) XXX_Unmarshal(b []byte) error {
@@ -1272,7 +1444,7 @@ func (m *ReadTransactionReply) Reset()         { *m = ReadTransactionReply{} }
 func (m *ReadTransactionReply) String() string { return proto.CompactTextString(m) }
 func (*ReadTransactionReply) ProtoMessage()    {}
 func (*ReadTransactionReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
 }
 
 func (m *ReadTransactionReply) XXX_Unmarshal(b []byte) error {
@@ -1371,7 +1543,7 @@ func (m *WriteTransactionRequest) Reset()         { *m = WriteTransactionRequest
 func (m *WriteTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*WriteTransactionRequest) ProtoMessage()    {}
 func (*WriteTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
 }
 
 func (m *WriteTransactionRequest) XXX_Unmarshal(b []byte) error {
@@ -1525,7 +1697,7 @@ func (m *WriteTransactionReply) Reset()         { *m = WriteTransactionReply{} }
 func (m *WriteTransactionReply) String() string { return proto.CompactTextString(m) }
 func (*WriteTransactionReply) ProtoMessage()    {}
 func (*WriteTransactionReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
 }
 
 func (m *WriteTransactionReply) XXX_Unmarshal(b []byte) error {
@@ -1659,7 +1831,7 @@ func (m *ListenRequest) Reset()         { *m = ListenRequest{} }
 func (m *ListenRequest) String() string { return proto.CompactTextString(m) }
 func (*ListenRequest) ProtoMessage()    {}
 func (*ListenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
 }
 
 func (m *ListenRequest) XXX_Unmarshal(b []byte) error {
@@ -1707,7 +1879,7 @@ func (m *ListenRequest_Filter) Reset()         { *m = ListenRequest_Filter{} }
 func (m *ListenRequest_Filter) String() string { return proto.CompactTextString(m) }
 func (*ListenRequest_Filter) ProtoMessage()    {}
 func (*ListenRequest_Filter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{27, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31, 0}
 }
 
 func (m *ListenRequest_Filter) XXX_Unmarshal(b []byte) error {
@@ -1763,7 +1935,7 @@ func (m *ListenReply) Reset()         { *m = ListenReply{} }
 func (m *ListenReply) String() string { return proto.CompactTextString(m) }
 func (*ListenReply) ProtoMessage()    {}
 func (*ListenReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
 }
 
 func (m *ListenReply) XXX_Unmarshal(b []byte) error {
@@ -1826,6 +1998,10 @@ func init() {
 	proto.RegisterType((*StartFromAddressReply)(nil), "api.pb.StartFromAddressReply")
 	proto.RegisterType((*GetStoreLinkRequest)(nil), "api.pb.GetStoreLinkRequest")
 	proto.RegisterType((*GetStoreLinkReply)(nil), "api.pb.GetStoreLinkReply")
+	proto.RegisterType((*GetStoreInviteRequest)(nil), "api.pb.GetStoreInviteRequest")
+	proto.RegisterType((*GetStoreInviteReply)(nil), "api.pb.GetStoreInviteReply")
+	proto.RegisterType((*StartFromInviteRequest)(nil), "api.pb.StartFromInviteRequest")
+	proto.RegisterType((*StartFromInviteReply)(nil), "api.pb.StartFromInviteReply")
 	proto.RegisterType((*ModelCreateRequest)(nil), "api.pb.ModelCreateRequest")
 	proto.RegisterType((*ModelCreateReply)(nil), "api.pb.ModelCreateReply")
 	proto.RegisterType((*ModelSaveRequest)(nil), "api.pb.ModelSaveRequest")
@@ -1851,87 +2027,92 @@ func init() {
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 1270 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdd, 0x6e, 0xe3, 0x44,
-	0x14, 0xb6, 0x93, 0xd4, 0x49, 0x4e, 0xfa, 0x93, 0x9d, 0x36, 0x4d, 0x30, 0x59, 0xb6, 0x1a, 0x2e,
-	0x28, 0xa8, 0x0a, 0xab, 0x54, 0x42, 0x20, 0x40, 0xd0, 0x36, 0x29, 0x09, 0x1b, 0xca, 0xe2, 0x84,
-	0x1f, 0x89, 0x0b, 0xe4, 0x36, 0xd3, 0xd6, 0x4b, 0x12, 0xa7, 0xb6, 0xbb, 0xdb, 0x3c, 0x00, 0xef,
-	0xc0, 0x35, 0xcf, 0xc0, 0x3d, 0x12, 0x12, 0x8f, 0x81, 0xc4, 0x13, 0xc0, 0x2b, 0xa0, 0x19, 0xcf,
-	0xd8, 0x1e, 0xff, 0xa4, 0x88, 0x76, 0xf7, 0x2e, 0x73, 0xe6, 0xfc, 0x7c, 0xe7, 0xf8, 0x9b, 0x73,
-	0x66, 0x02, 0x65, 0x73, 0x6e, 0xb5, 0xe6, 0x8e, 0xed, 0xd9, 0x48, 0x63, 0x3f, 0x4f, 0xf1, 0x03,
-	0xd8, 0x38, 0x21, 0x2f, 0x86, 0x9e, 0xed, 0x10, 0x83, 0x5c, 0x5d, 0x13, 0xd7, 0xc3, 0x8f, 0x60,
-	0x2d, 0x14, 0xcd, 0x27, 0x0b, 0xb4, 0x0e, 0xb9, 0x7e, 0xa7, 0xa1, 0xee, 0xa8, 0xbb, 0x65, 0x23,
-	0xd7, 0xef, 0xe0, 0x3f, 0x55, 0xa8, 0x19, 0xe4, 0xc2, 0x72, 0x3d, 0xe2, 0x0c, 0xcf, 0x2e, 0xc9,
-	0xd4, 0xe4, 0xa6, 0xa8, 0x01, 0x45, 0x97, 0xda, 0x05, 0xea, 0x62, 0x89, 0x10, 0x14, 0x66, 0xe6,
-	0x94, 0x34, 0x72, 0x4c, 0xcc, 0x7e, 0xa3, 0x6d, 0xd0, 0x5c, 0x66, 0xde, 0xc8, 0x33, 0x29, 0x5f,
-	0xa1, 0x23, 0x28, 0x5a, 0xb3, 0x31, 0xb9, 0x21, 0x6e, 0xa3, 0xb0, 0x93, 0xdf, 0xad, 0xb4, 0xdf,
-	0x6e, 0xf9, 0x68, 0x5b, 0xa9, 0x51, 0x5b, 0x7d, 0xaa, 0x7c, 0x64, 0xcf, 0xce, 0xad, 0x0b, 0x43,
-	0x58, 0xea, 0x1f, 0x40, 0x25, 0x22, 0xa7, 0xf1, 0xe7, 0xa6, 0x77, 0xc9, 0x61, 0xb1, 0xdf, 0x34,
-	0xfe, 0xf5, 0xcc, 0xba, 0xba, 0xf6, 0x51, 0x95, 0x0c, 0xbe, 0xc2, 0x35, 0xd8, 0x8c, 0x07, 0x9a,
-	0x4f, 0x16, 0x78, 0x17, 0x56, 0x87, 0x9e, 0xe9, 0x78, 0xb7, 0x26, 0x8b, 0x57, 0x01, 0xb8, 0x26,
-	0xb5, 0xfb, 0x49, 0x85, 0x3a, 0x5b, 0x1e, 0x3b, 0xf6, 0xf4, 0x60, 0x3c, 0x76, 0x88, 0xeb, 0xde,
-	0x5e, 0xb0, 0x06, 0x14, 0x4d, 0x5f, 0x97, 0xd7, 0x4c, 0x2c, 0x51, 0x13, 0xca, 0xe7, 0xf6, 0x64,
-	0x62, 0xbf, 0x78, 0x42, 0x16, 0xac, 0x72, 0xab, 0x46, 0x28, 0xa0, 0x76, 0x0e, 0x31, 0xc7, 0x74,
-	0xaf, 0xc0, 0xf6, 0xc4, 0x12, 0xd7, 0xa1, 0x96, 0x84, 0x41, 0x01, 0xbe, 0x0b, 0x9b, 0x9f, 0x11,
-	0x8f, 0x7d, 0xf0, 0x81, 0x35, 0xfb, 0xf1, 0xf6, 0xfc, 0x2c, 0x78, 0x20, 0x1b, 0x50, 0x96, 0x34,
-	0xa1, 0xcc, 0x11, 0x12, 0xb7, 0xa1, 0xee, 0xe4, 0x77, 0xcb, 0x46, 0x28, 0x90, 0x41, 0xe7, 0x96,
-	0x80, 0xce, 0xcb, 0xa0, 0xc7, 0x80, 0xbe, 0xb0, 0xc7, 0x64, 0x72, 0xe4, 0x10, 0xd3, 0x23, 0xb7,
-	0x97, 0xad, 0x09, 0xe5, 0x29, 0xd5, 0x3f, 0x09, 0xc9, 0x16, 0x0a, 0xe8, 0x17, 0x7f, 0x6e, 0x4e,
-	0xae, 0x89, 0xdb, 0xc8, 0x33, 0x80, 0x7c, 0x85, 0x5b, 0x50, 0x95, 0xa2, 0xd0, 0x7c, 0x74, 0x28,
-	0x91, 0x99, 0x67, 0x79, 0x56, 0x90, 0x4e, 0xb0, 0xc6, 0xa7, 0x5c, 0x7f, 0x68, 0x3e, 0x7f, 0x69,
-	0x98, 0xaa, 0xb0, 0x1e, 0x89, 0x41, 0xbf, 0xd3, 0x33, 0x5e, 0x8b, 0x0e, 0x99, 0x90, 0xbb, 0xd7,
-	0xa2, 0x09, 0x65, 0x96, 0xcf, 0xa2, 0xdf, 0x11, 0xa1, 0x43, 0x01, 0x46, 0x3c, 0x43, 0x11, 0x8b,
-	0xc6, 0xbf, 0x80, 0x0d, 0x26, 0xeb, 0x99, 0xee, 0xcb, 0x0d, 0xfe, 0x16, 0xac, 0x85, 0x81, 0xe8,
-	0xb7, 0xd8, 0x06, 0x8d, 0xdc, 0x58, 0xae, 0xe7, 0xb2, 0x28, 0x25, 0x83, 0xaf, 0xf0, 0x25, 0x47,
-	0x79, 0x6c, 0xcd, 0xc6, 0xf7, 0x00, 0xe9, 0xea, 0x9a, 0x38, 0x8b, 0xcf, 0x87, 0x5f, 0x9e, 0x88,
-	0x63, 0x15, 0x08, 0xf0, 0x1e, 0xff, 0x1a, 0x7e, 0xa4, 0x34, 0x7e, 0xac, 0x46, 0xf8, 0xf1, 0x0c,
-	0xb6, 0x02, 0xed, 0xc3, 0x45, 0xbf, 0x73, 0x57, 0x6c, 0x22, 0xd6, 0xa2, 0xdf, 0xe1, 0xbd, 0x32,
-	0x58, 0xe3, 0x3d, 0xce, 0x8a, 0x30, 0x96, 0xa8, 0x18, 0xd3, 0xe0, 0x81, 0xf8, 0x0a, 0x7f, 0xc5,
-	0x7b, 0xd1, 0xc8, 0x31, 0x67, 0xae, 0x79, 0xe6, 0x59, 0xf6, 0xec, 0x8e, 0xe0, 0xf0, 0x5f, 0x39,
-	0xd8, 0x36, 0x88, 0x39, 0x4e, 0x71, 0xf9, 0x3d, 0xd4, 0xdd, 0xf4, 0x68, 0x2c, 0x44, 0xa5, 0xfd,
-	0x48, 0x74, 0xf6, 0x0c, 0x50, 0x3d, 0xc5, 0xc8, 0xf2, 0x80, 0x8e, 0x60, 0x63, 0x2a, 0xd3, 0x91,
-	0x61, 0xab, 0xb4, 0xeb, 0xc2, 0x69, 0x8c, 0xad, 0x3d, 0xc5, 0x88, 0x5b, 0xa0, 0x63, 0xa8, 0x4e,
-	0x63, 0x0c, 0x62, 0x15, 0xae, 0xb4, 0x1b, 0x92, 0x97, 0xc8, 0x7e, 0x4f, 0x31, 0x12, 0x36, 0xc8,
-	0x80, 0xad, 0x69, 0xca, 0x17, 0x67, 0x3d, 0xb8, 0xd2, 0x6e, 0x26, 0x7c, 0x45, 0x74, 0x7a, 0x8a,
-	0x91, 0x6a, 0x7b, 0x58, 0x02, 0xcd, 0x9e, 0xd3, 0x8c, 0xf1, 0x3f, 0x2a, 0x6c, 0x25, 0x4a, 0x4c,
-	0x3f, 0xf3, 0xc7, 0xb0, 0x36, 0x8d, 0x9e, 0x14, 0x5e, 0xd6, 0x5a, 0xb2, 0x02, 0xf3, 0xc9, 0xa2,
-	0xa7, 0x18, 0xb2, 0x36, 0xfa, 0x14, 0xd6, 0xa7, 0x12, 0xab, 0x79, 0x05, 0xb7, 0x53, 0x72, 0xf7,
-	0x1d, 0xc4, 0xf4, 0xd1, 0x00, 0xd0, 0x34, 0xc1, 0x3e, 0x5e, 0x41, 0x3d, 0x23, 0x6b, 0xdf, 0x53,
-	0x8a, 0x5d, 0x24, 0xe3, 0x3f, 0x0a, 0x50, 0xff, 0xd6, 0xb1, 0x3c, 0xf2, 0xaa, 0x59, 0x25, 0x12,
-	0x92, 0x06, 0x0e, 0x2f, 0x8b, 0x9c, 0x90, 0xa4, 0x11, 0x24, 0x24, 0x0f, 0x2a, 0x41, 0xaf, 0xc8,
-	0xa0, 0x48, 0xa5, 0x57, 0x64, 0x3f, 0xa0, 0x57, 0x74, 0xb8, 0x08, 0x54, 0x52, 0xeb, 0xe7, 0xe4,
-	0x92, 0x51, 0x49, 0x1a, 0x01, 0x2a, 0x79, 0x64, 0xa4, 0x9c, 0x9c, 0x95, 0x7b, 0x39, 0x39, 0xda,
-	0x3d, 0x9e, 0x9c, 0xe2, 0xbd, 0x9c, 0x9c, 0xdf, 0xf3, 0x50, 0x4b, 0xf2, 0x88, 0x32, 0x57, 0xe0,
-	0x8f, 0xcc, 0x7c, 0x4e, 0x9f, 0x46, 0xea, 0x67, 0xf6, 0x59, 0x9b, 0xb0, 0x09, 0xce, 0x50, 0x30,
-	0xa7, 0x53, 0xcf, 0x50, 0xb0, 0x1b, 0x9c, 0xa1, 0x40, 0x12, 0x20, 0x89, 0xcc, 0xda, 0x54, 0x92,
-	0x44, 0xf6, 0x03, 0x24, 0x11, 0x59, 0xb2, 0x19, 0x14, 0xee, 0xd8, 0x0c, 0x56, 0xee, 0xa5, 0x19,
-	0x68, 0x77, 0x6e, 0x06, 0x3f, 0xe7, 0x60, 0x6d, 0x40, 0xef, 0xe3, 0xff, 0x61, 0x56, 0xbd, 0x07,
-	0xc5, 0x73, 0x6b, 0xe2, 0x11, 0x87, 0xde, 0x9b, 0xf3, 0x51, 0x06, 0x49, 0x1e, 0x5a, 0xc7, 0x4c,
-	0xc9, 0x10, 0xca, 0xfa, 0xaf, 0x2a, 0x68, 0xbe, 0x4c, 0x1e, 0x77, 0xea, 0xb2, 0x59, 0x9c, 0x93,
-	0x67, 0x31, 0xfa, 0x10, 0x34, 0x9f, 0x62, 0xec, 0xfb, 0xad, 0xb7, 0xdf, 0x5c, 0x16, 0xbb, 0x75,
-	0xe0, 0xb3, 0x91, 0x9b, 0xe0, 0x7d, 0xd0, 0x7c, 0x09, 0x2a, 0x42, 0xfe, 0x60, 0x30, 0xa8, 0x2a,
-	0x08, 0x40, 0x3b, 0x32, 0xba, 0x07, 0xa3, 0x6e, 0x55, 0x45, 0x25, 0x28, 0x0c, 0x0f, 0xbe, 0xe9,
-	0x56, 0x73, 0x54, 0xda, 0xe9, 0x0e, 0xba, 0xa3, 0x6e, 0x35, 0x8f, 0x7f, 0x53, 0xa1, 0x22, 0x9c,
-	0xf3, 0x5b, 0xf8, 0xff, 0xc4, 0xde, 0x8e, 0x61, 0xd7, 0xe3, 0xd8, 0xe7, 0x93, 0x45, 0x0c, 0x72,
-	0xe4, 0x96, 0xe1, 0xbf, 0x35, 0xc4, 0x2d, 0xe3, 0x9d, 0x20, 0x95, 0x30, 0x03, 0x25, 0xc8, 0x40,
-	0x8d, 0x64, 0x90, 0x6b, 0xff, 0x4d, 0xb3, 0x7d, 0xda, 0x47, 0x1f, 0x41, 0x49, 0x3c, 0x3b, 0x51,
-	0xd0, 0x87, 0x62, 0x6f, 0x53, 0xbd, 0x96, 0xdc, 0xa0, 0x37, 0x53, 0x05, 0x9d, 0xc0, 0xba, 0xfc,
-	0x66, 0x43, 0x0f, 0x97, 0x3e, 0x1a, 0xf5, 0xd7, 0xb3, 0xb6, 0x7d, 0x7f, 0xfb, 0xb0, 0xc2, 0x86,
-	0x07, 0xda, 0x92, 0x66, 0x89, 0xb0, 0x46, 0x31, 0xa9, 0x6f, 0x34, 0x82, 0x6a, 0xfc, 0x85, 0x85,
-	0xe4, 0x59, 0x94, 0x7c, 0x02, 0xea, 0x0f, 0xb3, 0x15, 0x7c, 0xaf, 0x3d, 0x58, 0x8d, 0xbe, 0xb6,
-	0x50, 0x80, 0x3c, 0xe5, 0xd1, 0xa6, 0xbf, 0x96, 0xbe, 0xe9, 0x7b, 0xea, 0x42, 0x25, 0xd2, 0xd2,
-	0xd0, 0x92, 0x71, 0xa6, 0x67, 0xf6, 0x40, 0xac, 0xa0, 0x4f, 0xa0, 0x1c, 0xf4, 0x34, 0x94, 0x39,
-	0xc7, 0xf4, 0x8c, 0x06, 0x18, 0xc1, 0xe1, 0x37, 0x2f, 0xb4, 0x64, 0x80, 0xe9, 0x99, 0x1d, 0x10,
-	0x2b, 0x94, 0x31, 0xa2, 0xa5, 0xa1, 0xac, 0xc9, 0xa5, 0xa7, 0x77, 0xbf, 0x48, 0x16, 0xb4, 0xe9,
-	0xa0, 0xcc, 0x91, 0xa5, 0x67, 0x74, 0x3f, 0xac, 0xa0, 0x27, 0xfc, 0x95, 0x22, 0xba, 0x16, 0x5a,
-	0x3a, 0xab, 0xf4, 0x25, 0x0d, 0x10, 0x2b, 0xe8, 0x6b, 0xd8, 0x88, 0x5d, 0xf0, 0xd0, 0x1b, 0x21,
-	0x43, 0xd3, 0x2e, 0xd7, 0x7a, 0x33, 0x73, 0x9f, 0xb9, 0xdc, 0x55, 0x1f, 0xab, 0xe8, 0x3b, 0xa8,
-	0xc6, 0xa7, 0x5f, 0xc8, 0xc8, 0x8c, 0xfb, 0x55, 0xc8, 0xc8, 0xd4, 0xc1, 0xc9, 0x3d, 0xbf, 0x0f,
-	0x9a, 0xdf, 0x18, 0x50, 0x2d, 0xb5, 0xc9, 0xe9, 0x9b, 0x29, 0xfd, 0x03, 0x2b, 0x8f, 0xd5, 0xc3,
-	0x3d, 0xa8, 0x5b, 0x76, 0xcb, 0x23, 0x37, 0x9e, 0x35, 0x21, 0x2d, 0xef, 0x92, 0x3e, 0xf5, 0xdd,
-	0x1f, 0x2e, 0x9c, 0xf9, 0xd9, 0x61, 0x71, 0xe4, 0xaf, 0x9e, 0xaa, 0xbf, 0xe4, 0x56, 0x46, 0x3d,
-	0xa3, 0x33, 0x3c, 0xd5, 0xd8, 0xff, 0x55, 0xfb, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x94, 0x9b,
-	0x27, 0x67, 0xbc, 0x12, 0x00, 0x00,
+	// 1355 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdd, 0x6e, 0x1b, 0xc5,
+	0x17, 0xdf, 0xb5, 0x9d, 0x75, 0x7c, 0x9c, 0x0f, 0x77, 0x12, 0x27, 0xfb, 0xdf, 0xba, 0xff, 0x56,
+	0xc3, 0x05, 0x01, 0x15, 0x53, 0xb9, 0x12, 0x02, 0x01, 0x82, 0x24, 0x76, 0xb0, 0xdb, 0x10, 0xda,
+	0xb5, 0xf9, 0x90, 0x10, 0x42, 0xdb, 0x7a, 0x9a, 0x6c, 0x59, 0x7f, 0x74, 0x77, 0xd3, 0xd6, 0x0f,
+	0xc0, 0x03, 0x70, 0xc7, 0x35, 0xcf, 0xc0, 0x3d, 0x12, 0x12, 0x8f, 0x81, 0xc4, 0x1b, 0xf0, 0x0a,
+	0x68, 0x66, 0x67, 0x67, 0x67, 0xf6, 0xc3, 0x8d, 0x48, 0xca, 0x9d, 0xcf, 0x99, 0xf3, 0xf1, 0x3b,
+	0x67, 0xce, 0x9c, 0xb3, 0xc7, 0x50, 0x73, 0xe6, 0x6e, 0x7b, 0xee, 0xcf, 0xc2, 0x19, 0x32, 0xd8,
+	0xcf, 0x47, 0xf8, 0x1a, 0x6c, 0x9e, 0x90, 0x17, 0xc3, 0x70, 0xe6, 0x13, 0x9b, 0x3c, 0x3b, 0x27,
+	0x41, 0x88, 0x6f, 0xc2, 0x7a, 0xc2, 0x9a, 0x7b, 0x0b, 0xb4, 0x01, 0xa5, 0x41, 0xd7, 0xd4, 0x6f,
+	0xe9, 0x7b, 0x35, 0xbb, 0x34, 0xe8, 0xe2, 0x3f, 0x75, 0x68, 0xda, 0xe4, 0xd4, 0x0d, 0x42, 0xe2,
+	0x0f, 0x1f, 0x9f, 0x91, 0x89, 0xc3, 0x55, 0x91, 0x09, 0xd5, 0x80, 0xea, 0x09, 0xf1, 0x98, 0x44,
+	0x08, 0x2a, 0x53, 0x67, 0x42, 0xcc, 0x12, 0x63, 0xb3, 0xdf, 0x68, 0x07, 0x8c, 0x80, 0xa9, 0x9b,
+	0x65, 0xc6, 0xe5, 0x14, 0x3a, 0x84, 0xaa, 0x3b, 0x1d, 0x93, 0x97, 0x24, 0x30, 0x2b, 0xb7, 0xca,
+	0x7b, 0xf5, 0xce, 0x5b, 0xed, 0x08, 0x6d, 0x3b, 0xd7, 0x6b, 0x7b, 0x40, 0x85, 0x0f, 0x67, 0xd3,
+	0x27, 0xee, 0xa9, 0x1d, 0x6b, 0x5a, 0x1f, 0x40, 0x5d, 0xe2, 0x53, 0xff, 0x73, 0x27, 0x3c, 0xe3,
+	0xb0, 0xd8, 0x6f, 0xea, 0xff, 0x7c, 0xea, 0x3e, 0x3b, 0x8f, 0x50, 0xad, 0xda, 0x9c, 0xc2, 0x4d,
+	0xd8, 0x4a, 0x3b, 0x9a, 0x7b, 0x0b, 0xbc, 0x07, 0x6b, 0xc3, 0xd0, 0xf1, 0xc3, 0x57, 0x06, 0x8b,
+	0xd7, 0x00, 0xb8, 0x24, 0xd5, 0xfb, 0x51, 0x87, 0x5d, 0x46, 0x1e, 0xf9, 0xb3, 0xc9, 0xfe, 0x78,
+	0xec, 0x93, 0x20, 0x78, 0x75, 0xc2, 0x4c, 0xa8, 0x3a, 0x91, 0x2c, 0xcf, 0x59, 0x4c, 0xa2, 0x16,
+	0xd4, 0x9e, 0xcc, 0x3c, 0x6f, 0xf6, 0xe2, 0x3e, 0x59, 0xb0, 0xcc, 0xad, 0xd9, 0x09, 0x83, 0xea,
+	0xf9, 0xc4, 0x19, 0xd3, 0xb3, 0x0a, 0x3b, 0x8b, 0x49, 0xbc, 0x0b, 0xcd, 0x2c, 0x0c, 0x0a, 0xf0,
+	0x5d, 0xd8, 0xfa, 0x8c, 0x84, 0xec, 0xc2, 0x8f, 0xdd, 0xe9, 0x0f, 0xaf, 0x8e, 0xcf, 0x85, 0x6b,
+	0xaa, 0x02, 0xad, 0x92, 0x16, 0xd4, 0x38, 0x42, 0x12, 0x98, 0xfa, 0xad, 0xf2, 0x5e, 0xcd, 0x4e,
+	0x18, 0x2a, 0xe8, 0xd2, 0x12, 0xd0, 0x65, 0x15, 0xf4, 0x77, 0xd0, 0x8c, 0x5d, 0x0d, 0xa6, 0xcf,
+	0xdd, 0x90, 0x5c, 0x28, 0x73, 0x2e, 0x13, 0x25, 0xdc, 0x51, 0x4c, 0xa2, 0x06, 0x94, 0xc3, 0xd0,
+	0x63, 0x2e, 0xca, 0x36, 0xfd, 0x89, 0xdf, 0x81, 0xad, 0xb4, 0x79, 0x1a, 0xcb, 0x0e, 0x18, 0x91,
+	0x0e, 0xb3, 0xbd, 0x66, 0x73, 0x0a, 0xdf, 0x83, 0x1d, 0x91, 0xc2, 0x8b, 0xc2, 0x49, 0x6c, 0x95,
+	0x14, 0x5b, 0x3b, 0xb0, 0x9d, 0xb1, 0x45, 0x6f, 0x63, 0x0c, 0xe8, 0xf3, 0xd9, 0x98, 0x78, 0x87,
+	0x3e, 0x71, 0x2e, 0x62, 0xbf, 0x05, 0xb5, 0x09, 0x95, 0x3f, 0x49, 0x9e, 0x57, 0xc2, 0xa0, 0xde,
+	0x9f, 0x3b, 0xde, 0x39, 0x09, 0xcc, 0x32, 0xbb, 0x12, 0x4e, 0xe1, 0x36, 0x34, 0x14, 0x2f, 0x34,
+	0x6a, 0x0b, 0x56, 0xc9, 0x34, 0x74, 0x43, 0x57, 0x5c, 0xa0, 0xa0, 0xf1, 0x23, 0x2e, 0x3f, 0x74,
+	0x9e, 0xbf, 0x36, 0x4c, 0x0d, 0xd8, 0x90, 0x7c, 0xd0, 0x5c, 0x3c, 0xe5, 0xb9, 0xe8, 0x12, 0x8f,
+	0x5c, 0x3e, 0x17, 0x2d, 0xa8, 0xb1, 0x78, 0x16, 0x83, 0x6e, 0xec, 0x3a, 0x61, 0x60, 0x04, 0x0d,
+	0xc5, 0x17, 0xf5, 0x7f, 0x0a, 0x9b, 0x8c, 0xd7, 0x77, 0x82, 0xd7, 0xeb, 0xfc, 0x4d, 0x58, 0x4f,
+	0x1c, 0xf1, 0x0a, 0x24, 0x2f, 0xdd, 0x20, 0x0c, 0x98, 0x97, 0x55, 0x9b, 0x53, 0xf8, 0x8c, 0xa3,
+	0x3c, 0x72, 0xa7, 0xe3, 0x2b, 0x80, 0xf4, 0xec, 0x9c, 0xf8, 0x8b, 0x7b, 0xc3, 0x2f, 0x4e, 0xe2,
+	0x46, 0x22, 0x18, 0xf8, 0x36, 0x6c, 0x48, 0x9e, 0xf2, 0xea, 0x63, 0x4d, 0xaa, 0x8f, 0xa7, 0xb0,
+	0x2d, 0xa4, 0x0f, 0x16, 0x83, 0xee, 0x65, 0xb1, 0xc5, 0xbe, 0x16, 0x83, 0x2e, 0x9f, 0x0e, 0x82,
+	0xc6, 0xb7, 0x01, 0xa5, 0x7c, 0xc5, 0x19, 0x63, 0x12, 0xdc, 0x11, 0xa7, 0xf0, 0x43, 0xde, 0x7d,
+	0x47, 0xbe, 0x33, 0x0d, 0x9c, 0xc7, 0xa1, 0x3b, 0x9b, 0x5e, 0x12, 0x1c, 0xfe, 0xab, 0x04, 0x3b,
+	0x36, 0x71, 0xc6, 0x39, 0x26, 0xbf, 0x85, 0xdd, 0x20, 0xdf, 0x1b, 0x73, 0x51, 0xef, 0xdc, 0x8c,
+	0x67, 0x59, 0x01, 0xa8, 0xbe, 0x66, 0x17, 0x59, 0x40, 0x87, 0xb0, 0x39, 0x51, 0xcb, 0x91, 0x61,
+	0xab, 0x77, 0x76, 0x63, 0xa3, 0xa9, 0x6a, 0xed, 0x6b, 0x76, 0x5a, 0x03, 0x1d, 0x41, 0x63, 0x92,
+	0xaa, 0x20, 0x96, 0xe1, 0x7a, 0xc7, 0x54, 0xac, 0x48, 0xe7, 0x7d, 0xcd, 0xce, 0xe8, 0x20, 0x1b,
+	0xb6, 0x27, 0x39, 0x37, 0xce, 0xa6, 0x4e, 0xbd, 0xd3, 0xca, 0xd8, 0x92, 0x64, 0xfa, 0x9a, 0x9d,
+	0xab, 0x7b, 0xb0, 0x0a, 0xc6, 0x6c, 0x4e, 0x23, 0xc6, 0x7f, 0xeb, 0xb0, 0x9d, 0x49, 0x31, 0xbd,
+	0xe6, 0x8f, 0x61, 0x7d, 0x22, 0xbf, 0x14, 0x9e, 0xd6, 0x66, 0x36, 0x03, 0x73, 0x6f, 0xd1, 0xd7,
+	0x6c, 0x55, 0x1a, 0x7d, 0x0a, 0x1b, 0x13, 0xa5, 0xaa, 0x79, 0x06, 0x77, 0x72, 0x62, 0x8f, 0x0c,
+	0xa4, 0xe4, 0xd1, 0x31, 0xa0, 0x49, 0xa6, 0xfa, 0x78, 0x06, 0xad, 0x82, 0xa8, 0x23, 0x4b, 0x39,
+	0x7a, 0x52, 0xc4, 0x7f, 0x54, 0x60, 0xf7, 0x6b, 0xdf, 0x0d, 0xc9, 0x7f, 0x5d, 0x55, 0x71, 0x40,
+	0xca, 0xc0, 0x31, 0x4b, 0x39, 0x01, 0x29, 0x12, 0x22, 0x20, 0x85, 0x2b, 0xca, 0x4b, 0x1a, 0x14,
+	0xb9, 0xe5, 0x25, 0x9d, 0x8b, 0xf2, 0x92, 0x78, 0x02, 0x95, 0xd2, 0xfa, 0xcd, 0x4a, 0x0e, 0x2a,
+	0x45, 0x42, 0xa0, 0x52, 0xb8, 0x79, 0x2f, 0x67, 0xe5, 0x4a, 0x5e, 0x8e, 0x71, 0x85, 0x2f, 0xa7,
+	0x7a, 0x25, 0x2f, 0xe7, 0xf7, 0x32, 0x34, 0xb3, 0x75, 0x44, 0x2b, 0x37, 0xc6, 0x2f, 0xcd, 0x7c,
+	0x53, 0xcf, 0xc1, 0x2f, 0x9d, 0x0b, 0xfc, 0x12, 0x4f, 0xbc, 0x21, 0x31, 0xa7, 0x73, 0xdf, 0x90,
+	0x38, 0x15, 0x6f, 0x48, 0x70, 0x04, 0x12, 0x69, 0xd6, 0xe6, 0x16, 0x89, 0x74, 0x2e, 0x90, 0x48,
+	0xbc, 0x6c, 0x33, 0xa8, 0x5c, 0xb2, 0x19, 0xac, 0x5c, 0x49, 0x33, 0x30, 0x2e, 0xdd, 0x0c, 0x7e,
+	0x2e, 0xc1, 0xfa, 0xb1, 0x1b, 0x84, 0xe4, 0x02, 0xb3, 0xea, 0x3d, 0xa8, 0x3e, 0x71, 0xbd, 0x90,
+	0xf8, 0x74, 0x53, 0x28, 0xcb, 0x15, 0xa4, 0x58, 0x68, 0x1f, 0x31, 0x21, 0x3b, 0x16, 0xb6, 0x7e,
+	0xd5, 0xc1, 0x88, 0x78, 0xea, 0xb8, 0xd3, 0x97, 0xcd, 0xe2, 0x92, 0x3a, 0x8b, 0xd1, 0x87, 0x60,
+	0x44, 0x25, 0xc6, 0xee, 0x6f, 0xa3, 0xf3, 0xc6, 0x32, 0xdf, 0xed, 0xfd, 0xa8, 0x1a, 0xb9, 0x0a,
+	0xbe, 0x0b, 0x46, 0xc4, 0x41, 0x55, 0x28, 0xef, 0x1f, 0x1f, 0x37, 0x34, 0x04, 0x60, 0x1c, 0xda,
+	0xbd, 0xfd, 0x51, 0xaf, 0xa1, 0xa3, 0x55, 0xa8, 0x0c, 0xf7, 0xbf, 0xea, 0x35, 0x4a, 0x94, 0xdb,
+	0xed, 0x1d, 0xf7, 0x46, 0xbd, 0x46, 0x19, 0xff, 0xa6, 0x43, 0x3d, 0x36, 0xce, 0xf7, 0x8e, 0x7f,
+	0x89, 0xbd, 0x93, 0xc2, 0x6e, 0xa5, 0xb1, 0xcf, 0xbd, 0x45, 0x0a, 0xb2, 0xf4, 0x95, 0x11, 0x6d,
+	0x57, 0x9c, 0xc2, 0x6f, 0x8b, 0x50, 0x92, 0x08, 0x34, 0x11, 0x81, 0x2e, 0x45, 0x50, 0xea, 0xfc,
+	0x54, 0x83, 0xf2, 0xfe, 0x83, 0x01, 0xfa, 0x08, 0x56, 0xe3, 0x45, 0x1b, 0x89, 0x3e, 0x94, 0xda,
+	0xc6, 0xad, 0x66, 0xf6, 0x80, 0x7e, 0x99, 0x6a, 0xe8, 0x04, 0x36, 0xd4, 0x2d, 0x15, 0xdd, 0x58,
+	0xba, 0x26, 0x5b, 0xd7, 0x8b, 0x8e, 0x23, 0x7b, 0x77, 0x61, 0x85, 0x0d, 0x0f, 0xb4, 0xad, 0xcc,
+	0x92, 0x58, 0x1b, 0xa5, 0xb8, 0x91, 0xd2, 0x08, 0x1a, 0xe9, 0x9d, 0x12, 0xa9, 0xb3, 0x28, 0xbb,
+	0xf4, 0x5a, 0x37, 0x8a, 0x05, 0x22, 0xab, 0x7d, 0x58, 0x93, 0xf7, 0x4b, 0x24, 0x90, 0xe7, 0xac,
+	0xa9, 0xd6, 0xff, 0xf2, 0x0f, 0x45, 0x92, 0xd4, 0xfd, 0x2e, 0x49, 0x52, 0xee, 0x5a, 0x69, 0x5d,
+	0x2f, 0x3a, 0x8e, 0xec, 0x3d, 0x84, 0xcd, 0xd4, 0xd2, 0x86, 0xfe, 0x9f, 0x89, 0x46, 0xb5, 0xd8,
+	0x2a, 0x3c, 0x8f, 0x4c, 0xf6, 0xa0, 0x2e, 0x75, 0x5d, 0xb4, 0x64, 0xe2, 0x5a, 0x85, 0x6d, 0x1a,
+	0x6b, 0xe8, 0x13, 0xa8, 0x89, 0xb6, 0x8b, 0x0a, 0x47, 0xad, 0x55, 0xd0, 0xa3, 0x25, 0x1c, 0x51,
+	0x7f, 0x45, 0x4b, 0x66, 0xac, 0x55, 0xd8, 0xa4, 0xb1, 0x46, 0x8b, 0x3a, 0xee, 0xba, 0xa8, 0x68,
+	0xb8, 0x5a, 0xf9, 0x0d, 0x5a, 0x8a, 0x82, 0xf6, 0x45, 0x54, 0x38, 0x55, 0xad, 0x82, 0x06, 0x8d,
+	0x35, 0x74, 0x1f, 0xd6, 0x05, 0x8f, 0x36, 0x56, 0xb4, 0x74, 0x9c, 0x5a, 0x4b, 0x7a, 0x34, 0xd6,
+	0xd0, 0x97, 0xb0, 0x99, 0xfa, 0x06, 0x4d, 0x6e, 0x3b, 0xff, 0xfb, 0xdf, 0x6a, 0x15, 0x9e, 0x33,
+	0x93, 0x7b, 0xfa, 0x1d, 0x1d, 0x7d, 0x03, 0x8d, 0xf4, 0x80, 0x4e, 0x1e, 0x4d, 0xc1, 0x27, 0xa0,
+	0x75, 0xa3, 0x58, 0x20, 0xb1, 0xfc, 0x3e, 0x18, 0x51, 0xef, 0x42, 0xcd, 0xdc, 0x3e, 0x6c, 0x6d,
+	0xe5, 0xb4, 0x38, 0xac, 0xdd, 0xd1, 0x0f, 0x6e, 0xc3, 0xae, 0x3b, 0x6b, 0x87, 0xe4, 0x65, 0xe8,
+	0x7a, 0xa4, 0x1d, 0x9e, 0xf9, 0xc4, 0x19, 0x07, 0xdf, 0x9f, 0xfa, 0xf3, 0xc7, 0x07, 0xd5, 0x51,
+	0x44, 0x3d, 0xd0, 0x7f, 0x29, 0xad, 0x8c, 0xfa, 0x76, 0x77, 0xf8, 0xc8, 0x60, 0x7f, 0x22, 0xde,
+	0xfd, 0x67, 0x00, 0x34, 0xa1, 0x8f, 0xe5, 0x51, 0x14, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1951,6 +2132,8 @@ type APIClient interface {
 	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartReply, error)
 	StartFromAddress(ctx context.Context, in *StartFromAddressRequest, opts ...grpc.CallOption) (*StartFromAddressReply, error)
 	GetStoreLink(ctx context.Context, in *GetStoreLinkRequest, opts ...grpc.CallOption) (*GetStoreLinkReply, error)
+	GetStoreInvite(ctx context.Context, in *GetStoreInviteRequest, opts ...grpc.CallOption) (*GetStoreInviteReply, error)
+	StartFromInvite(ctx context.Context, in *StartFromInviteRequest, opts ...grpc.CallOption) (*StartFromInviteReply, error)
 	ModelCreate(ctx context.Context, in *ModelCreateRequest, opts ...grpc.CallOption) (*ModelCreateReply, error)
 	ModelSave(ctx context.Context, in *ModelSaveRequest, opts ...grpc.CallOption) (*ModelSaveReply, error)
 	ModelDelete(ctx context.Context, in *ModelDeleteRequest, opts ...grpc.CallOption) (*ModelDeleteReply, error)
@@ -2015,6 +2198,24 @@ func (c *aPIClient) GetStoreLink(ctx context.Context, in *GetStoreLinkRequest, o
 	return out, nil
 }
 
+func (c *aPIClient) GetStoreInvite(ctx context.Context, in *GetStoreInviteRequest, opts ...grpc.CallOption) (*GetStoreInviteReply, error) {
+	out := new(GetStoreInviteReply)
+	err := c.cc.Invoke(ctx, "/api.pb.API/GetStoreInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) StartFromInvite(ctx context.Context, in *StartFromInviteRequest, opts ...grpc.CallOption) (*StartFromInviteReply, error) {
+	out := new(StartFromInviteReply)
+	err := c.cc.Invoke(ctx, "/api.pb.API/StartFromInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) ModelCreate(ctx context.Context, in *ModelCreateRequest, opts ...grpc.CallOption) (*ModelCreateReply, error) {
 	out := new(ModelCreateReply)
 	err := c.cc.Invoke(ctx, "/api.pb.API/ModelCreate", in, out, opts...)
@@ -2170,6 +2371,8 @@ type APIServer interface {
 	Start(context.Context, *StartRequest) (*StartReply, error)
 	StartFromAddress(context.Context, *StartFromAddressRequest) (*StartFromAddressReply, error)
 	GetStoreLink(context.Context, *GetStoreLinkRequest) (*GetStoreLinkReply, error)
+	GetStoreInvite(context.Context, *GetStoreInviteRequest) (*GetStoreInviteReply, error)
+	StartFromInvite(context.Context, *StartFromInviteRequest) (*StartFromInviteReply, error)
 	ModelCreate(context.Context, *ModelCreateRequest) (*ModelCreateReply, error)
 	ModelSave(context.Context, *ModelSaveRequest) (*ModelSaveReply, error)
 	ModelDelete(context.Context, *ModelDeleteRequest) (*ModelDeleteReply, error)
@@ -2200,6 +2403,12 @@ func (*UnimplementedAPIServer) StartFromAddress(ctx context.Context, req *StartF
 func (*UnimplementedAPIServer) GetStoreLink(ctx context.Context, req *GetStoreLinkRequest) (*GetStoreLinkReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStoreLink not implemented")
 }
+func (*UnimplementedAPIServer) GetStoreInvite(ctx context.Context, req *GetStoreInviteRequest) (*GetStoreInviteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStoreInvite not implemented")
+}
+func (*UnimplementedAPIServer) StartFromInvite(ctx context.Context, req *StartFromInviteRequest) (*StartFromInviteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartFromInvite not implemented")
+}
 func (*UnimplementedAPIServer) ModelCreate(ctx context.Context, req *ModelCreateRequest) (*ModelCreateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ModelCreate not implemented")
 }
@@ -2322,6 +2531,42 @@ func _API_GetStoreLink_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetStoreInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStoreInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetStoreInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.pb.API/GetStoreInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetStoreInvite(ctx, req.(*GetStoreInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_StartFromInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartFromInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).StartFromInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.pb.API/StartFromInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).StartFromInvite(ctx, req.(*StartFromInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_ModelCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ModelCreateRequest)
 	if err := dec(in); err != nil {
@@ -2527,6 +2772,14 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStoreLink",
 			Handler:    _API_GetStoreLink_Handler,
 		},
+		{
+			MethodName: "GetStoreInvite",
+			Handler:    _API_GetStoreInvite_Handler,
+		},
+		{
+			MethodName: "StartFromInvite",
+			Handler:    _API_StartFromInvite_Handler,
+		},
 		{
 			MethodName: "ModelCreate",
 			Handler:    _API_ModelCreate_Handler,
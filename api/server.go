@@ -11,13 +11,16 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 	pb "github.com/textileio/go-threads/api/pb"
 	core "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/metrics"
 	"github.com/textileio/go-threads/store"
+	"github.com/textileio/go-threads/tracing"
 	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
 )
 
 var (
-	log = logging.Logger("threadsapi")
+	log    = logging.Logger("threadsapi")
+	tracer = tracing.Tracer("threadsapi")
 )
 
 // Server provides a gRPC API to a store manager.
@@ -60,6 +63,8 @@ func NewServer(ctx context.Context, ts core.Service, conf Config, opts ...grpc.S
 		return nil, err
 	}
 
+	metrics.RegisterAPI()
+
 	ctx, cancel := context.WithCancel(ctx)
 	s := &Server{
 		rpc:     grpc.NewServer(opts...),
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	pb "github.com/textileio/go-threads/api/pb"
 	corestore "github.com/textileio/go-threads/core/store"
 	"github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/metrics"
 	"github.com/textileio/go-threads/store"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -22,7 +25,10 @@ type service struct {
 }
 
 // NewStore adds a new store into the manager.
-func (s *service) NewStore(context.Context, *pb.NewStoreRequest) (*pb.NewStoreReply, error) {
+func (s *service) NewStore(ctx context.Context, _ *pb.NewStoreRequest) (*pb.NewStoreReply, error) {
+	_, span := tracer.Start(ctx, "NewStore")
+	defer span.End()
+
 	log.Debugf("received new store request")
 
 	id, _, err := s.manager.NewStore()
@@ -36,7 +42,10 @@ func (s *service) NewStore(context.Context, *pb.NewStoreRequest) (*pb.NewStoreRe
 }
 
 // RegisterSchema registers a JSON schema with a store.
-func (s *service) RegisterSchema(_ context.Context, req *pb.RegisterSchemaRequest) (*pb.RegisterSchemaReply, error) {
+func (s *service) RegisterSchema(ctx context.Context, req *pb.RegisterSchemaRequest) (*pb.RegisterSchemaReply, error) {
+	_, span := tracer.Start(ctx, "RegisterSchema")
+	defer span.End()
+
 	log.Debugf("received register schema request in store %s", req.StoreID)
 
 	st, err := s.getStore(req.StoreID)
@@ -57,7 +66,10 @@ func (s *service) RegisterSchema(_ context.Context, req *pb.RegisterSchemaReques
 	return &pb.RegisterSchemaReply{}, nil
 }
 
-func (s *service) Start(_ context.Context, req *pb.StartRequest) (*pb.StartReply, error) {
+func (s *service) Start(ctx context.Context, req *pb.StartRequest) (*pb.StartReply, error) {
+	_, span := tracer.Start(ctx, "Start")
+	defer span.End()
+
 	st, err := s.getStore(req.GetStoreID())
 	if err != nil {
 		return nil, err
@@ -69,6 +81,9 @@ func (s *service) Start(_ context.Context, req *pb.StartRequest) (*pb.StartReply
 }
 
 func (s *service) GetStoreLink(ctx context.Context, req *pb.GetStoreLinkRequest) (*pb.GetStoreLinkReply, error) {
+	ctx, span := tracer.Start(ctx, "GetStoreLink")
+	defer span.End()
+
 	var err error
 	var st *store.Store
 	if st, err = s.getStore(req.GetStoreID()); err != nil {
@@ -98,7 +113,10 @@ func (s *service) GetStoreLink(ctx context.Context, req *pb.GetStoreLinkRequest)
 	return reply, nil
 }
 
-func (s *service) StartFromAddress(_ context.Context, req *pb.StartFromAddressRequest) (*pb.StartFromAddressReply, error) {
+func (s *service) StartFromAddress(ctx context.Context, req *pb.StartFromAddressRequest) (*pb.StartFromAddressReply, error) {
+	_, span := tracer.Start(ctx, "StartFromAddress")
+	defer span.End()
+
 	var err error
 	var st *store.Store
 	var addr ma.Multiaddr
@@ -121,8 +139,53 @@ func (s *service) StartFromAddress(_ context.Context, req *pb.StartFromAddressRe
 	return &pb.StartFromAddressReply{}, nil
 }
 
+// GetStoreInvite returns an invite to a store's thread for the given peer.
+// The thread keys are sealed to the peer, so they never leave this host in plaintext.
+func (s *service) GetStoreInvite(ctx context.Context, req *pb.GetStoreInviteRequest) (*pb.GetStoreInviteReply, error) {
+	ctx, span := tracer.Start(ctx, "GetStoreInvite")
+	defer span.End()
+
+	var err error
+	var st *store.Store
+	if st, err = s.getStore(req.GetStoreID()); err != nil {
+		return nil, err
+	}
+	tid, _, err := st.ThreadID()
+	if err != nil {
+		return nil, err
+	}
+	invitee, err := peer.IDFromBytes(req.GetInvitee())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	invite, err := st.Service().CreateInvite(ctx, tid, invitee, time.Duration(req.GetTtl()))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetStoreInviteReply{Invite: invite}, nil
+}
+
+// StartFromInvite starts a store from an invite to its thread.
+func (s *service) StartFromInvite(ctx context.Context, req *pb.StartFromInviteRequest) (*pb.StartFromInviteReply, error) {
+	_, span := tracer.Start(ctx, "StartFromInvite")
+	defer span.End()
+
+	var err error
+	var st *store.Store
+	if st, err = s.getStore(req.GetStoreID()); err != nil {
+		return nil, err
+	}
+	if err = st.StartFromInvite(req.GetInvite()); err != nil {
+		return nil, err
+	}
+	return &pb.StartFromInviteReply{}, nil
+}
+
 // ModelCreate adds a new instance of a model to a store.
-func (s *service) ModelCreate(_ context.Context, req *pb.ModelCreateRequest) (*pb.ModelCreateReply, error) {
+func (s *service) ModelCreate(ctx context.Context, req *pb.ModelCreateRequest) (*pb.ModelCreateReply, error) {
+	_, span := tracer.Start(ctx, "ModelCreate")
+	defer span.End()
+
 	log.Debugf("received model create request for model %s", req.ModelName)
 	model, err := s.getModel(req.StoreID, req.ModelName)
 	if err != nil {
@@ -131,7 +194,10 @@ func (s *service) ModelCreate(_ context.Context, req *pb.ModelCreateRequest) (*p
 	return s.processCreateRequest(req, model.Create)
 }
 
-func (s *service) ModelSave(_ context.Context, req *pb.ModelSaveRequest) (*pb.ModelSaveReply, error) {
+func (s *service) ModelSave(ctx context.Context, req *pb.ModelSaveRequest) (*pb.ModelSaveReply, error) {
+	_, span := tracer.Start(ctx, "ModelSave")
+	defer span.End()
+
 	model, err := s.getModel(req.StoreID, req.ModelName)
 	if err != nil {
 		return nil, err
@@ -139,7 +205,10 @@ func (s *service) ModelSave(_ context.Context, req *pb.ModelSaveRequest) (*pb.Mo
 	return s.processSaveRequest(req, model.Save)
 }
 
-func (s *service) ModelDelete(_ context.Context, req *pb.ModelDeleteRequest) (*pb.ModelDeleteReply, error) {
+func (s *service) ModelDelete(ctx context.Context, req *pb.ModelDeleteRequest) (*pb.ModelDeleteReply, error) {
+	_, span := tracer.Start(ctx, "ModelDelete")
+	defer span.End()
+
 	model, err := s.getModel(req.StoreID, req.ModelName)
 	if err != nil {
 		return nil, err
@@ -147,7 +216,10 @@ func (s *service) ModelDelete(_ context.Context, req *pb.ModelDeleteRequest) (*p
 	return s.processDeleteRequest(req, model.Delete)
 }
 
-func (s *service) ModelHas(_ context.Context, req *pb.ModelHasRequest) (*pb.ModelHasReply, error) {
+func (s *service) ModelHas(ctx context.Context, req *pb.ModelHasRequest) (*pb.ModelHasReply, error) {
+	_, span := tracer.Start(ctx, "ModelHas")
+	defer span.End()
+
 	model, err := s.getModel(req.StoreID, req.ModelName)
 	if err != nil {
 		return nil, err
@@ -155,7 +227,10 @@ func (s *service) ModelHas(_ context.Context, req *pb.ModelHasRequest) (*pb.Mode
 	return s.processHasRequest(req, model.Has)
 }
 
-func (s *service) ModelFind(_ context.Context, req *pb.ModelFindRequest) (*pb.ModelFindReply, error) {
+func (s *service) ModelFind(ctx context.Context, req *pb.ModelFindRequest) (*pb.ModelFindReply, error) {
+	_, span := tracer.Start(ctx, "ModelFind")
+	defer span.End()
+
 	model, err := s.getModel(req.StoreID, req.ModelName)
 	if err != nil {
 		return nil, err
@@ -163,7 +238,10 @@ func (s *service) ModelFind(_ context.Context, req *pb.ModelFindRequest) (*pb.Mo
 	return s.processFindRequest(req, model.FindJSON)
 }
 
-func (s *service) ModelFindByID(_ context.Context, req *pb.ModelFindByIDRequest) (*pb.ModelFindByIDReply, error) {
+func (s *service) ModelFindByID(ctx context.Context, req *pb.ModelFindByIDRequest) (*pb.ModelFindByIDReply, error) {
+	_, span := tracer.Start(ctx, "ModelFindByID")
+	defer span.End()
+
 	model, err := s.getModel(req.StoreID, req.ModelName)
 	if err != nil {
 		return nil, err
@@ -172,6 +250,10 @@ func (s *service) ModelFindByID(_ context.Context, req *pb.ModelFindByIDRequest)
 }
 
 func (s *service) ReadTransaction(stream pb.API_ReadTransactionServer) error {
+	_, span := tracer.Start(stream.Context(), "ReadTransaction")
+	defer span.End()
+	defer metrics.TrackStream("store", "ReadTransaction")()
+
 	firstReq, err := stream.Recv()
 	if err != nil {
 		return err
@@ -240,6 +322,10 @@ func (s *service) ReadTransaction(stream pb.API_ReadTransactionServer) error {
 }
 
 func (s *service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
+	_, span := tracer.Start(stream.Context(), "WriteTransaction")
+	defer span.End()
+	defer metrics.TrackStream("store", "WriteTransaction")()
+
 	firstReq, err := stream.Recv()
 	if err != nil {
 		return err
@@ -336,6 +422,10 @@ func (s *service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 
 // Listen returns a stream of entities, trigged by a local or remote state change.
 func (s *service) Listen(req *pb.ListenRequest, server pb.API_ListenServer) error {
+	_, span := tracer.Start(server.Context(), "Listen")
+	defer span.End()
+	defer metrics.TrackStream("store", "Listen")()
+
 	st, err := s.getStore(req.StoreID)
 	if err != nil {
 		return err
@@ -40,6 +40,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/textileio/go-threads/metrics"
 )
 
 // ErrClosedChannel means the caller attempted to send to one or more closed broadcast channels.
@@ -79,6 +80,7 @@ func (b *Broadcaster) SendWithTimeout(v interface{}, timeout time.Duration) erro
 		case l <- v:
 			// Success!
 		case <-time.After(timeout):
+			metrics.BroadcastSendTimeouts.Inc()
 			err := fmt.Sprintf("unable to send to listener '%d'", id)
 			result = multierror.Append(result, errors.New(err))
 		}
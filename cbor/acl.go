@@ -0,0 +1,98 @@
+package cbor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crypto"
+)
+
+func init() {
+	cbornode.RegisterCborType(acl{})
+}
+
+// acl defines the node structure of an access control list.
+type acl struct {
+	Version uint64
+	Roles   map[string]int
+}
+
+// CreateACLEvent creates a new event holding an access control list.
+// Its header is encrypted with the follow-key fkey, which belongs to the given
+// key epoch, so that any follower can enforce the list. See CreateEvent for
+// seen and opts.
+func CreateACLEvent(
+	ctx context.Context,
+	dag format.DAGService,
+	list thread.ACL,
+	fkey crypto.EncryptionKey,
+	epoch uint64,
+	seen map[cid.Cid]uint64,
+	opts ...service.EventOption,
+) (*Event, error) {
+	body, err := cbornode.WrapObject(aclToObj(list), mh.SHA2_256, -1)
+	if err != nil {
+		return nil, err
+	}
+	return createEvent(ctx, dag, body, fkey, epoch, seen, true, opts...)
+}
+
+// ACLFromEvent returns the access control list held by an event.
+// The key must be the follow-key matching the event's key epoch.
+func ACLFromEvent(
+	ctx context.Context,
+	dag format.DAGService,
+	event *Event,
+	fkey crypto.DecryptionKey,
+) (list thread.ACL, err error) {
+	if !event.IsACL() {
+		return list, fmt.Errorf("event does not hold an access control list")
+	}
+	body, err := event.GetBody(ctx, dag, fkey)
+	if err != nil {
+		return
+	}
+	return UnmarshalACL(body.RawData())
+}
+
+// MarshalACL returns the cbor encoding of an access control list.
+func MarshalACL(list thread.ACL) ([]byte, error) {
+	return cbornode.DumpObject(aclToObj(list))
+}
+
+// UnmarshalACL decodes an access control list from cbor.
+func UnmarshalACL(data []byte) (list thread.ACL, err error) {
+	obj := new(acl)
+	if err = cbornode.DecodeInto(data, obj); err != nil {
+		return
+	}
+	list.Version = obj.Version
+	list.Roles = make(map[peer.ID]thread.Role, len(obj.Roles))
+	for k, r := range obj.Roles {
+		id, err := peer.Decode(k)
+		if err != nil {
+			return list, err
+		}
+		list.Roles[id] = thread.Role(r)
+	}
+	return list, nil
+}
+
+// aclToObj returns the node structure of an access control list.
+func aclToObj(list thread.ACL) *acl {
+	obj := &acl{
+		Version: list.Version,
+		Roles:   make(map[string]int, len(list.Roles)),
+	}
+	for id, r := range list.Roles {
+		obj.Roles[id.String()] = int(r)
+	}
+	return obj
+}
@@ -0,0 +1,120 @@
+package cbor
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/crypto"
+)
+
+func init() {
+	cbornode.RegisterCborType(bodyChunks{})
+}
+
+// DefaultChunkSize is the size of the chunks that event bodies larger than
+// it are split into.
+var DefaultChunkSize = 256 << 10
+
+// bodyChunks defines the node structure of an event body split into
+// encrypted chunks. The node itself isn't encrypted so that the chunks can
+// be fetched by following its links.
+type bodyChunks struct {
+	Size   uint64
+	Chunks []cid.Cid
+}
+
+// encodeChunks splits data into chunks of size bytes, each encrypted with key,
+// and returns the node linking them in order with the chunk nodes.
+func encodeChunks(data []byte, key crypto.EncryptionKey, size int) (format.Node, []format.Node, error) {
+	if size <= 0 {
+		return nil, nil, fmt.Errorf("invalid chunk size %d", size)
+	}
+	obj := &bodyChunks{Size: uint64(len(data))}
+	var chunks []format.Node
+	for len(data) > 0 {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		coded, err := key.Encrypt(data[:n])
+		if err != nil {
+			return nil, nil, err
+		}
+		chunk, err := cbornode.WrapObject(coded, mh.SHA2_256, -1)
+		if err != nil {
+			return nil, nil, err
+		}
+		chunks = append(chunks, chunk)
+		obj.Chunks = append(obj.Chunks, chunk.Cid())
+		data = data[n:]
+	}
+	root, err := cbornode.WrapObject(obj, mh.SHA2_256, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return root, chunks, nil
+}
+
+// chunkReader reads a chunked body, fetching and decrypting one chunk at a time.
+type chunkReader struct {
+	ctx    context.Context
+	dag    format.DAGService
+	key    crypto.DecryptionKey
+	chunks []cid.Cid
+	size   uint64
+	read   uint64
+	buf    []byte
+}
+
+// newChunkReader returns a reader of the chunked body linked from root.
+func newChunkReader(
+	ctx context.Context,
+	dag format.DAGService,
+	root format.Node,
+	key crypto.DecryptionKey,
+) (*chunkReader, error) {
+	obj := new(bodyChunks)
+	if err := cbornode.DecodeInto(root.RawData(), obj); err != nil {
+		return nil, err
+	}
+	return &chunkReader{
+		ctx:    ctx,
+		dag:    dag,
+		key:    key,
+		chunks: obj.Chunks,
+		size:   obj.Size,
+	}, nil
+}
+
+// Read reads the next bytes of the body.
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if len(r.chunks) == 0 {
+			if r.read != r.size {
+				return 0, fmt.Errorf("body size mismatch: expected %d got %d", r.size, r.read)
+			}
+			return 0, io.EOF
+		}
+		node, err := r.dag.Get(r.ctx, r.chunks[0])
+		if err != nil {
+			return 0, err
+		}
+		var coded []byte
+		if err = cbornode.DecodeInto(node.RawData(), &coded); err != nil {
+			return 0, err
+		}
+		if r.buf, err = r.key.Decrypt(coded); err != nil {
+			return 0, err
+		}
+		r.chunks = r.chunks[1:]
+		r.read += uint64(len(r.buf))
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
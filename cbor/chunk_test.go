@@ -0,0 +1,44 @@
+package cbor
+
+import (
+	"bytes"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/textileio/go-threads/crypto/symmetric"
+)
+
+func TestEncodeChunks(t *testing.T) {
+	key, err := symmetric.CreateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 64)
+	root, chunks, err := encodeChunks(data, key, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 2 || len(root.Links()) != 2 {
+		t.Fatalf("expected 2 chunks got %d", len(chunks))
+	}
+
+	// Chunks of equal plaintext must not share a nonce
+	var nonces [][]byte
+	for _, c := range chunks {
+		var coded []byte
+		if err = cbornode.DecodeInto(c.RawData(), &coded); err != nil {
+			t.Fatal(err)
+		}
+		alg, ok := symmetric.EnvelopeAlgorithm(coded)
+		if !ok || alg != symmetric.AES256GCM {
+			t.Fatalf("expected %s envelope got %s", symmetric.AES256GCM, alg)
+		}
+		nonces = append(nonces, coded[2:14])
+	}
+	if bytes.Equal(nonces[0], nonces[1]) {
+		t.Fatal("expected chunks to use different nonces")
+	}
+	if chunks[0].Cid().Equals(chunks[1].Cid()) {
+		t.Fatal("expected chunks of equal plaintext to differ")
+	}
+}
@@ -1,6 +1,8 @@
 package cbor
 
 import (
+	"fmt"
+
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-ipld-cbor"
 	format "github.com/ipfs/go-ipld-format"
@@ -8,7 +10,19 @@ import (
 	"github.com/textileio/go-threads/crypto"
 )
 
+func init() {
+	cbornode.RegisterCborType(epochBlock{})
+}
+
+// epochBlock defines the node structure of a block encrypted with a key
+// from a key epoch other than zero.
+type epochBlock struct {
+	Epoch uint64
+	Data  []byte
+}
+
 // EncodeBlock returns a node by encrypting the block's raw bytes with key.
+// Symmetric keys put the ciphertext in an envelope naming its algorithm.
 func EncodeBlock(block blocks.Block, key crypto.EncryptionKey) (format.Node, error) {
 	coded, err := key.Encrypt(block.RawData())
 	if err != nil {
@@ -17,10 +31,46 @@ func EncodeBlock(block blocks.Block, key crypto.EncryptionKey) (format.Node, err
 	return cbornode.WrapObject(coded, mh.SHA2_256, -1)
 }
 
+// EncodeBlockAt returns a node by encrypting the block's raw bytes with key,
+// which belongs to the given key epoch. Blocks at epoch zero are encoded
+// exactly like EncodeBlock.
+func EncodeBlockAt(block blocks.Block, key crypto.EncryptionKey, epoch uint64) (format.Node, error) {
+	if epoch == 0 {
+		return EncodeBlock(block, key)
+	}
+	coded, err := key.Encrypt(block.RawData())
+	if err != nil {
+		return nil, err
+	}
+	return cbornode.WrapObject(&epochBlock{Epoch: epoch, Data: coded}, mh.SHA2_256, -1)
+}
+
 // DecodeBlock returns a node by decrypting the block's raw bytes with key.
+// Symmetric keys pick the algorithm from the ciphertext envelope, and blocks
+// encoded before envelopes existed are still decoded.
 func DecodeBlock(block blocks.Block, key crypto.DecryptionKey) (format.Node, error) {
-	var raw []byte
-	err := cbornode.DecodeInto(block.RawData(), &raw)
+	_, raw, err := decodeEpochBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := key.Decrypt(raw)
+	if err != nil {
+		return nil, err
+	}
+	return cbornode.Decode(decoded, mh.SHA2_256, -1)
+}
+
+// DecodeBlockAt returns a node by decrypting the block's raw bytes with the
+// key from keys matching the block's key epoch.
+func DecodeBlockAt(block blocks.Block, keys crypto.KeyRing) (format.Node, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("decryption key is required")
+	}
+	epoch, raw, err := decodeEpochBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keys.KeyAt(epoch)
 	if err != nil {
 		return nil, err
 	}
@@ -30,3 +80,23 @@ func DecodeBlock(block blocks.Block, key crypto.DecryptionKey) (format.Node, err
 	}
 	return cbornode.Decode(decoded, mh.SHA2_256, -1)
 }
+
+// BlockEpoch returns the key epoch of an encoded block.
+func BlockEpoch(block blocks.Block) (uint64, error) {
+	epoch, _, err := decodeEpochBlock(block)
+	return epoch, err
+}
+
+// decodeEpochBlock returns the key epoch and encrypted bytes of a block.
+func decodeEpochBlock(block blocks.Block) (uint64, []byte, error) {
+	var raw []byte
+	err := cbornode.DecodeInto(block.RawData(), &raw)
+	if err == nil {
+		return 0, raw, nil
+	}
+	eb := new(epochBlock)
+	if err := cbornode.DecodeInto(block.RawData(), eb); err != nil {
+		return 0, nil, err
+	}
+	return eb.Epoch, eb.Data, nil
+}
@@ -1,13 +1,18 @@
 package cbor
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
 	"time"
 
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	format "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/peer"
 	mh "github.com/multiformats/go-multihash"
 	"github.com/textileio/go-threads/core/service"
 	"github.com/textileio/go-threads/crypto"
@@ -21,28 +26,83 @@ func init() {
 
 // event defines the node structure of an event.
 type event struct {
-	Body   cid.Cid
-	Header cid.Cid
+	Body    cid.Cid
+	Header  cid.Cid
+	Epoch   uint64 `refmt:",omitempty"`
+	ACL     bool   `refmt:",omitempty"`
+	Chunked bool   `refmt:",omitempty"`
 }
 
 // eventHeader defines the node structure of an event header.
 type eventHeader struct {
-	Time int64
-	Key  []byte `refmt:",omitempty"`
+	Time          int64
+	Key           []byte    `refmt:",omitempty"`
+	Clock         uint64    `refmt:",omitempty"`
+	Deps          []cid.Cid `refmt:",omitempty"`
+	ContentType   string    `refmt:",omitempty"`
+	Codec         string    `refmt:",omitempty"`
+	SchemaVersion string    `refmt:",omitempty"`
+	Author        []byte    `refmt:",omitempty"`
 }
 
 // CreateEvent create a new event by wrapping the body node.
+// The header is encrypted with rkey, which belongs to the given key epoch.
+// Seen maps the heads this peer has seen across the thread's logs to their
+// logical clocks. The event's clock is one more than the highest of them,
+// and the heads are recorded as the event's dependencies.
+// Options add optional metadata to the header. Bodies larger than the chunk
+// size are stored as a DAG of encrypted chunks.
 func CreateEvent(
 	ctx context.Context,
 	dag format.DAGService,
 	body format.Node,
 	rkey crypto.EncryptionKey,
+	epoch uint64,
+	seen map[cid.Cid]uint64,
+	opts ...service.EventOption,
 ) (service.Event, error) {
+	event, err := createEvent(ctx, dag, body, rkey, epoch, seen, false, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// createEvent creates a new event, optionally marked as an access control list event.
+func createEvent(
+	ctx context.Context,
+	dag format.DAGService,
+	body format.Node,
+	rkey crypto.EncryptionKey,
+	epoch uint64,
+	seen map[cid.Cid]uint64,
+	acl bool,
+	opts ...service.EventOption,
+) (*Event, error) {
+	args := &service.EventOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
 	key, err := symmetric.CreateKey()
 	if err != nil {
 		return nil, err
 	}
-	codedBody, err := EncodeBlock(body, key)
+	// Bodies use the cipher of the header
+	if rk, ok := rkey.(*symmetric.Key); ok {
+		key = key.WithAlgorithm(rk.Algorithm())
+	}
+	chunkSize := args.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	var codedBody format.Node
+	var chunks []format.Node
+	chunked := chunkSize > 0 && len(body.RawData()) > chunkSize
+	if chunked {
+		codedBody, chunks, err = encodeChunks(body.RawData(), key, chunkSize)
+	} else {
+		codedBody, err = EncodeBlock(body, key)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -50,21 +110,33 @@ func CreateEvent(
 	if err != nil {
 		return nil, err
 	}
+	clock, deps := nextClock(seen)
 	eventHeader := &eventHeader{
-		Time: time.Now().Unix(),
-		Key:  keyb,
+		Time:          time.Now().Unix(),
+		Key:           keyb,
+		Clock:         clock,
+		Deps:          deps,
+		ContentType:   args.ContentType,
+		Codec:         args.Codec,
+		SchemaVersion: args.SchemaVersion,
+	}
+	if args.Author != "" {
+		eventHeader.Author = []byte(args.Author)
 	}
 	header, err := cbornode.WrapObject(eventHeader, mh.SHA2_256, -1)
 	if err != nil {
 		return nil, err
 	}
-	codedHeader, err := EncodeBlock(header, rkey)
+	codedHeader, err := EncodeBlockAt(header, rkey, epoch)
 	if err != nil {
 		return nil, err
 	}
 	obj := &event{
-		Body:   codedBody.Cid(),
-		Header: codedHeader.Cid(),
+		Body:    codedBody.Cid(),
+		Header:  codedHeader.Cid(),
+		Epoch:   epoch,
+		ACL:     acl,
+		Chunked: chunked,
 	}
 	node, err := cbornode.WrapObject(obj, mh.SHA2_256, -1)
 	if err != nil {
@@ -72,7 +144,8 @@ func CreateEvent(
 	}
 
 	if dag != nil {
-		if err = dag.AddMany(ctx, []format.Node{node, codedHeader, codedBody}); err != nil {
+		nodes := append([]format.Node{node, codedHeader, codedBody}, chunks...)
+		if err = dag.AddMany(ctx, nodes); err != nil {
 			return nil, err
 		}
 	}
@@ -88,6 +161,23 @@ func CreateEvent(
 	}, nil
 }
 
+// nextClock returns the logical clock that follows the seen clocks,
+// and the seen heads in a deterministic order.
+func nextClock(seen map[cid.Cid]uint64) (uint64, []cid.Cid) {
+	var clock uint64
+	deps := make([]cid.Cid, 0, len(seen))
+	for c, t := range seen {
+		if t > clock {
+			clock = t
+		}
+		deps = append(deps, c)
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].KeyString() < deps[j].KeyString()
+	})
+	return clock + 1, deps
+}
+
 // GetEvent returns the event node for the given cid.
 func GetEvent(ctx context.Context, dag format.DAGService, id cid.Cid) (service.Event, error) {
 	node, err := dag.Get(ctx, id)
@@ -137,6 +227,17 @@ func (e *Event) HeaderID() cid.Cid {
 	return e.obj.Header
 }
 
+// KeyEpoch returns the epoch of the read-key used to encrypt the header.
+func (e *Event) KeyEpoch() uint64 {
+	return e.obj.Epoch
+}
+
+// IsACL returns whether or not the event holds an access control list.
+// The header of these events is encrypted with the follow-key instead of the read-key.
+func (e *Event) IsACL() bool {
+	return e.obj.ACL
+}
+
 // GetHeader returns the header node.
 func (e *Event) GetHeader(
 	ctx context.Context,
@@ -176,33 +277,81 @@ func (e *Event) BodyID() cid.Cid {
 	return e.obj.Body
 }
 
-// GetBody returns the body node.
+// IsChunked returns whether or not the body is split into chunks.
+func (e *Event) IsChunked() bool {
+	return e.obj.Chunked
+}
+
+// GetBody returns the body node. Chunks of chunked bodies are fetched
+// when the body is decrypted. Without a key, the node linking the chunks
+// is returned.
 func (e *Event) GetBody(ctx context.Context, dag format.DAGService, key crypto.DecryptionKey) (format.Node, error) {
-	var k crypto.DecryptionKey
-	if key != nil {
-		header, err := e.GetHeader(ctx, dag, key)
+	k, err := e.bodyKey(ctx, dag, key)
+	if err != nil {
+		return nil, err
+	}
+	if e.body == nil {
+		e.body, err = dag.Get(ctx, e.obj.Body)
 		if err != nil {
 			return nil, err
 		}
-		k, err = header.Key()
+	}
+
+	if k == nil {
+		return e.body, nil
+	}
+	if !e.obj.Chunked {
+		return DecodeBlock(e.body, k)
+	}
+	r, err := newChunkReader(ctx, dag, e.body, k)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return cbornode.Decode(data, mh.SHA2_256, -1)
+}
+
+// BodyReader returns a reader of the decrypted body's raw bytes. Chunks of
+// chunked bodies are fetched as they are read.
+func (e *Event) BodyReader(ctx context.Context, dag format.DAGService, key crypto.DecryptionKey) (io.Reader, error) {
+	if key == nil {
+		return nil, fmt.Errorf("decryption key is required")
+	}
+	if !e.obj.Chunked {
+		body, err := e.GetBody(ctx, dag, key)
 		if err != nil {
 			return nil, err
 		}
+		return bytes.NewReader(body.RawData()), nil
 	}
 
-	var err error
+	k, err := e.bodyKey(ctx, dag, key)
+	if err != nil {
+		return nil, err
+	}
 	if e.body == nil {
 		e.body, err = dag.Get(ctx, e.obj.Body)
 		if err != nil {
 			return nil, err
 		}
 	}
+	return newChunkReader(ctx, dag, e.body, k)
+}
 
-	if k == nil {
-		return e.body, nil
-	} else {
-		return DecodeBlock(e.body, k)
+// bodyKey returns the body key from the header decrypted with key.
+// It's nil if key is nil.
+func (e *Event) bodyKey(ctx context.Context, dag format.DAGService, key crypto.DecryptionKey) (crypto.DecryptionKey, error) {
+	if key == nil {
+		return nil, nil
+	}
+	header, err := e.GetHeader(ctx, dag, key)
+	if err != nil {
+		return nil, err
 	}
+	return header.Key()
 }
 
 // EventHeader is an IPLD node representing an event header.
@@ -228,3 +377,59 @@ func (h *EventHeader) Key() (crypto.DecryptionKey, error) {
 	}
 	return crypto.ParseDecryptionKey(h.obj.Key)
 }
+
+// Clock returns the logical clock of the event if it has been decoded.
+// Events created before clocks were introduced have a zero clock.
+func (h *EventHeader) Clock() (uint64, error) {
+	if h.obj == nil {
+		return 0, fmt.Errorf("obj not loaded")
+	}
+	return h.obj.Clock, nil
+}
+
+// Deps returns the heads that were seen when the event was created
+// if it has been decoded.
+func (h *EventHeader) Deps() ([]cid.Cid, error) {
+	if h.obj == nil {
+		return nil, fmt.Errorf("obj not loaded")
+	}
+	return h.obj.Deps, nil
+}
+
+// ContentType returns the format of the event body if it has been decoded.
+func (h *EventHeader) ContentType() (string, error) {
+	if h.obj == nil {
+		return "", fmt.Errorf("obj not loaded")
+	}
+	return h.obj.ContentType, nil
+}
+
+// Codec returns the name of the codec that encoded the event body
+// if it has been decoded.
+func (h *EventHeader) Codec() (string, error) {
+	if h.obj == nil {
+		return "", fmt.Errorf("obj not loaded")
+	}
+	return h.obj.Codec, nil
+}
+
+// SchemaVersion returns the schema version of the event body
+// if it has been decoded.
+func (h *EventHeader) SchemaVersion() (string, error) {
+	if h.obj == nil {
+		return "", fmt.Errorf("obj not loaded")
+	}
+	return h.obj.SchemaVersion, nil
+}
+
+// Author returns the identity of the event's creator if it has been decoded.
+// It's empty if the header has no author.
+func (h *EventHeader) Author() (peer.ID, error) {
+	if h.obj == nil {
+		return "", fmt.Errorf("obj not loaded")
+	}
+	if len(h.obj.Author) == 0 {
+		return "", nil
+	}
+	return peer.IDFromBytes(h.obj.Author)
+}
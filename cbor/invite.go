@@ -0,0 +1,210 @@
+package cbor
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	ic "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crypto/asymmetric"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+func init() {
+	cbornode.RegisterCborType(invite{})
+	cbornode.RegisterCborType(sealedKeys{})
+	cbornode.RegisterCborType(pastKeys{})
+}
+
+// invite defines the node structure of a signed thread invite.
+type invite struct {
+	Thread  []byte
+	Addrs   [][]byte
+	Keys    []byte
+	Expiry  int64
+	Inviter []byte
+	Sig     []byte `refmt:",omitempty"`
+}
+
+// sealedKeys defines the node structure of thread keys sealed to a peer.
+type sealedKeys struct {
+	FollowKey []byte
+	ReadKey   []byte     `refmt:",omitempty"`
+	Epoch     uint64     `refmt:",omitempty"`
+	Past      []pastKeys `refmt:",omitempty"`
+}
+
+// pastKeys defines the node structure of the keys of an earlier key epoch.
+type pastKeys struct {
+	Epoch     uint64
+	FollowKey []byte
+	ReadKey   []byte `refmt:",omitempty"`
+}
+
+// SealKeys encrypts thread keys of the given key epoch to a peer's
+// public key. Only Ed25519 keys can be sealed to.
+func SealKeys(to ic.PubKey, fk, rk *sym.Key, epoch uint64) ([]byte, error) {
+	return sealKeys(to, fk, rk, epoch, nil)
+}
+
+// sealKeys is like SealKeys, but also seals the keys of earlier epochs.
+func sealKeys(to ic.PubKey, fk, rk *sym.Key, epoch uint64, past map[uint64]thread.EpochKeys) ([]byte, error) {
+	if fk == nil {
+		return nil, fmt.Errorf("a follow-key is required")
+	}
+	ek, err := asymmetric.NewEncryptionKey(to)
+	if err != nil {
+		return nil, err
+	}
+	obj := &sealedKeys{FollowKey: fk.Bytes(), Epoch: epoch}
+	if rk != nil {
+		obj.ReadKey = rk.Bytes()
+	}
+	for e, keys := range past {
+		if e >= epoch || keys.FollowKey == nil {
+			continue
+		}
+		pk := pastKeys{Epoch: e, FollowKey: keys.FollowKey.Bytes()}
+		if keys.ReadKey != nil {
+			pk.ReadKey = keys.ReadKey.Bytes()
+		}
+		obj.Past = append(obj.Past, pk)
+	}
+	sort.Slice(obj.Past, func(i, j int) bool {
+		return obj.Past[i].Epoch < obj.Past[j].Epoch
+	})
+	data, err := cbornode.DumpObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return ek.Encrypt(data)
+}
+
+// OpenKeys decrypts thread keys sealed with SealKeys using the recipient's
+// private key. The read-key is nil if none was sealed.
+func OpenKeys(data []byte, sk ic.PrivKey) (fk, rk *sym.Key, epoch uint64, err error) {
+	fk, rk, epoch, _, err = openKeys(data, sk)
+	return
+}
+
+// openKeys is like OpenKeys, but also returns the keys of earlier epochs.
+func openKeys(data []byte, sk ic.PrivKey) (fk, rk *sym.Key, epoch uint64, past map[uint64]thread.EpochKeys, err error) {
+	dk, err := asymmetric.NewDecryptionKey(sk)
+	if err != nil {
+		return
+	}
+	plain, err := dk.Decrypt(data)
+	if err != nil {
+		return
+	}
+	obj := new(sealedKeys)
+	if err = cbornode.DecodeInto(plain, obj); err != nil {
+		return
+	}
+	if fk, err = sym.NewKey(obj.FollowKey); err != nil {
+		return
+	}
+	if obj.ReadKey != nil {
+		if rk, err = sym.NewKey(obj.ReadKey); err != nil {
+			return
+		}
+	}
+	for _, p := range obj.Past {
+		if past == nil {
+			past = make(map[uint64]thread.EpochKeys)
+		}
+		var keys thread.EpochKeys
+		if keys.FollowKey, err = sym.NewKey(p.FollowKey); err != nil {
+			return
+		}
+		if p.ReadKey != nil {
+			if keys.ReadKey, err = sym.NewKey(p.ReadKey); err != nil {
+				return
+			}
+		}
+		past[p.Epoch] = keys
+	}
+	return fk, rk, obj.Epoch, past, nil
+}
+
+// CreateInvite returns an invite to a thread signed with the inviter's
+// private key sk. Its keys, including past keys, are sealed to the invitee's
+// public key, so only the invitee can accept it. A zero expiry never expires.
+func CreateInvite(inv thread.Invite, to ic.PubKey, sk ic.PrivKey) ([]byte, error) {
+	keys, err := sealKeys(to, inv.FollowKey, inv.ReadKey, inv.KeyEpoch, inv.PastKeys)
+	if err != nil {
+		return nil, err
+	}
+	inviter, err := ic.MarshalPublicKey(sk.GetPublic())
+	if err != nil {
+		return nil, err
+	}
+	obj := &invite{
+		Thread:  inv.ID.Bytes(),
+		Addrs:   make([][]byte, len(inv.Addrs)),
+		Keys:    keys,
+		Inviter: inviter,
+	}
+	for i, a := range inv.Addrs {
+		obj.Addrs[i] = a.Bytes()
+	}
+	if !inv.Expiry.IsZero() {
+		obj.Expiry = inv.Expiry.UnixNano()
+	}
+	payload, err := cbornode.DumpObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Sig, err = sk.Sign(payload); err != nil {
+		return nil, err
+	}
+	return cbornode.DumpObject(obj)
+}
+
+// OpenInvite verifies the signature of an invite and opens its keys with
+// the invitee's private key sk. Expired invites return ErrInviteExpired.
+func OpenInvite(data []byte, sk ic.PrivKey) (inv thread.Invite, err error) {
+	obj := new(invite)
+	if err = cbornode.DecodeInto(data, obj); err != nil {
+		return
+	}
+	pk, err := ic.UnmarshalPublicKey(obj.Inviter)
+	if err != nil {
+		return
+	}
+	sig := obj.Sig
+	obj.Sig = nil
+	payload, err := cbornode.DumpObject(obj)
+	if err != nil {
+		return
+	}
+	if ok, err := pk.Verify(payload, sig); !ok || err != nil {
+		return inv, fmt.Errorf("bad invite signature")
+	}
+	if obj.Expiry != 0 {
+		inv.Expiry = time.Unix(0, obj.Expiry)
+		if inv.Expired() {
+			return inv, service.ErrInviteExpired
+		}
+	}
+
+	if inv.ID, err = thread.Cast(obj.Thread); err != nil {
+		return
+	}
+	if inv.Inviter, err = peer.IDFromPublicKey(pk); err != nil {
+		return
+	}
+	inv.Addrs = make([]ma.Multiaddr, len(obj.Addrs))
+	for i, a := range obj.Addrs {
+		if inv.Addrs[i], err = ma.NewMultiaddrBytes(a); err != nil {
+			return
+		}
+	}
+	inv.FollowKey, inv.ReadKey, inv.KeyEpoch, inv.PastKeys, err = openKeys(obj.Keys, sk)
+	return inv, err
+}
@@ -0,0 +1,63 @@
+package cbor
+
+import (
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func init() {
+	cbornode.RegisterCborType(peerPolicy{})
+}
+
+// peerPolicy defines the node structure of a peer policy.
+type peerPolicy struct {
+	Allow        []string
+	Deny         []string
+	LogPeersOnly bool
+}
+
+// MarshalPeerPolicy returns the cbor encoding of a peer policy.
+func MarshalPeerPolicy(p thread.PeerPolicy) ([]byte, error) {
+	return cbornode.DumpObject(&peerPolicy{
+		Allow:        peersToStrs(p.Allow),
+		Deny:         peersToStrs(p.Deny),
+		LogPeersOnly: p.LogPeersOnly,
+	})
+}
+
+// UnmarshalPeerPolicy decodes a peer policy from cbor.
+func UnmarshalPeerPolicy(data []byte) (p thread.PeerPolicy, err error) {
+	obj := new(peerPolicy)
+	if err = cbornode.DecodeInto(data, obj); err != nil {
+		return
+	}
+	if p.Allow, err = strsToPeers(obj.Allow); err != nil {
+		return
+	}
+	if p.Deny, err = strsToPeers(obj.Deny); err != nil {
+		return
+	}
+	p.LogPeersOnly = obj.LogPeersOnly
+	return p, nil
+}
+
+func peersToStrs(ids []peer.ID) []string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
+
+func strsToPeers(strs []string) ([]peer.ID, error) {
+	ids := make([]peer.ID, len(strs))
+	for i, s := range strs {
+		id, err := peer.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -19,39 +19,51 @@ func init() {
 }
 
 // record defines the node structure of a record.
+// Merge records link to more than one previous record. The first is stored
+// under Prev, the others under Merge.
 type record struct {
 	Block cid.Cid
 	Sig   []byte
-	Prev  cid.Cid `refmt:",omitempty"`
+	Prev  cid.Cid   `refmt:",omitempty"`
+	Merge []cid.Cid `refmt:",omitempty"`
 }
 
 // CreateRecord returns a new record from the given block and log private key.
+// The record links to all of prevs, which are usually the log heads. More than
+// one previous record makes it a merge record.
+// The record is encrypted with key, which belongs to the given key epoch.
 func CreateRecord(
 	ctx context.Context,
 	dag format.DAGService,
 	block format.Node,
-	prev cid.Cid,
+	prevs []cid.Cid,
 	sk ic.PrivKey,
 	key crypto.EncryptionKey,
+	epoch uint64,
 ) (service.Record, error) {
-	payload := block.Cid().Bytes()
-	if prev.Defined() {
-		payload = append(payload, prev.Bytes()...)
+	obj := &record{
+		Block: block.Cid(),
+	}
+	for _, p := range prevs {
+		if !p.Defined() {
+			continue
+		}
+		if !obj.Prev.Defined() {
+			obj.Prev = p
+		} else {
+			obj.Merge = append(obj.Merge, p)
+		}
 	}
-	sig, err := sk.Sign(payload)
+	sig, err := sk.Sign(obj.payload())
 	if err != nil {
 		return nil, err
 	}
-	obj := &record{
-		Block: block.Cid(),
-		Sig:   sig,
-		Prev:  prev,
-	}
+	obj.Sig = sig
 	node, err := cbornode.WrapObject(obj, mh.SHA2_256, -1)
 	if err != nil {
 		return nil, err
 	}
-	coded, err := EncodeBlock(node, key)
+	coded, err := EncodeBlockAt(node, key, epoch)
 	if err != nil {
 		return nil, err
 	}
@@ -70,18 +82,19 @@ func CreateRecord(
 }
 
 // GetRecord returns a record from the given cid.
-func GetRecord(ctx context.Context, dag format.DAGService, id cid.Cid, key crypto.DecryptionKey) (service.Record, error) {
+// The record is decrypted with the key matching its key epoch.
+func GetRecord(ctx context.Context, dag format.DAGService, id cid.Cid, keys crypto.KeyRing) (service.Record, error) {
 	coded, err := dag.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return RecordFromNode(coded, key)
+	return RecordFromNode(coded, keys)
 }
 
-// RecordFromNode decodes a record from a node using the given key.
-func RecordFromNode(coded format.Node, key crypto.DecryptionKey) (service.Record, error) {
+// RecordFromNode decodes a record from a node using the key matching its key epoch.
+func RecordFromNode(coded format.Node, keys crypto.KeyRing) (service.Record, error) {
 	obj := new(record)
-	node, err := DecodeBlock(coded, key)
+	node, err := DecodeBlockAt(coded, keys)
 	if err != nil {
 		return nil, err
 	}
@@ -126,8 +139,8 @@ func RecordToProto(ctx context.Context, dag format.DAGService, rec service.Recor
 }
 
 // Unmarshal returns a node from a serialized version that contains link data.
-func RecordFromProto(rec *pb.Log_Record, key crypto.DecryptionKey) (service.Record, error) {
-	if key == nil {
+func RecordFromProto(rec *pb.Log_Record, keys crypto.KeyRing) (service.Record, error) {
+	if keys == nil {
 		return nil, fmt.Errorf("decryption key is required")
 	}
 
@@ -148,7 +161,7 @@ func RecordFromProto(rec *pb.Log_Record, key crypto.DecryptionKey) (service.Reco
 		return nil, err
 	}
 
-	decoded, err := DecodeBlock(rnode, key)
+	decoded, err := DecodeBlockAt(rnode, keys)
 	if err != nil {
 		return nil, err
 	}
@@ -204,10 +217,19 @@ func (r *Record) GetBlock(ctx context.Context, dag format.DAGService) (format.No
 }
 
 // PrevID returns the cid of the previous linked record.
+// Use PrevIDs to get all previous records of merge records.
 func (r *Record) PrevID() cid.Cid {
 	return r.obj.Prev
 }
 
+// PrevIDs returns the cids of all previous linked records.
+func (r *Record) PrevIDs() []cid.Cid {
+	if !r.obj.Prev.Defined() {
+		return nil
+	}
+	return append([]cid.Cid{r.obj.Prev}, r.obj.Merge...)
+}
+
 // Sig returns the record signature.
 func (r *Record) Sig() []byte {
 	return r.obj.Sig
@@ -218,13 +240,24 @@ func (r *Record) Verify(pk ic.PubKey) error {
 	if r.block == nil {
 		return fmt.Errorf("block not loaded")
 	}
-	payload := r.block.Cid().Bytes()
-	if r.PrevID().Defined() {
-		payload = append(payload, r.PrevID().Bytes()...)
+	if !r.block.Cid().Equals(r.obj.Block) {
+		return fmt.Errorf("block does not match record")
 	}
-	ok, err := pk.Verify(payload, r.Sig())
+	ok, err := pk.Verify(r.obj.payload(), r.Sig())
 	if !ok || err != nil {
 		return fmt.Errorf("bad signature")
 	}
 	return nil
 }
+
+// payload returns the signed bytes of a record.
+func (r *record) payload() []byte {
+	payload := r.Block.Bytes()
+	if r.Prev.Defined() {
+		payload = append(payload, r.Prev.Bytes()...)
+	}
+	for _, m := range r.Merge {
+		payload = append(payload, m.Bytes()...)
+	}
+	return payload
+}
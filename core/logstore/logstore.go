@@ -24,6 +24,7 @@ type Logstore interface {
 	KeyBook
 	AddrBook
 	HeadBook
+	OutboxBook
 
 	// Threads returns all threads in the store.
 	Threads() (thread.IDSlice, error)
@@ -39,6 +40,9 @@ type Logstore interface {
 
 	// LogInfo returns info about a log.
 	LogInfo(thread.ID, peer.ID) (thread.LogInfo, error)
+
+	// DeleteThread deletes a thread's logs, keys, addresses, heads, metadata and outbox.
+	DeleteThread(thread.ID) error
 }
 
 // ThreadMetadata stores local thread metadata like name.
@@ -60,6 +64,9 @@ type ThreadMetadata interface {
 
 	// PutBytes stores a byte value under key.
 	PutBytes(t thread.ID, key string, val []byte) error
+
+	// ClearMetadata deletes all metadata stored under a thread.
+	ClearMetadata(t thread.ID) error
 }
 
 // KeyBook stores log keys.
@@ -88,11 +95,27 @@ type KeyBook interface {
 	// AddFollowKey adds a follow key under a log.
 	AddFollowKey(thread.ID, *sym.Key) error
 
+	// KeyEpoch returns the current key epoch of a thread.
+	KeyEpoch(thread.ID) (uint64, error)
+
+	// ReadKeyAt retrieves the read key of a thread at a key epoch.
+	ReadKeyAt(thread.ID, uint64) (*sym.Key, error)
+
+	// FollowKeyAt retrieves the follow key of a thread at a key epoch.
+	FollowKeyAt(thread.ID, uint64) (*sym.Key, error)
+
+	// AddKeysAt adds a follow key and an optional read key at a key epoch.
+	// Newer epochs become current. Keys from older epochs are kept.
+	AddKeysAt(t thread.ID, epoch uint64, fk *sym.Key, rk *sym.Key) error
+
 	// LogsWithKeys returns a list of log IDs for a service.
 	LogsWithKeys(thread.ID) (peer.IDSlice, error)
 
 	// ThreadsFromKeys returns a list of threads referenced in the book.
 	ThreadsFromKeys() (thread.IDSlice, error)
+
+	// ClearKeys deletes all keys stored under a thread.
+	ClearKeys(thread.ID) error
 }
 
 // AddrBook stores log addresses.
@@ -148,3 +171,40 @@ type HeadBook interface {
 	// ClearHeads deletes the head entry for a log.
 	ClearHeads(thread.ID, peer.ID) error
 }
+
+// OutboxEntry is a record that hasn't been delivered to a peer.
+type OutboxEntry struct {
+	// Log is the ID of the log the record belongs to.
+	Log peer.ID
+	// Record is the record's cid. It's undefined for entries that push the
+	// thread's current keys with the log.
+	Record cid.Cid
+	// Peer is the peer the record is pushed to.
+	Peer peer.ID
+	// Attempts is the number of failed deliveries.
+	Attempts int
+	// NextAttempt is the earliest time of the next delivery.
+	NextAttempt time.Time
+	// LastError is the reason the last delivery failed.
+	LastError string
+	// Created is the time of the first failed delivery.
+	Created time.Time
+}
+
+// OutboxBook stores records that haven't been delivered to peers.
+type OutboxBook interface {
+	// PutOutbox adds or replaces an entry, keyed by peer and record.
+	PutOutbox(thread.ID, OutboxEntry) error
+
+	// RemoveOutbox deletes the entry of a record for a peer.
+	RemoveOutbox(t thread.ID, p peer.ID, rec cid.Cid) error
+
+	// Outbox returns all entries of a thread.
+	Outbox(thread.ID) ([]OutboxEntry, error)
+
+	// ThreadsFromOutbox returns a list of threads with entries in the book.
+	ThreadsFromOutbox() (thread.IDSlice, error)
+
+	// ClearOutbox deletes all entries of a thread.
+	ClearOutbox(thread.ID) error
+}
@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/textileio/go-threads/crypto"
 )
 
@@ -16,6 +18,9 @@ type Event interface {
 	// HeaderID returns the cid of the event header.
 	HeaderID() cid.Cid
 
+	// KeyEpoch returns the key epoch of the read key that encrypts the header.
+	KeyEpoch() uint64
+
 	// GetHeader loads and optionally decrypts the event header.
 	// If no key is given, the header time and key methods will return an error.
 	GetHeader(context.Context, format.DAGService, crypto.DecryptionKey) (EventHeader, error)
@@ -25,6 +30,10 @@ type Event interface {
 
 	// GetBody loads and optionally decrypts the event body.
 	GetBody(context.Context, format.DAGService, crypto.DecryptionKey) (format.Node, error)
+
+	// BodyReader returns a reader of the decrypted body's raw bytes. Large
+	// bodies are stored in chunks, which are loaded as they are read.
+	BodyReader(context.Context, format.DAGService, crypto.DecryptionKey) (io.Reader, error)
 }
 
 // EventHeader is the format of the event's header object
@@ -36,4 +45,25 @@ type EventHeader interface {
 
 	// Key returns a single-use decryption key for the event body.
 	Key() (crypto.DecryptionKey, error)
+
+	// Clock returns the logical clock of the event. It's greater than the
+	// clocks of all records seen by the author when the event was created.
+	Clock() (uint64, error)
+
+	// Deps returns the head records seen by the author when the event was created.
+	Deps() ([]cid.Cid, error)
+
+	// ContentType returns the format of the event body, if set.
+	ContentType() (string, error)
+
+	// Codec returns the name of the codec that encoded the event body, if set.
+	Codec() (string, error)
+
+	// SchemaVersion returns the application schema version of the body, if set.
+	SchemaVersion() (string, error)
+
+	// Author returns the ID of the log that created the event, if set.
+	// Hosts only accept records whose author is the log that signed them.
+	// Events created before authors were recorded return an empty ID.
+	Author() (peer.ID, error)
 }
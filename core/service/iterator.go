@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// RecordIterator walks all records of a thread, one page at a time.
+type RecordIterator struct {
+	api   API
+	id    thread.ID
+	token string
+	limit int
+
+	page []ThreadRecord
+	rec  ThreadRecord
+	done bool
+	err  error
+}
+
+// NewRecordIterator returns an iterator over the records of a thread.
+// Listing starts at token, or from the beginning if empty, and fetches
+// pages of up to limit records.
+func NewRecordIterator(api API, id thread.ID, token string, limit int) *RecordIterator {
+	return &RecordIterator{
+		api:   api,
+		id:    id,
+		token: token,
+		limit: limit,
+	}
+}
+
+// Next advances the iterator. It returns false when there are no more
+// records or an error occurred.
+func (i *RecordIterator) Next(ctx context.Context) bool {
+	for len(i.page) == 0 {
+		if i.done || i.err != nil {
+			return false
+		}
+		i.page, i.token, i.err = i.api.ListThreadRecords(ctx, i.id, i.token, i.limit)
+		if i.err != nil {
+			return false
+		}
+		i.done = i.token == ""
+	}
+	i.rec, i.page = i.page[0], i.page[1:]
+	return true
+}
+
+// Record returns the current record.
+func (i *RecordIterator) Record() ThreadRecord {
+	return i.rec
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (i *RecordIterator) Err() error {
+	return i.err
+}
+
+// CausalIterator walks records that are in causal order.
+type CausalIterator struct {
+	recs []ThreadRecord
+	rec  ThreadRecord
+}
+
+// NewCausalIterator returns an iterator over recs, which must already be
+// in causal order.
+func NewCausalIterator(recs []ThreadRecord) *CausalIterator {
+	return &CausalIterator{recs: recs}
+}
+
+// Next advances the iterator. It returns false when there are no more records.
+func (i *CausalIterator) Next() bool {
+	if len(i.recs) == 0 {
+		return false
+	}
+	i.rec, i.recs = i.recs[0], i.recs[1:]
+	return true
+}
+
+// Record returns the current record.
+func (i *CausalIterator) Record() ThreadRecord {
+	return i.rec
+}
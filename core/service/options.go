@@ -1,7 +1,9 @@
 package service
 
 import (
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/crypto/symmetric"
 )
@@ -11,6 +13,12 @@ type KeyOptions struct {
 	FollowKey *symmetric.Key
 	ReadKey   *symmetric.Key
 	LogKey    crypto.Key
+	KeyEpoch  uint64
+	PastKeys  map[uint64]EpochKeys
+	Algorithm symmetric.Algorithm
+	Creator   peer.ID
+
+	NoDiscovery bool
 }
 
 // KeyOption specifies encryption keys.
@@ -30,6 +38,54 @@ func ReadKey(key *symmetric.Key) KeyOption {
 	}
 }
 
+// KeyEpoch is the key epoch of the given follow and read keys.
+func KeyEpoch(epoch uint64) KeyOption {
+	return func(args *KeyOptions) {
+		args.KeyEpoch = epoch
+	}
+}
+
+// Algorithm sets the cipher the host encrypts the thread's records with.
+// Peers decrypt records with the cipher they were encrypted with, but those
+// that predate cipher envelopes can't read them.
+func Algorithm(alg symmetric.Algorithm) KeyOption {
+	return func(args *KeyOptions) {
+		args.Algorithm = alg
+	}
+}
+
+// EpochKeys are the follow and read keys of a key epoch.
+type EpochKeys = thread.EpochKeys
+
+// PastKeys adds the keys of an earlier key epoch, so that records written
+// before the keys were rotated can be read. Use this option once per epoch.
+func PastKeys(epoch uint64, fk, rk *symmetric.Key) KeyOption {
+	return func(args *KeyOptions) {
+		if args.PastKeys == nil {
+			args.PastKeys = make(map[uint64]EpochKeys)
+		}
+		args.PastKeys[epoch] = EpochKeys{FollowKey: fk, ReadKey: rk}
+	}
+}
+
+// Creator sets the log of the thread's creator when importing a thread. The
+// creator is trusted to publish the initial access control list and to rotate
+// keys, so it must come from a source the caller trusts, not from the file.
+func Creator(lid peer.ID) KeyOption {
+	return func(args *KeyOptions) {
+		args.Creator = lid
+	}
+}
+
+// NoDiscovery keeps the host from announcing that it follows the thread on
+// the content routing system, e.g., the DHT, for privacy. The host can still
+// look up other peers of the thread.
+func NoDiscovery() KeyOption {
+	return func(args *KeyOptions) {
+		args.NoDiscovery = true
+	}
+}
+
 // LogKey defines the public or private key used to write a log records.
 // If this is just a public key, the service itself won't be able to create records.
 // In other words, all records must pre-created and added with AddRecord.
@@ -43,6 +99,8 @@ func LogKey(key crypto.Key) KeyOption {
 // SubOptions defines options for a thread subscription.
 type SubOptions struct {
 	ThreadIDs thread.IDSlice
+	Cursor    map[peer.ID]cid.Cid
+	Causal    bool
 }
 
 // SubOption is a thread subscription option.
@@ -56,3 +114,113 @@ func ThreadID(id thread.ID) SubOption {
 		args.ThreadIDs = append(args.ThreadIDs, id)
 	}
 }
+
+// Cursor replays local records that were added after the given per-log
+// cursor before delivering new records. Cursor values are the ID of the last
+// record seen in each log. Logs missing from the cursor are replayed from
+// their first record.
+func Cursor(logs map[peer.ID]cid.Cid) SubOption {
+	return func(args *SubOptions) {
+		if args.Cursor == nil {
+			args.Cursor = make(map[peer.ID]cid.Cid)
+		}
+		for lid, rid := range logs {
+			args.Cursor[lid] = rid
+		}
+	}
+}
+
+// Causal delivers records in causal order. Records are held back until the
+// records they depend on, in their own log and in other logs of the thread,
+// have been delivered. Replayed records are sorted as by CausalRecords, so
+// replicas replay the same records in the same order. New records only keep
+// causal order: concurrent records are delivered as they arrive, which may
+// differ between replicas. Use CausalRecords for a replica-independent order.
+func Causal() SubOption {
+	return func(args *SubOptions) {
+		args.Causal = true
+	}
+}
+
+// DeleteOptions defines options for deleting a thread.
+type DeleteOptions struct {
+	Blocks bool
+}
+
+// DeleteOption specifies thread deletion options.
+type DeleteOption func(*DeleteOptions)
+
+// DeleteBlocks indicates whether or not the thread's record, event, header,
+// and body blocks should be removed from the blockstore.
+func DeleteBlocks(remove bool) DeleteOption {
+	return func(args *DeleteOptions) {
+		args.Blocks = remove
+	}
+}
+
+// RotateOptions defines options for rotating thread keys.
+type RotateOptions struct {
+	Remove []peer.ID
+}
+
+// RotateOption specifies key rotation options.
+type RotateOption func(*RotateOptions)
+
+// RemoveLog excludes the given log from the thread.
+// The log won't receive the new keys, nor will it be pushed new records.
+// Use this option multiple times to remove more than one log.
+func RemoveLog(id peer.ID) RotateOption {
+	return func(args *RotateOptions) {
+		args.Remove = append(args.Remove, id)
+	}
+}
+
+// EventOptions defines optional metadata for the header of a new event,
+// and how its body is stored.
+type EventOptions struct {
+	ContentType   string
+	Codec         string
+	SchemaVersion string
+	Author        peer.ID
+	ChunkSize     int
+}
+
+// EventOption specifies event header metadata or body storage.
+type EventOption func(*EventOptions)
+
+// ContentType describes the format of the event body, e.g., a MIME type.
+func ContentType(t string) EventOption {
+	return func(args *EventOptions) {
+		args.ContentType = t
+	}
+}
+
+// Codec names the codec that encoded the event body.
+func Codec(name string) EventOption {
+	return func(args *EventOptions) {
+		args.Codec = name
+	}
+}
+
+// SchemaVersion is the version of the application schema the body follows.
+func SchemaVersion(v string) EventOption {
+	return func(args *EventOptions) {
+		args.SchemaVersion = v
+	}
+}
+
+// Author identifies the log that creates the event. It defaults to, and must
+// be, the log that signs the event's record.
+func Author(id peer.ID) EventOption {
+	return func(args *EventOptions) {
+		args.Author = id
+	}
+}
+
+// ChunkSize sets the size of the chunks that a body larger than it is split
+// into. Zero uses the default chunk size and a negative size disables chunking.
+func ChunkSize(size int) EventOption {
+	return func(args *EventOptions) {
+		args.ChunkSize = size
+	}
+}
@@ -23,6 +23,10 @@ type Record interface {
 	// PrevID returns the cid of the previous node.
 	PrevID() cid.Cid
 
+	// PrevIDs returns the cids of all previous nodes.
+	// Merge records have more than one.
+	PrevIDs() []cid.Cid
+
 	// Sig returns the node signature.
 	Sig() []byte
 
@@ -2,14 +2,38 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/core/logstore"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crypto"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+var (
+	// ErrThreadInUse indicates a thread is still being used, e.g., by a subscription.
+	ErrThreadInUse = errors.New("thread is in use")
+
+	// ErrUnauthorized indicates an access control list does not permit an action.
+	ErrUnauthorized = errors.New("not authorized")
+
+	// ErrInviteExpired indicates an invite can no longer be accepted.
+	ErrInviteExpired = errors.New("invite expired")
+
+	// ErrReadKeyNotAllowed indicates a read-key was given to a replicator.
+	ErrReadKeyNotAllowed = errors.New("replicators do not accept read-keys")
+
+	// ErrPeerNotAllowed indicates a thread's peer policy does not allow a peer.
+	ErrPeerNotAllowed = errors.New("peer not allowed")
 )
 
 // Service is the network interface for thread orchestration.
@@ -21,6 +45,34 @@ type Service interface {
 
 	// Host provides a network identity.
 	Host() host.Host
+
+	// SetRecordValidator validates records added to a thread in addition to
+	// any validators given to the service. A nil validator removes it.
+	SetRecordValidator(id thread.ID, v RecordValidator)
+
+	// ExportThread writes a thread's logs and blocks to w as a CAR file.
+	ExportThread(ctx context.Context, id thread.ID, w io.Writer) error
+
+	// ImportThread adds a thread from a CAR file written by ExportThread.
+	// Access controlled threads require the Creator option.
+	ImportThread(ctx context.Context, r io.Reader, opts ...KeyOption) (thread.Info, error)
+
+	// CausalRecords returns an iterator over the local records of a thread in
+	// causal order, using the logical clocks in event headers. Records that are
+	// concurrent are ordered by clock, log ID, and record ID, so replicas with
+	// the same records iterate them in the same order.
+	CausalRecords(ctx context.Context, id thread.ID) (*CausalIterator, error)
+
+	// Pin keeps the DAG rooted at c from garbage collection while the thread exists.
+	Pin(ctx context.Context, id thread.ID, c cid.Cid) error
+
+	// Unpin allows the DAG rooted at c to be garbage collected, unless it's
+	// reachable from a log or another pin.
+	Unpin(ctx context.Context, id thread.ID, c cid.Cid) error
+
+	// SetDiscovery turns announcing that the host follows a thread on or off.
+	// Announcements that were already made expire on their own.
+	SetDiscovery(ctx context.Context, id thread.ID, enabled bool) error
 }
 
 // API is the network interface for thread orchestration.
@@ -43,13 +95,52 @@ type API interface {
 	PullThread(ctx context.Context, id thread.ID) error
 
 	// DeleteThread with id.
-	DeleteThread(ctx context.Context, id thread.ID) error
+	DeleteThread(ctx context.Context, id thread.ID, opts ...DeleteOption) error
+
+	// GetKeysAt returns the follow and read keys of a thread at a key epoch.
+	// Keys that aren't known are nil.
+	GetKeysAt(ctx context.Context, id thread.ID, epoch uint64) (fk *sym.Key, rk *sym.Key, err error)
+
+	// RotateKeys replaces the follow and read keys of a thread with new keys
+	// under a new key epoch. Keys from older epochs are kept so old records stay
+	// readable. The new keys are only sent to the remaining logs. Only the
+	// thread's creator, or an admin of an access controlled thread, can rotate keys.
+	RotateKeys(ctx context.Context, id thread.ID, opts ...RotateOption) (thread.Info, error)
+
+	// GetACL returns the access control list of an access controlled thread.
+	GetACL(ctx context.Context, id thread.ID) (thread.ACL, error)
+
+	// UpdateACL replaces the access control list of an access controlled thread.
+	// The list is stored as a record in the host's log, which must have the admin role.
+	// Grant roles to log IDs to authorize records, and to peer IDs to authorize hosts.
+	UpdateACL(ctx context.Context, id thread.ID, roles map[peer.ID]thread.Role) (ThreadRecord, error)
+
+	// GetPeerPolicy returns the peer policy of a thread.
+	GetPeerPolicy(ctx context.Context, id thread.ID) (thread.PeerPolicy, error)
+
+	// SetPeerPolicy replaces the peer policy of a thread, which restricts the
+	// peers its logs and records are exchanged with.
+	SetPeerPolicy(ctx context.Context, id thread.ID, policy thread.PeerPolicy) error
 
 	// AddFollower to a thread.
 	AddFollower(ctx context.Context, id thread.ID, paddr ma.Multiaddr) (peer.ID, error)
 
-	// CreateRecord with body.
-	CreateRecord(ctx context.Context, id thread.ID, body format.Node) (ThreadRecord, error)
+	// AddReplicator to a thread. Replicators are only ever sent the follow-key,
+	// so they can store and serve the thread's logs but can't read record bodies.
+	AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multiaddr) (peer.ID, error)
+
+	// CreateInvite returns a signed invite to a thread for the given peer.
+	// The thread keys, including those of earlier key epochs, are sealed to
+	// the invitee's public key, which must be an Ed25519 key. A zero ttl uses
+	// the default invite lifetime.
+	CreateInvite(ctx context.Context, id thread.ID, invitee peer.ID, ttl time.Duration) ([]byte, error)
+
+	// AcceptInvite opens an invite created for this host and adds the thread
+	// from the first of its addresses that can be reached.
+	AcceptInvite(ctx context.Context, invite []byte) (thread.Info, error)
+
+	// CreateRecord with body. Event options add metadata to the event header.
+	CreateRecord(ctx context.Context, id thread.ID, body format.Node, opts ...EventOption) (ThreadRecord, error)
 
 	// AddRecord to the given log.
 	AddRecord(ctx context.Context, id thread.ID, lid peer.ID, rec Record) error
@@ -57,6 +148,50 @@ type API interface {
 	// GetRecord returns the record at cid.
 	GetRecord(ctx context.Context, id thread.ID, rid cid.Cid) (Record, error)
 
+	// ListRecords returns up to limit records from a log, newest first.
+	// Listing starts at from, or the log head if undefined, and stops before to,
+	// or the first record if undefined. The returned cid is the from value of the
+	// next page. It's undefined once the log is exhausted.
+	ListRecords(ctx context.Context, id thread.ID, lid peer.ID, from, to cid.Cid, limit int) ([]Record, cid.Cid, error)
+
+	// ListThreadRecords returns up to limit records from all of a thread's logs.
+	// Logs are listed in order of ID, each newest first. The returned token
+	// continues the listing. It's empty once the thread is exhausted.
+	ListThreadRecords(ctx context.Context, id thread.ID, token string, limit int) ([]ThreadRecord, string, error)
+
 	// Subscribe returns a read-only channel of records.
+	// Use the Cursor option to first replay records missed since an earlier subscription.
 	Subscribe(ctx context.Context, opts ...SubOption) (<-chan ThreadRecord, error)
+
+	// GetOutbox returns the records of a thread that haven't been delivered to
+	// peers, one entry per record and peer. Delivery is retried with backoff.
+	GetOutbox(ctx context.Context, id thread.ID) ([]logstore.OutboxEntry, error)
+
+	// GC removes the blocks that aren't reachable from the heads of a log or
+	// from a pinned block, and returns the number removed. Records aren't
+	// written while it runs.
+	GC(ctx context.Context) (int, error)
+}
+
+// FollowKeys returns a key ring with the follow-keys of all key epochs of a
+// thread. Keys are fetched from api when they're first needed.
+func FollowKeys(ctx context.Context, api API, id thread.ID) crypto.KeyRing {
+	var lock sync.Mutex
+	keys := make(map[uint64]*sym.Key)
+	return crypto.KeyRingFunc(func(epoch uint64) (crypto.DecryptionKey, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		if fk, ok := keys[epoch]; ok {
+			return fk, nil
+		}
+		fk, _, err := api.GetKeysAt(ctx, id, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if fk == nil {
+			return nil, fmt.Errorf("follow-key for epoch %d not found", epoch)
+		}
+		keys[epoch] = fk
+		return fk, nil
+	})
 }
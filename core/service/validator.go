@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrInvalidRecord indicates a record was rejected by a RecordValidator.
+var ErrInvalidRecord = errors.New("invalid record")
+
+// RecordValidator decides whether or not a record from another peer is
+// accepted into a thread. It's called before the record is linked from its
+// log and before the log heads move. Blocks fetched from peers to read the
+// record may already be in the blockstore. Those of rejected records aren't
+// linked from any log, so the next garbage collection removes them.
+type RecordValidator interface {
+	// Validate returns a non-nil error to reject rec from log lid.
+	// The header and body are decrypted if the read-key is known. Otherwise,
+	// the header can't report its time and key, and the body is encrypted.
+	Validate(
+		ctx context.Context,
+		id thread.ID,
+		lid peer.ID,
+		rec Record,
+		header EventHeader,
+		body format.Node,
+	) error
+}
+
+// RecordValidatorFunc is an adapter to use functions as record validators.
+type RecordValidatorFunc func(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	rec Record,
+	header EventHeader,
+	body format.Node,
+) error
+
+// Validate calls f.
+func (f RecordValidatorFunc) Validate(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	rec Record,
+	header EventHeader,
+	body format.Node,
+) error {
+	return f(ctx, id, lid, rec, header, body)
+}
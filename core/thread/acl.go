@@ -0,0 +1,66 @@
+package thread
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// Role is an access role in an access controlled thread.
+// Each role includes the permissions of the roles below it.
+type Role int
+
+// Roles.
+const (
+	// NoRole has no access.
+	NoRole Role = iota
+	// Reader can pull logs and records.
+	Reader
+	// Writer can add records to its log and push logs.
+	Writer
+	// Admin can update the access control list.
+	Admin
+)
+
+// RoleToStr maps roles to their names.
+var RoleToStr = map[Role]string{
+	NoRole: "none",
+	Reader: "reader",
+	Writer: "writer",
+	Admin:  "admin",
+}
+
+// String returns the role's name.
+func (r Role) String() string {
+	if s, ok := RoleToStr[r]; ok {
+		return s
+	}
+	return fmt.Sprintf("role(%d)", int(r))
+}
+
+// ACL is an access control list for a thread.
+// Roles are keyed by log ID or peer ID. Log IDs authorize records,
+// peer IDs authorize requests made by hosts.
+type ACL struct {
+	Version uint64
+	Roles   map[peer.ID]Role
+}
+
+// Role returns the highest role granted to any of ids.
+func (a *ACL) Role(ids ...peer.ID) Role {
+	role := NoRole
+	if a == nil {
+		return role
+	}
+	for _, id := range ids {
+		if r, ok := a.Roles[id]; ok && r > role {
+			role = r
+		}
+	}
+	return role
+}
+
+// Allows returns whether or not any of ids has at least role.
+func (a *ACL) Allows(role Role, ids ...peer.ID) bool {
+	return a.Role(ids...) >= role
+}
@@ -0,0 +1,37 @@
+package thread
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+func TestACL_Allows(t *testing.T) {
+	admin := peer.ID("admin")
+	writer := peer.ID("writer")
+	stranger := peer.ID("stranger")
+	list := &ACL{
+		Roles: map[peer.ID]Role{
+			admin:  Admin,
+			writer: Writer,
+		},
+	}
+
+	if !list.Allows(Reader, admin) {
+		t.Fatal("expected admin to have reader role")
+	}
+	if list.Allows(Admin, writer) {
+		t.Fatal("expected writer to not have admin role")
+	}
+	if !list.Allows(Admin, writer, admin) {
+		t.Fatal("expected highest role to be used")
+	}
+	if list.Allows(Reader, stranger) {
+		t.Fatal("expected stranger to not have reader role")
+	}
+
+	var empty *ACL
+	if empty.Role(admin) != NoRole {
+		t.Fatal("expected nil list to grant no role")
+	}
+}
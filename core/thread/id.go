@@ -282,6 +282,7 @@ type Info struct {
 	Logs      []LogInfo
 	FollowKey *sym.Key
 	ReadKey   *sym.Key
+	KeyEpoch  uint64
 }
 
 // GetOwnLog returns the first log found with a private key.
@@ -0,0 +1,35 @@
+package thread
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+// Invite holds what a peer needs to join a thread. Invites are exchanged
+// signed by the inviter, with the keys sealed to the invitee's peer key.
+// PastKeys holds the keys of earlier key epochs, so that records written
+// before the last rotation can be read.
+type Invite struct {
+	ID        ID
+	Addrs     []ma.Multiaddr
+	FollowKey *sym.Key
+	ReadKey   *sym.Key
+	KeyEpoch  uint64
+	PastKeys  map[uint64]EpochKeys
+	Inviter   peer.ID
+	Expiry    time.Time
+}
+
+// EpochKeys are the follow and read keys of a key epoch.
+type EpochKeys struct {
+	FollowKey *sym.Key
+	ReadKey   *sym.Key
+}
+
+// Expired returns whether the invite can no longer be accepted.
+func (i Invite) Expired() bool {
+	return !i.Expiry.IsZero() && time.Now().After(i.Expiry)
+}
@@ -0,0 +1,44 @@
+package thread
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// PeerPolicy restricts the peers a host serves a thread's logs and records
+// to, accepts them from, and sends them and the thread's keys to. The zero
+// policy allows all peers.
+type PeerPolicy struct {
+	// Allow lists the only peers that are allowed, unless it's empty.
+	Allow []peer.ID
+	// Deny lists peers that are never allowed.
+	Deny []peer.ID
+	// LogPeersOnly only allows peers that appear in the addresses of the
+	// thread's logs.
+	LogPeersOnly bool
+}
+
+// Allows returns whether or not the policy allows pid. logPeers are the
+// peers that appear in the addresses of the thread's logs.
+func (p PeerPolicy) Allows(pid peer.ID, logPeers []peer.ID) bool {
+	for _, d := range p.Deny {
+		if d == pid {
+			return false
+		}
+	}
+	if len(p.Allow) > 0 && !containsPeer(p.Allow, pid) {
+		return false
+	}
+	if p.LogPeersOnly && !containsPeer(logPeers, pid) {
+		return false
+	}
+	return true
+}
+
+func containsPeer(ids []peer.ID, pid peer.ID) bool {
+	for _, id := range ids {
+		if id == pid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package thread
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+func TestPeerPolicy_Allows(t *testing.T) {
+	a := peer.ID("a")
+	b := peer.ID("b")
+	c := peer.ID("c")
+
+	var open PeerPolicy
+	if !open.Allows(a, nil) {
+		t.Fatal("expected zero policy to allow all peers")
+	}
+
+	p := PeerPolicy{Allow: []peer.ID{a, b}, Deny: []peer.ID{b}}
+	if !p.Allows(a, nil) {
+		t.Fatal("expected allowed peer to be allowed")
+	}
+	if p.Allows(b, nil) {
+		t.Fatal("expected deny list to win over allow list")
+	}
+	if p.Allows(c, nil) {
+		t.Fatal("expected peer missing from allow list to not be allowed")
+	}
+
+	p = PeerPolicy{LogPeersOnly: true}
+	if !p.Allows(a, []peer.ID{a}) {
+		t.Fatal("expected log peer to be allowed")
+	}
+	if p.Allows(c, []peer.ID{a}) {
+		t.Fatal("expected non-log peer to not be allowed")
+	}
+}
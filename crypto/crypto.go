@@ -25,6 +25,30 @@ type DecryptionKey interface {
 	Decrypt([]byte) ([]byte, error)
 }
 
+// KeyRing provides decryption keys by key epoch.
+type KeyRing interface {
+	// KeyAt returns the decryption key for a key epoch.
+	KeyAt(epoch uint64) (DecryptionKey, error)
+}
+
+// KeyRingFunc is an adapter to allow the use of ordinary functions as a KeyRing.
+type KeyRingFunc func(epoch uint64) (DecryptionKey, error)
+
+// KeyAt calls f(epoch).
+func (f KeyRingFunc) KeyAt(epoch uint64) (DecryptionKey, error) {
+	return f(epoch)
+}
+
+// NewKeyRing returns a KeyRing that only holds key at epoch.
+func NewKeyRing(key DecryptionKey, epoch uint64) KeyRing {
+	return KeyRingFunc(func(e uint64) (DecryptionKey, error) {
+		if e != epoch || key == nil {
+			return nil, fmt.Errorf("key for epoch %d not found", e)
+		}
+		return key, nil
+	})
+}
+
 // ParseEncryptionKey returns an EncryptionKey from k.
 func ParseEncryptionKey(k []byte) (EncryptionKey, error) {
 	pk, err := ic.UnmarshalPublicKey(k)
@@ -5,14 +5,64 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Algorithm identifies the AEAD cipher of a ciphertext envelope.
+type Algorithm byte
+
+const (
+	// AES256GCM is AES-256 in Galois/Counter Mode with a 12 byte nonce.
+	AES256GCM Algorithm = iota + 1
+	// XChaCha20Poly1305 is ChaCha20-Poly1305 with a 24 byte nonce.
+	XChaCha20Poly1305
 )
 
+// DefaultAlgorithm is the cipher used by keys without an algorithm.
+var DefaultAlgorithm = AES256GCM
+
+// envelopeVersion is the version of the ciphertext envelope:
+// version (1 byte) | algorithm (1 byte) | nonce | sealed plaintext
+// The version and algorithm are authenticated as additional data.
+const envelopeVersion = 1
+
+// String returns the algorithm's name.
+func (a Algorithm) String() string {
+	switch a {
+	case AES256GCM:
+		return "aes-256-gcm"
+	case XChaCha20Poly1305:
+		return "xchacha20-poly1305"
+	default:
+		return fmt.Sprintf("algorithm(%d)", byte(a))
+	}
+}
+
+// aead returns the cipher of the algorithm with a 32 byte key.
+func (a Algorithm) aead(key []byte) (cipher.AEAD, error) {
+	switch a {
+	case AES256GCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case XChaCha20Poly1305:
+		return chacha20poly1305.NewX(key)
+	default:
+		return nil, fmt.Errorf("unknown cipher %s", a)
+	}
+}
+
 // Key is a wrapper for a symmetric key.
 type Key struct {
 	raw []byte
+	alg Algorithm
 }
 
 // CreateKey returns 44 random bytes, 32 for the key and 12 for a nonce.
+// The nonce is only used to decrypt ciphertexts without an envelope.
 func CreateKey() (*Key, error) {
 	raw := make([]byte, 44)
 	if _, err := rand.Read(raw); err != nil {
@@ -29,27 +79,92 @@ func NewKey(k []byte) (*Key, error) {
 	return &Key{raw: k}, nil
 }
 
-// Encrypt performs AES-256 GCM encryption on plaintext.
+// WithAlgorithm returns a copy of the key that encrypts with alg.
+// Decryption always uses the algorithm of the ciphertext envelope.
+func (k *Key) WithAlgorithm(alg Algorithm) *Key {
+	return &Key{raw: k.raw, alg: alg}
+}
+
+// Algorithm returns the cipher the key encrypts with.
+func (k *Key) Algorithm() Algorithm {
+	if k.alg == 0 {
+		return DefaultAlgorithm
+	}
+	return k.alg
+}
+
+// Valid returns whether the algorithm is known.
+func (a Algorithm) Valid() bool {
+	switch a {
+	case AES256GCM, XChaCha20Poly1305:
+		return true
+	default:
+		return false
+	}
+}
+
+// Encrypt seals plaintext with the key's algorithm and a random nonce,
+// and returns it in a versioned envelope.
 func (k *Key) Encrypt(plaintext []byte) ([]byte, error) {
-	block, err := aes.NewCipher(k.raw[:32])
+	alg := k.Algorithm()
+	aead, err := alg.aead(k.raw[:32])
 	if err != nil {
 		return nil, err
 	}
-	aesgcm, err := cipher.NewGCM(block)
-	if err != nil {
+	header := []byte{envelopeVersion, byte(alg)}
+	out := make([]byte, len(header)+aead.NonceSize(), len(header)+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	copy(out, header)
+	nonce := out[len(header):]
+	if _, err := rand.Read(nonce); err != nil {
 		return nil, err
 	}
-	ciph := aesgcm.Seal(nil, k.raw[32:], plaintext, nil)
-	return ciph, nil
+	return aead.Seal(out, nonce, plaintext, header), nil
 }
 
-// Decrypt uses key (:32 key, 32:12 nonce) to perform AES-256 GCM decryption on ciphertext.
+// Decrypt opens a ciphertext with the algorithm of its envelope.
+// Ciphertexts without an envelope, which peers that predate envelopes
+// created, are decrypted with AES-256 GCM using the key (:32 key, 32:12 nonce).
+// Such ciphertexts are never created, since they reuse the key's nonce.
 func (k *Key) Decrypt(ciphertext []byte) ([]byte, error) {
-	block, err := aes.NewCipher(k.raw[:32])
+	if alg, ok := EnvelopeAlgorithm(ciphertext); ok {
+		if plain, err := k.open(alg, ciphertext); err == nil {
+			return plain, nil
+		}
+	}
+	return k.decryptLegacy(ciphertext)
+}
+
+// EnvelopeAlgorithm returns the algorithm of a ciphertext envelope. It's false
+// for ciphertexts without an envelope.
+func EnvelopeAlgorithm(ciphertext []byte) (Algorithm, bool) {
+	if len(ciphertext) < 2 || ciphertext[0] != envelopeVersion {
+		return 0, false
+	}
+	alg := Algorithm(ciphertext[1])
+	switch alg {
+	case AES256GCM, XChaCha20Poly1305:
+		return alg, true
+	default:
+		return 0, false
+	}
+}
+
+// open decrypts a ciphertext envelope.
+func (k *Key) open(alg Algorithm, ciphertext []byte) ([]byte, error) {
+	aead, err := alg.aead(k.raw[:32])
 	if err != nil {
 		return nil, err
 	}
-	aesgcm, err := cipher.NewGCM(block)
+	if len(ciphertext) < 2+aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce := ciphertext[2 : 2+aead.NonceSize()]
+	return aead.Open(nil, nonce, ciphertext[2+aead.NonceSize():], ciphertext[:2])
+}
+
+// decryptLegacy decrypts a ciphertext without an envelope.
+func (k *Key) decryptLegacy(ciphertext []byte) ([]byte, error) {
+	aesgcm, err := AES256GCM.aead(k.raw[:32])
 	if err != nil {
 		return nil, err
 	}
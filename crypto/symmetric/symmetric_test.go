@@ -1,6 +1,9 @@
 package symmetric_test
 
 import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
 	"testing"
 
 	. "github.com/textileio/go-threads/crypto/symmetric"
@@ -47,3 +50,80 @@ func TestDecrypt(t *testing.T) {
 		t.Error("decrypt AES with bad key succeeded")
 	}
 }
+
+func TestAlgorithms(t *testing.T) {
+	key, err := CreateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, alg := range []Algorithm{AES256GCM, XChaCha20Poly1305} {
+		ciphertext, err := key.WithAlgorithm(alg).Encrypt(symmetricTestData.plaintext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a, ok := EnvelopeAlgorithm(ciphertext); !ok || a != alg {
+			t.Errorf("expected %s envelope, got %s", alg, a)
+		}
+		plaintext, err := key.Decrypt(ciphertext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(plaintext, symmetricTestData.plaintext) {
+			t.Errorf("decrypt %s failed", alg)
+		}
+	}
+}
+
+func TestEncryptNonces(t *testing.T) {
+	key, err := CreateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, alg := range []Algorithm{AES256GCM, XChaCha20Poly1305} {
+		k := key.WithAlgorithm(alg)
+		c1, err := k.Encrypt(symmetricTestData.plaintext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c2, err := k.Encrypt(symmetricTestData.plaintext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(c1, c2) {
+			t.Errorf("expected %s ciphertexts to use different nonces", alg)
+		}
+	}
+}
+
+func TestUnknownAlgorithm(t *testing.T) {
+	key, err := CreateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = key.WithAlgorithm(0xff).Encrypt(symmetricTestData.plaintext); err == nil {
+		t.Fatal("expected unknown algorithm to be refused")
+	}
+}
+
+func TestDecryptWithoutEnvelope(t *testing.T) {
+	key, err := CreateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := aes.NewCipher(key.Bytes()[:32])
+	if err != nil {
+		t.Fatal(err)
+	}
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext := aesgcm.Seal(nil, key.Bytes()[32:], symmetricTestData.plaintext, nil)
+	plaintext, err := key.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plaintext, symmetricTestData.plaintext) {
+		t.Error("decrypt without envelope failed")
+	}
+}
@@ -172,20 +172,23 @@ func main() {
 				logError(err)
 				continue
 			}
-			info, err := ts.GetThread(context.Background(), rec.ThreadID())
+			event, err := cbor.EventFromRecord(ctx, ts, rec.Value())
 			if err != nil {
 				logError(err)
 				continue
 			}
-			if info.ReadKey == nil {
-				continue // just following, we don't have the read key
+			if event.IsACL() {
+				continue // Access control list update
 			}
-			event, err := cbor.EventFromRecord(ctx, ts, rec.Value())
+			_, rk, err := ts.GetKeysAt(context.Background(), rec.ThreadID(), event.KeyEpoch())
 			if err != nil {
 				logError(err)
 				continue
 			}
-			node, err := event.GetBody(ctx, ts, info.ReadKey)
+			if rk == nil {
+				continue // just following, we don't have the read key
+			}
+			node, err := event.GetBody(ctx, ts, rk)
 			if err != nil {
 				continue // Not for us
 			}
@@ -194,7 +197,7 @@ func main() {
 			if err != nil {
 				continue // Not one of our messages
 			}
-			header, err := event.GetHeader(ctx, ts, info.ReadKey)
+			header, err := event.GetHeader(ctx, ts, rk)
 			if err != nil {
 				logError(err)
 				continue
@@ -17,6 +17,9 @@ import (
 	core "github.com/textileio/go-threads/core/store"
 )
 
+// Name identifies the JSON-Patcher codec in event headers.
+const Name = "jsonpatcher"
+
 type operationType int
 
 const (
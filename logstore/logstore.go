@@ -16,15 +16,23 @@ type logstore struct {
 	core.AddrBook
 	core.ThreadMetadata
 	core.HeadBook
+	core.OutboxBook
 }
 
 // NewLogstore creates a new log store from the given books.
-func NewLogstore(kb core.KeyBook, ab core.AddrBook, hb core.HeadBook, md core.ThreadMetadata) core.Logstore {
+func NewLogstore(
+	kb core.KeyBook,
+	ab core.AddrBook,
+	hb core.HeadBook,
+	md core.ThreadMetadata,
+	ob core.OutboxBook,
+) core.Logstore {
 	return &logstore{
 		KeyBook:        kb,
 		AddrBook:       ab,
 		HeadBook:       hb,
 		ThreadMetadata: md,
+		OutboxBook:     ob,
 	}
 }
 
@@ -43,6 +51,7 @@ func (ts *logstore) Close() (err error) {
 	weakClose("addressbook", ts.AddrBook)
 	weakClose("headbook", ts.HeadBook)
 	weakClose("threadmetadata", ts.ThreadMetadata)
+	weakClose("outboxbook", ts.OutboxBook)
 
 	if len(errs) > 0 {
 		return fmt.Errorf("failed while closing logstore; err(s): %q", errs)
@@ -81,15 +90,7 @@ func (ts *logstore) AddThread(info thread.Info) error {
 	if info.FollowKey == nil {
 		return fmt.Errorf("a follow-key is required to add a thread")
 	}
-	if err := ts.AddFollowKey(info.ID, info.FollowKey); err != nil {
-		return err
-	}
-	if info.ReadKey != nil {
-		if err := ts.AddReadKey(info.ID, info.ReadKey); err != nil {
-			return err
-		}
-	}
-	return nil
+	return ts.AddKeysAt(info.ID, info.KeyEpoch, info.FollowKey, info.ReadKey)
 }
 
 // ThreadInfo returns thread info of the given id.
@@ -119,11 +120,15 @@ func (ts *logstore) ThreadInfo(id thread.ID) (info thread.Info, err error) {
 		logs = append(logs, i)
 	}
 
-	fk, err := ts.FollowKey(id)
+	epoch, err := ts.KeyEpoch(id)
 	if err != nil {
 		return
 	}
-	rk, err := ts.ReadKey(id)
+	fk, err := ts.FollowKeyAt(id, epoch)
+	if err != nil {
+		return
+	}
+	rk, err := ts.ReadKeyAt(id, epoch)
 	if err != nil {
 		return
 	}
@@ -133,6 +138,7 @@ func (ts *logstore) ThreadInfo(id thread.ID) (info thread.Info, err error) {
 		Logs:      logs,
 		FollowKey: fk,
 		ReadKey:   rk,
+		KeyEpoch:  epoch,
 	}, nil
 }
 
@@ -188,3 +194,26 @@ func (ts *logstore) LogInfo(id thread.ID, lid peer.ID) (info thread.LogInfo, err
 	info.Heads = heads
 	return
 }
+
+// DeleteThread deletes a thread's logs, keys, addresses, heads, metadata and outbox.
+func (ts *logstore) DeleteThread(id thread.ID) error {
+	info, err := ts.ThreadInfo(id)
+	if err != nil {
+		return err
+	}
+	for _, l := range info.Logs {
+		if err = ts.ClearAddrs(id, l.ID); err != nil {
+			return err
+		}
+		if err = ts.ClearHeads(id, l.ID); err != nil {
+			return err
+		}
+	}
+	if err = ts.ClearKeys(id); err != nil {
+		return err
+	}
+	if err = ts.ClearOutbox(id); err != nil {
+		return err
+	}
+	return ts.ClearMetadata(id)
+}
@@ -1,6 +1,7 @@
 package lstoreds
 
 import (
+	"bytes"
 	"context"
 	"io/ioutil"
 	"os"
@@ -9,7 +10,11 @@ import (
 
 	ds "github.com/ipfs/go-datastore"
 	badger "github.com/ipfs/go-ds-badger"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
 	core "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
 	pt "github.com/textileio/go-threads/test"
 )
 
@@ -48,6 +53,176 @@ func TestDatastoreKeyBook(t *testing.T) {
 			t.Parallel()
 			pt.KeyBookTest(t, keyBookFactory(t, dsFactory))
 		})
+
+		t.Run(name+" Encrypted", func(t *testing.T) {
+			t.Parallel()
+			pt.KeyBookTest(t, encryptedKeyBookFactory(t, dsFactory))
+		})
+	}
+}
+
+func TestDatastoreEncryptedKeyBook(t *testing.T) {
+	for name, dsFactory := range dstores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			store, closeFunc := dsFactory(t)
+			defer closeFunc()
+
+			// Keys added in plaintext are encrypted on unlock
+			tid := thread.NewIDV1(thread.Raw, 24)
+			fk, err := sym.CreateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			kb, err := NewKeyBook(store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = kb.AddFollowKey(tid, fk); err != nil {
+				t.Fatal(err)
+			}
+			mk, err := MasterKeyFromPassphrase(store, []byte("secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = NewEncryptedKeyBook(store.(ds.Batching), mk); err != nil {
+				t.Fatal(err)
+			}
+			v, err := store.Get(dsEpochKey(tid, 0).Child(followSuffix))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Equal(v, fk.Bytes()) {
+				t.Fatal("expected follow-key to be encrypted at rest")
+			}
+
+			if _, err = NewKeyBook(store); err != ErrKeyBookLocked {
+				t.Fatalf("expected key book to be locked, got %v", err)
+			}
+			bad, err := MasterKeyFromPassphrase(store, []byte("wrong"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = NewEncryptedKeyBook(store.(ds.Batching), bad); err != ErrBadMasterKey {
+				t.Fatalf("expected bad master key, got %v", err)
+			}
+
+			// Rotate the master key and read the key back
+			mk2, err := MasterKeyFromPassphrase(store, []byte("secret2"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = RotateMasterKey(store.(ds.Batching), mk, mk2); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = NewEncryptedKeyBook(store.(ds.Batching), mk); err != ErrBadMasterKey {
+				t.Fatalf("expected old master key to be rejected, got %v", err)
+			}
+			kb, err = NewEncryptedKeyBook(store.(ds.Batching), mk2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := kb.FollowKey(tid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == nil || !bytes.Equal(got.Bytes(), fk.Bytes()) {
+				t.Fatal("expected follow-key to survive master key rotation")
+			}
+		})
+	}
+}
+
+func TestDatastoreEncryptedKeyBookReopen(t *testing.T) {
+	dataPath, err := ioutil.TempDir(os.TempDir(), "badger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataPath)
+
+	tid := thread.NewIDV1(thread.Raw, 24)
+	sk, pk, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := peer.IDFromPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fk, err := sym.CreateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rk, err := sym.CreateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Store keys in a fresh encrypted key book and close it
+	store, err := badger.NewDatastore(dataPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mk, err := MasterKeyFromPassphrase(store, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb, err := NewEncryptedKeyBook(store, mk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = kb.AddPrivKey(tid, id, sk); err != nil {
+		t.Fatal(err)
+	}
+	if err = kb.AddFollowKey(tid, fk); err != nil {
+		t.Fatal(err)
+	}
+	if err = kb.AddReadKey(tid, rk); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopen it with the same passphrase and read the keys back
+	store, err = badger.NewDatastore(dataPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if encrypted, err := IsEncrypted(store); err != nil {
+		t.Fatal(err)
+	} else if !encrypted {
+		t.Fatal("expected reopened key book to be encrypted")
+	}
+	mk, err = MasterKeyFromPassphrase(store, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb, err = NewEncryptedKeyBook(store, mk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotSk, err := kb.PrivKey(tid, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotSk == nil || !gotSk.Equals(sk) {
+		t.Fatal("expected private key to round-trip")
+	}
+	gotFk, err := kb.FollowKey(tid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotFk == nil || !bytes.Equal(gotFk.Bytes(), fk.Bytes()) {
+		t.Fatal("expected follow-key to round-trip")
+	}
+	gotRk, err := kb.ReadKey(tid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotRk == nil || !bytes.Equal(gotRk.Bytes(), rk.Bytes()) {
+		t.Fatal("expected read-key to round-trip")
 	}
 }
 
@@ -69,6 +244,15 @@ func TestDatastoreMetadataBook(t *testing.T) {
 	}
 }
 
+func TestDatastoreOutboxBook(t *testing.T) {
+	for name, dsFactory := range dstores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			pt.OutboxBookTest(t, outboxBookFactory(t, dsFactory))
+		})
+	}
+}
+
 func addressBookFactory(tb testing.TB, storeFactory datastoreFactory, opts Options) pt.AddrBookFactory {
 	return func() (core.AddrBook, func()) {
 		store, closeFunc := storeFactory(tb)
@@ -98,6 +282,24 @@ func keyBookFactory(tb testing.TB, storeFactory datastoreFactory) pt.KeyBookFact
 	}
 }
 
+func encryptedKeyBookFactory(tb testing.TB, storeFactory datastoreFactory) pt.KeyBookFactory {
+	return func() (core.KeyBook, func()) {
+		store, closeFunc := storeFactory(tb)
+		mk, err := sym.CreateKey()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		kb, err := NewEncryptedKeyBook(store.(ds.Batching), mk)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		closer := func() {
+			closeFunc()
+		}
+		return kb, closer
+	}
+}
+
 func headBookFactory(tb testing.TB, storeFactory datastoreFactory) pt.HeadBookFactory {
 	return func() (core.HeadBook, func()) {
 		store, closeFunc := storeFactory(tb)
@@ -120,6 +322,17 @@ func metadataBookFactory(tb testing.TB, storeFactory datastoreFactory) pt.Metada
 	}
 }
 
+func outboxBookFactory(tb testing.TB, storeFactory datastoreFactory) pt.OutboxBookFactory {
+	return func() (core.OutboxBook, func()) {
+		store, closeFunc := storeFactory(tb)
+		ob := NewOutboxBook(store)
+		closer := func() {
+			closeFunc()
+		}
+		return ob, closer
+	}
+}
+
 func badgerStore(tb testing.TB) (ds.Datastore, func()) {
 	dataPath, err := ioutil.TempDir(os.TempDir(), "badger")
 	if err != nil {
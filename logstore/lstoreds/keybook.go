@@ -1,7 +1,9 @@
 package lstoreds
 
 import (
+	"encoding/binary"
 	"fmt"
+	"strconv"
 
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
@@ -15,28 +17,53 @@ import (
 
 type dsKeyBook struct {
 	ds ds.Datastore
+	mk *sym.Key
 }
 
 // Public and private keys are stored under the following db key pattern:
 // /threads/keys/<b32 thread id no padding>/<b32 log id no padding>/(pub|priv)
 // Follow and read keys are stored under the following db key pattern:
 // /threads/keys/<b32 thread id no padding>/(follow|read)
+// Follow and read keys of later key epochs are stored under:
+// /threads/keys/<b32 thread id no padding>/<epoch>/(follow|read)
+// The current key epoch is stored under:
+// /threads/keys/<b32 thread id no padding>/epoch
 var (
 	kbBase       = ds.NewKey("/thread/keys")
 	pubSuffix    = ds.NewKey("/pub")
 	privSuffix   = ds.NewKey("/priv")
 	readSuffix   = ds.NewKey("/read")
 	followSuffix = ds.NewKey("/follow")
+	epochSuffix  = ds.NewKey("/epoch")
 )
 
 var _ core.KeyBook = (*dsKeyBook)(nil)
 
 // NewKeyBook returns a new key book for storing public and private keys
 // of (thread.ID, peer.ID) pairs with durable guarantees by store.
+// It returns ErrKeyBookLocked if the stored keys are encrypted.
 func NewKeyBook(store ds.Datastore) (core.KeyBook, error) {
+	encrypted, err := IsEncrypted(store)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		return nil, ErrKeyBookLocked
+	}
 	return &dsKeyBook{ds: store}, nil
 }
 
+// NewEncryptedKeyBook returns a key book that encrypts private, follow, and
+// read keys at rest with the master key mk. Keys already in store are
+// encrypted on first use. It returns ErrBadMasterKey if mk doesn't match the
+// key the store was encrypted with.
+func NewEncryptedKeyBook(store ds.Batching, mk *sym.Key) (core.KeyBook, error) {
+	if err := unlockKeyBook(store, mk); err != nil {
+		return nil, err
+	}
+	return &dsKeyBook{ds: store, mk: mk}, nil
+}
+
 // PubKey returns the public key of (thread.ID, peer.ID). The implementation
 // assumes the key is in the store with the exception that peer.ID is an
 // Identity multihash. If the public key can't be resolved, nil is returned.
@@ -82,12 +109,12 @@ func (kb *dsKeyBook) AddPubKey(t thread.ID, p peer.ID, pk crypto.PubKey) error {
 // is stored, returns nil.
 func (kb *dsKeyBook) PrivKey(t thread.ID, p peer.ID) (crypto.PrivKey, error) {
 	key := dsLogKey(t, p, kbBase).Child(privSuffix)
-	v, err := kb.ds.Get(key)
+	v, err := kb.getSecret(key)
 	if err == ds.ErrNotFound {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error when getting private key for %s", key)
+		return nil, fmt.Errorf("error when getting private key for %s: %w", key, err)
 	}
 	sk, err := crypto.UnmarshalPrivateKey(v)
 	if err != nil {
@@ -109,17 +136,27 @@ func (kb *dsKeyBook) AddPrivKey(t thread.ID, p peer.ID, sk crypto.PrivKey) error
 		return fmt.Errorf("error when getting private key bytes: %w", err)
 	}
 	key := dsLogKey(t, p, kbBase).Child(privSuffix)
-	if err = kb.ds.Put(key, skb); err != nil {
+	if err = kb.putSecret(key, skb); err != nil {
 		return fmt.Errorf("error when putting key %v in datastore: %w", key, err)
 	}
 	return nil
 }
 
-// ReadKey returns the read-key associated with thread.ID thread.
-// In case it doesn't exist, it will return nil.
+// ReadKey returns the read-key associated with thread.ID thread at the
+// current key epoch. In case it doesn't exist, it will return nil.
 func (kb *dsKeyBook) ReadKey(t thread.ID) (*sym.Key, error) {
-	key := dsThreadKey(t, kbBase).Child(readSuffix)
-	v, err := kb.ds.Get(key)
+	epoch, err := kb.KeyEpoch(t)
+	if err != nil {
+		return nil, err
+	}
+	return kb.ReadKeyAt(t, epoch)
+}
+
+// ReadKeyAt returns the read-key associated with thread.ID thread at epoch.
+// In case it doesn't exist, it will return nil.
+func (kb *dsKeyBook) ReadKeyAt(t thread.ID, epoch uint64) (*sym.Key, error) {
+	key := dsEpochKey(t, epoch).Child(readSuffix)
+	v, err := kb.getSecret(key)
 	if err == ds.ErrNotFound {
 		return nil, nil
 	}
@@ -129,24 +166,38 @@ func (kb *dsKeyBook) ReadKey(t thread.ID) (*sym.Key, error) {
 	return sym.NewKey(v)
 }
 
-// AddReadKey adds a read-key for a peer.ID
+// AddReadKey adds a read-key for a peer.ID at the current key epoch.
 func (kb *dsKeyBook) AddReadKey(t thread.ID, rk *sym.Key) error {
 	if rk == nil {
 		return fmt.Errorf("read-key is nil")
 	}
-	key := dsThreadKey(t, kbBase).Child(readSuffix)
-	if err := kb.ds.Put(key, rk.Bytes()); err != nil {
+	epoch, err := kb.KeyEpoch(t)
+	if err != nil {
+		return err
+	}
+	key := dsEpochKey(t, epoch).Child(readSuffix)
+	if err := kb.putSecret(key, rk.Bytes()); err != nil {
 		return fmt.Errorf("error when adding read-key to datastore: %w", err)
 	}
 	return nil
 }
 
-// FollowKey returns the follow-key associated with thread.ID service.
-// In case it doesn't exist, it will return nil.
+// FollowKey returns the follow-key associated with thread.ID service at the
+// current key epoch. In case it doesn't exist, it will return nil.
 func (kb *dsKeyBook) FollowKey(t thread.ID) (*sym.Key, error) {
-	key := dsThreadKey(t, kbBase).Child(followSuffix)
+	epoch, err := kb.KeyEpoch(t)
+	if err != nil {
+		return nil, err
+	}
+	return kb.FollowKeyAt(t, epoch)
+}
 
-	v, err := kb.ds.Get(key)
+// FollowKeyAt returns the follow-key associated with thread.ID service at
+// epoch. In case it doesn't exist, it will return nil.
+func (kb *dsKeyBook) FollowKeyAt(t thread.ID, epoch uint64) (*sym.Key, error) {
+	key := dsEpochKey(t, epoch).Child(followSuffix)
+
+	v, err := kb.getSecret(key)
 	if err == ds.ErrNotFound {
 		return nil, nil
 	}
@@ -156,18 +207,70 @@ func (kb *dsKeyBook) FollowKey(t thread.ID) (*sym.Key, error) {
 	return sym.NewKey(v)
 }
 
-// AddFollowKey adds a follow-key for a peer.ID
+// AddFollowKey adds a follow-key for a peer.ID at the current key epoch.
 func (kb *dsKeyBook) AddFollowKey(t thread.ID, fk *sym.Key) error {
 	if fk == nil {
 		return fmt.Errorf("follow-key is nil")
 	}
-	key := dsThreadKey(t, kbBase).Child(followSuffix)
-	if err := kb.ds.Put(key, fk.Bytes()); err != nil {
+	epoch, err := kb.KeyEpoch(t)
+	if err != nil {
+		return err
+	}
+	key := dsEpochKey(t, epoch).Child(followSuffix)
+	if err := kb.putSecret(key, fk.Bytes()); err != nil {
 		return fmt.Errorf("error when adding follow-key to datastore: %w", err)
 	}
 	return nil
 }
 
+// KeyEpoch returns the current key epoch of thread.ID t. Threads without
+// rotated keys are at epoch zero.
+func (kb *dsKeyBook) KeyEpoch(t thread.ID) (uint64, error) {
+	key := dsThreadKey(t, kbBase).Child(epochSuffix)
+	v, err := kb.ds.Get(key)
+	if err == ds.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error when getting key epoch from datastore: %v", err)
+	}
+	if len(v) != 8 {
+		return 0, fmt.Errorf("invalid key epoch for %s", key)
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+// AddKeysAt adds a follow-key and an optional read-key at epoch. The epoch
+// becomes the current key epoch if it is newer than the current one.
+func (kb *dsKeyBook) AddKeysAt(t thread.ID, epoch uint64, fk *sym.Key, rk *sym.Key) error {
+	if fk == nil {
+		return fmt.Errorf("follow-key is nil")
+	}
+	current, err := kb.KeyEpoch(t)
+	if err != nil {
+		return err
+	}
+
+	// Keys are written before the epoch so that the current epoch always has keys
+	ekey := dsEpochKey(t, epoch)
+	if err = kb.putSecret(ekey.Child(followSuffix), fk.Bytes()); err != nil {
+		return fmt.Errorf("error when adding follow-key to datastore: %w", err)
+	}
+	if rk != nil {
+		if err = kb.putSecret(ekey.Child(readSuffix), rk.Bytes()); err != nil {
+			return fmt.Errorf("error when adding read-key to datastore: %w", err)
+		}
+	}
+	if epoch > current {
+		v := make([]byte, 8)
+		binary.BigEndian.PutUint64(v, epoch)
+		if err = kb.ds.Put(dsThreadKey(t, kbBase).Child(epochSuffix), v); err != nil {
+			return fmt.Errorf("error when adding key epoch to datastore: %w", err)
+		}
+	}
+	return nil
+}
+
 // LogsWithKeys returns a list of log IDs for a thread.
 func (kb *dsKeyBook) LogsWithKeys(t thread.ID) (peer.IDSlice, error) {
 	ids, err := uniqueLogIds(kb.ds, kbBase.ChildString(base32.RawStdEncoding.EncodeToString(t.Bytes())),
@@ -190,3 +293,40 @@ func (kb *dsKeyBook) ThreadsFromKeys() (thread.IDSlice, error) {
 	}
 	return ids, nil
 }
+
+// ClearKeys deletes all keys stored under thread.ID t.
+func (kb *dsKeyBook) ClearKeys(t thread.ID) error {
+	if err := deleteWithPrefix(kb.ds, dsThreadKey(t, kbBase)); err != nil {
+		return fmt.Errorf("error when clearing keys from datastore: %w", err)
+	}
+	return nil
+}
+
+// getSecret returns a secret key's bytes, decrypting them if the key book
+// is encrypted.
+func (kb *dsKeyBook) getSecret(key ds.Key) ([]byte, error) {
+	v, err := kb.ds.Get(key)
+	if err != nil || kb.mk == nil {
+		return v, err
+	}
+	return kb.mk.Decrypt(v)
+}
+
+// putSecret puts a secret key's bytes, encrypting them if the key book
+// is encrypted.
+func (kb *dsKeyBook) putSecret(key ds.Key, v []byte) error {
+	if kb.mk == nil {
+		return kb.ds.Put(key, v)
+	}
+	return putSealed(kb.ds, kb.mk, key, v)
+}
+
+// dsEpochKey returns the base key for follow and read keys at epoch.
+// Keys at epoch zero live directly under the thread for compatibility.
+func dsEpochKey(t thread.ID, epoch uint64) ds.Key {
+	key := dsThreadKey(t, kbBase)
+	if epoch == 0 {
+		return key
+	}
+	return key.ChildString(strconv.FormatUint(epoch, 10))
+}
@@ -0,0 +1,187 @@
+package lstoreds
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+	"golang.org/x/crypto/scrypt"
+)
+
+var (
+	// ErrKeyBookLocked indicates the key book is encrypted and no master key was given.
+	ErrKeyBookLocked = errors.New("key book is encrypted")
+
+	// ErrBadMasterKey indicates the master key can't decrypt the key book.
+	ErrBadMasterKey = errors.New("bad master key")
+)
+
+// The master key salt and a value encrypted with the master key, which is
+// used to check that the key book is unlocked with the right key, are
+// stored under:
+// /thread/masterkey/(salt|check)
+var (
+	mkBase     = ds.NewKey("/thread/masterkey")
+	mkSaltKey  = mkBase.ChildString("salt")
+	mkCheckKey = mkBase.ChildString("check")
+	mkCheck    = []byte("threads")
+)
+
+// Parameters of the scrypt derivation of master keys from passphrases.
+const (
+	scryptN       = 1 << 15
+	scryptR       = 8
+	scryptP       = 1
+	scryptSaltLen = 32
+
+	// scryptKeyLen is the length of a symmetric key with its nonce.
+	scryptKeyLen = 44
+)
+
+// MasterKeyFromPassphrase derives a key book master key from a passphrase
+// using scrypt. The salt is created on first use and kept in store, so the
+// same passphrase always derives the same key for a store.
+func MasterKeyFromPassphrase(store ds.Datastore, passphrase []byte) (*sym.Key, error) {
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase is empty")
+	}
+	salt, err := store.Get(mkSaltKey)
+	if err == ds.ErrNotFound {
+		salt = make([]byte, scryptSaltLen)
+		if _, err = rand.Read(salt); err != nil {
+			return nil, err
+		}
+		if err = store.Put(mkSaltKey, salt); err != nil {
+			return nil, fmt.Errorf("error when putting master key salt in store: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("error when getting master key salt from store: %w", err)
+	}
+	k, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	return sym.NewKey(k)
+}
+
+// IsEncrypted returns whether the key book in store is encrypted with a master key.
+func IsEncrypted(store ds.Datastore) (bool, error) {
+	return store.Has(mkCheckKey)
+}
+
+// RotateMasterKey re-encrypts the key book in store with a new master key.
+// The old key must unlock the key book.
+func RotateMasterKey(store ds.Batching, oldKey, newKey *sym.Key) error {
+	if newKey == nil {
+		return fmt.Errorf("master key is nil")
+	}
+	if err := unlockKeyBook(store, oldKey); err != nil {
+		return err
+	}
+	secrets, err := querySecrets(store)
+	if err != nil {
+		return err
+	}
+	batch, err := store.Batch()
+	if err != nil {
+		return err
+	}
+	for k, v := range secrets {
+		plain, err := oldKey.Decrypt(v)
+		if err != nil {
+			return fmt.Errorf("error when decrypting %s: %w", k, err)
+		}
+		if err = putSealed(batch, newKey, k, plain); err != nil {
+			return err
+		}
+	}
+	if err = putSealed(batch, newKey, mkCheckKey, mkCheck); err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+// unlockKeyBook checks that the master key decrypts the key book in store.
+// A key book that isn't encrypted yet is encrypted in place.
+func unlockKeyBook(store ds.Batching, mk *sym.Key) error {
+	if mk == nil {
+		return fmt.Errorf("master key is nil")
+	}
+	v, err := store.Get(mkCheckKey)
+	if err == nil {
+		check, err := mk.Decrypt(v)
+		if err != nil || !bytes.Equal(check, mkCheck) {
+			return ErrBadMasterKey
+		}
+		return nil
+	}
+	if err != ds.ErrNotFound {
+		return fmt.Errorf("error when getting master key check from store: %w", err)
+	}
+
+	secrets, err := querySecrets(store)
+	if err != nil {
+		return err
+	}
+	batch, err := store.Batch()
+	if err != nil {
+		return err
+	}
+	for k, v := range secrets {
+		if err = putSealed(batch, mk, k, v); err != nil {
+			return err
+		}
+	}
+	// The check is written with the secrets, so a key book is never half encrypted
+	if err = putSealed(batch, mk, mkCheckKey, mkCheck); err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+// querySecrets returns the stored private, follow, and read keys.
+func querySecrets(store ds.Datastore) (map[ds.Key][]byte, error) {
+	results, err := store.Query(query.Query{Prefix: kbBase.String()})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	secrets := make(map[ds.Key][]byte)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		k := ds.RawKey(result.Key)
+		if isSecret(k) {
+			secrets[k] = result.Value
+		}
+	}
+	return secrets, nil
+}
+
+// isSecret returns whether a key book entry holds a secret key.
+func isSecret(k ds.Key) bool {
+	switch "/" + k.Name() {
+	case privSuffix.String(), readSuffix.String(), followSuffix.String():
+		return true
+	default:
+		return false
+	}
+}
+
+// putSealed puts a value encrypted with the master key.
+func putSealed(w ds.Write, mk *sym.Key, k ds.Key, v []byte) error {
+	sealed, err := mk.Encrypt(v)
+	if err != nil {
+		return err
+	}
+	if err = w.Put(k, sealed); err != nil {
+		return fmt.Errorf("error when putting key %s in store: %w", k, err)
+	}
+	return nil
+}
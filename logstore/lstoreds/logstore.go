@@ -9,6 +9,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	core "github.com/textileio/go-threads/core/logstore"
 	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
 	lstore "github.com/textileio/go-threads/logstore"
 	"github.com/whyrusleeping/base32"
 )
@@ -25,6 +26,10 @@ type Options struct {
 	// Initial delay before GC processes start. Intended to give the system breathing room to fully boot
 	// before starting GC.
 	GCInitialDelay time.Duration
+
+	// Master key used to encrypt private, follow, and read keys at rest. If nil, keys are stored
+	// in plaintext. See MasterKeyFromPassphrase.
+	MasterKey *sym.Key
 }
 
 // DefaultOpts returns the default options for a persistent peerstore, with the full-purge GC algorithm:
@@ -47,7 +52,12 @@ func NewLogstore(ctx context.Context, store ds.Batching, opts Options) (core.Log
 		return nil, err
 	}
 
-	keyBook, err := NewKeyBook(store)
+	var keyBook core.KeyBook
+	if opts.MasterKey != nil {
+		keyBook, err = NewEncryptedKeyBook(store, opts.MasterKey)
+	} else {
+		keyBook, err = NewKeyBook(store)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +66,9 @@ func NewLogstore(ctx context.Context, store ds.Batching, opts Options) (core.Log
 
 	headBook := NewHeadBook(store.(ds.TxnDatastore))
 
-	ps := lstore.NewLogstore(keyBook, addrBook, headBook, threadMetadata)
+	outboxBook := NewOutboxBook(store)
+
+	ps := lstore.NewLogstore(keyBook, addrBook, headBook, threadMetadata, outboxBook)
 	return ps, nil
 }
 
@@ -132,6 +144,36 @@ func uniqueLogIds(ds ds.Datastore, prefix ds.Key, extractor func(result query.Re
 	return ids, nil
 }
 
+// deleteWithPrefix deletes all keys nested under prefix.
+func deleteWithPrefix(store ds.Datastore, prefix ds.Key) error {
+	var (
+		q       = query.Query{Prefix: prefix.String() + "/", KeysOnly: true}
+		results query.Results
+		err     error
+	)
+
+	if results, err = store.Query(q); err != nil {
+		return err
+	}
+
+	var keys []ds.Key
+	for result := range results.Next() {
+		if result.Error != nil {
+			results.Close()
+			return result.Error
+		}
+		keys = append(keys, ds.RawKey(result.Key))
+	}
+	results.Close()
+
+	for _, k := range keys {
+		if err = store.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func dsThreadKey(t thread.ID, baseKey ds.Key) ds.Key {
 	key := baseKey.ChildString(base32.RawStdEncoding.EncodeToString(t.Bytes()))
 	return key
@@ -76,6 +76,13 @@ func (ts *dsThreadMetadata) PutBytes(t thread.ID, key string, val []byte) error
 	return ts.setValue(t, key, val)
 }
 
+func (ts *dsThreadMetadata) ClearMetadata(t thread.ID) error {
+	if err := deleteWithPrefix(ts.ds, tmetaBase.ChildString(base32.RawStdEncoding.EncodeToString(t.Bytes()))); err != nil {
+		return fmt.Errorf("error when clearing metadata from datastore: %w", err)
+	}
+	return nil
+}
+
 func keyMeta(t thread.ID, k string) ds.Key {
 	key := tmetaBase.ChildString(base32.RawStdEncoding.EncodeToString(t.Bytes()))
 	key = key.ChildString(k)
@@ -0,0 +1,148 @@
+package lstoreds
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/whyrusleeping/base32"
+)
+
+// Outbox entries are stored in db key pattern:
+// /thread/outbox/<base32 thread id no padding>/<base32 peer id no padding>/<base32 record cid no padding>
+// Key pushes are stored under the name "keys" in place of the record cid.
+var (
+	obBase                 = ds.NewKey("/thread/outbox")
+	_      core.OutboxBook = (*dsOutboxBook)(nil)
+)
+
+// obKeysName is the key name of key push entries, which can't clash with
+// an upper case base32 record cid.
+const obKeysName = "keys"
+
+type dsOutboxBook struct {
+	ds ds.Datastore
+}
+
+// outboxRecord is the serialized form of an outbox entry.
+type outboxRecord struct {
+	Log         []byte
+	Record      []byte
+	Peer        []byte
+	Attempts    int
+	NextAttempt int64
+	LastError   string
+	Created     int64
+}
+
+// NewOutboxBook returns a new OutboxBook backed by a datastore.
+func NewOutboxBook(ds ds.Datastore) core.OutboxBook {
+	return &dsOutboxBook{
+		ds: ds,
+	}
+}
+
+func (ob *dsOutboxBook) PutOutbox(t thread.ID, e core.OutboxEntry) error {
+	rec := outboxRecord{
+		Log:         []byte(e.Log),
+		Record:      e.Record.Bytes(),
+		Peer:        []byte(e.Peer),
+		Attempts:    e.Attempts,
+		NextAttempt: e.NextAttempt.UnixNano(),
+		LastError:   e.LastError,
+	}
+	if !e.Created.IsZero() {
+		rec.Created = e.Created.UnixNano()
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(rec); err != nil {
+		return fmt.Errorf("error when marshaling outbox entry: %w", err)
+	}
+	key := dsOutboxKey(t, e.Peer, e.Record)
+	if err := ob.ds.Put(key, buf.Bytes()); err != nil {
+		return fmt.Errorf("error when saving outbox entry in datastore for %s: %w", key, err)
+	}
+	return nil
+}
+
+func (ob *dsOutboxBook) RemoveOutbox(t thread.ID, p peer.ID, rec cid.Cid) error {
+	key := dsOutboxKey(t, p, rec)
+	if err := ob.ds.Delete(key); err != nil && err != ds.ErrNotFound {
+		return fmt.Errorf("error when deleting outbox entry %s: %w", key, err)
+	}
+	return nil
+}
+
+func (ob *dsOutboxBook) Outbox(t thread.ID) ([]core.OutboxEntry, error) {
+	q := query.Query{Prefix: dsThreadKey(t, obBase).String() + "/"}
+	results, err := ob.ds.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("error when querying outbox entries: %w", err)
+	}
+	defer results.Close()
+
+	var entries []core.OutboxEntry
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, fmt.Errorf("error when iterating outbox entries: %w", result.Error)
+		}
+		var rec outboxRecord
+		if err := gob.NewDecoder(bytes.NewReader(result.Value)).Decode(&rec); err != nil {
+			return nil, fmt.Errorf("error when deserializing outbox entry %s: %w", result.Key, err)
+		}
+		e := core.OutboxEntry{
+			Attempts:    rec.Attempts,
+			NextAttempt: time.Unix(0, rec.NextAttempt),
+			LastError:   rec.LastError,
+		}
+		if rec.Created != 0 {
+			e.Created = time.Unix(0, rec.Created)
+		}
+		if e.Log, err = peer.IDFromBytes(rec.Log); err != nil {
+			return nil, err
+		}
+		if len(rec.Record) > 0 {
+			if e.Record, err = cid.Cast(rec.Record); err != nil {
+				return nil, err
+			}
+		}
+		if e.Peer, err = peer.IDFromBytes(rec.Peer); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (ob *dsOutboxBook) ThreadsFromOutbox() (thread.IDSlice, error) {
+	ids, err := uniqueThreadIds(ob.ds, obBase, func(result query.Result) string {
+		return ds.RawKey(result.Key).Parent().Parent().Name()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while retrieving threads from outbox: %v", err)
+	}
+	return ids, nil
+}
+
+func (ob *dsOutboxBook) ClearOutbox(t thread.ID) error {
+	if err := deleteWithPrefix(ob.ds, dsThreadKey(t, obBase)); err != nil {
+		return fmt.Errorf("error when clearing outbox from datastore: %w", err)
+	}
+	return nil
+}
+
+func dsOutboxKey(t thread.ID, p peer.ID, rec cid.Cid) ds.Key {
+	key := dsLogKey(t, p, obBase)
+	if !rec.Defined() {
+		return key.ChildString(obKeysName)
+	}
+	key = key.ChildString(base32.RawStdEncoding.EncodeToString(rec.Bytes()))
+	return key
+}
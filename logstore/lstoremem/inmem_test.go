@@ -38,6 +38,12 @@ func TestInMemoryMetadataBook(t *testing.T) {
 	})
 }
 
+func TestInMemoryOutboxBook(t *testing.T) {
+	pt.OutboxBookTest(t, func() (core.OutboxBook, func()) {
+		return m.NewOutboxBook(), nil
+	})
+}
+
 func BenchmarkInMemoryLogstore(b *testing.B) {
 	pt.BenchmarkLogstore(b, func() (core.Logstore, func()) {
 		return m.NewLogstore(), nil
@@ -16,8 +16,9 @@ type memoryKeyBook struct {
 
 	pks map[thread.ID]map[peer.ID]crypto.PubKey
 	sks map[thread.ID]map[peer.ID]crypto.PrivKey
-	rks map[thread.ID][]byte
-	fks map[thread.ID][]byte
+	rks map[thread.ID]map[uint64][]byte
+	fks map[thread.ID]map[uint64][]byte
+	eps map[thread.ID]uint64
 }
 
 func (mkb *memoryKeyBook) getPubKey(t thread.ID, p peer.ID) (crypto.PubKey, bool) {
@@ -44,8 +45,9 @@ func NewKeyBook() core.KeyBook {
 	return &memoryKeyBook{
 		pks: map[thread.ID]map[peer.ID]crypto.PubKey{},
 		sks: map[thread.ID]map[peer.ID]crypto.PrivKey{},
-		rks: map[thread.ID][]byte{},
-		fks: map[thread.ID][]byte{},
+		rks: map[thread.ID]map[uint64][]byte{},
+		fks: map[thread.ID]map[uint64][]byte{},
+		eps: map[thread.ID]uint64{},
 	}
 }
 
@@ -135,9 +137,16 @@ func (mkb *memoryKeyBook) AddPrivKey(t thread.ID, p peer.ID, sk crypto.PrivKey)
 	return nil
 }
 
-func (mkb *memoryKeyBook) ReadKey(t thread.ID) (key *sym.Key, err error) {
+func (mkb *memoryKeyBook) ReadKey(t thread.ID) (*sym.Key, error) {
 	mkb.RLock()
-	b := mkb.rks[t]
+	epoch := mkb.eps[t]
+	mkb.RUnlock()
+	return mkb.ReadKeyAt(t, epoch)
+}
+
+func (mkb *memoryKeyBook) ReadKeyAt(t thread.ID, epoch uint64) (key *sym.Key, err error) {
+	mkb.RLock()
+	b := mkb.rks[t][epoch]
 	if b != nil {
 		key, err = sym.NewKey(b)
 	}
@@ -151,14 +160,21 @@ func (mkb *memoryKeyBook) AddReadKey(t thread.ID, key *sym.Key) error {
 	}
 
 	mkb.Lock()
-	mkb.rks[t] = key.Bytes()
+	putEpochKey(mkb.rks, t, mkb.eps[t], key)
 	mkb.Unlock()
 	return nil
 }
 
-func (mkb *memoryKeyBook) FollowKey(t thread.ID) (key *sym.Key, err error) {
+func (mkb *memoryKeyBook) FollowKey(t thread.ID) (*sym.Key, error) {
+	mkb.RLock()
+	epoch := mkb.eps[t]
+	mkb.RUnlock()
+	return mkb.FollowKeyAt(t, epoch)
+}
+
+func (mkb *memoryKeyBook) FollowKeyAt(t thread.ID, epoch uint64) (key *sym.Key, err error) {
 	mkb.RLock()
-	b := mkb.fks[t]
+	b := mkb.fks[t][epoch]
 	if b != nil {
 		key, err = sym.NewKey(b)
 	}
@@ -172,7 +188,48 @@ func (mkb *memoryKeyBook) AddFollowKey(t thread.ID, key *sym.Key) error {
 	}
 
 	mkb.Lock()
-	mkb.fks[t] = key.Bytes()
+	putEpochKey(mkb.fks, t, mkb.eps[t], key)
 	mkb.Unlock()
 	return nil
 }
+
+func (mkb *memoryKeyBook) KeyEpoch(t thread.ID) (uint64, error) {
+	mkb.RLock()
+	defer mkb.RUnlock()
+	return mkb.eps[t], nil
+}
+
+func (mkb *memoryKeyBook) AddKeysAt(t thread.ID, epoch uint64, fk *sym.Key, rk *sym.Key) error {
+	if fk == nil {
+		return errors.New("key is nil (FollowKey)")
+	}
+
+	mkb.Lock()
+	putEpochKey(mkb.fks, t, epoch, fk)
+	if rk != nil {
+		putEpochKey(mkb.rks, t, epoch, rk)
+	}
+	if epoch > mkb.eps[t] {
+		mkb.eps[t] = epoch
+	}
+	mkb.Unlock()
+	return nil
+}
+
+func (mkb *memoryKeyBook) ClearKeys(t thread.ID) error {
+	mkb.Lock()
+	delete(mkb.pks, t)
+	delete(mkb.sks, t)
+	delete(mkb.rks, t)
+	delete(mkb.fks, t)
+	delete(mkb.eps, t)
+	mkb.Unlock()
+	return nil
+}
+
+func putEpochKey(m map[thread.ID]map[uint64][]byte, t thread.ID, epoch uint64, key *sym.Key) {
+	if m[t] == nil {
+		m[t] = make(map[uint64][]byte, 1)
+	}
+	m[t][epoch] = key.Bytes()
+}
@@ -11,5 +11,6 @@ func NewLogstore() core.Logstore {
 		NewKeyBook(),
 		NewAddrBook(),
 		NewHeadBook(),
-		NewThreadMetadata())
+		NewThreadMetadata(),
+		NewOutboxBook())
 }
@@ -72,6 +72,17 @@ func (ts *memoryThreadMetadata) GetBytes(t thread.ID, key string) (*[]byte, erro
 	return &val, nil
 }
 
+func (ts *memoryThreadMetadata) ClearMetadata(t thread.ID) error {
+	ts.dslock.Lock()
+	defer ts.dslock.Unlock()
+	for k := range ts.ds {
+		if k.id.Equals(t) {
+			delete(ts.ds, k)
+		}
+	}
+	return nil
+}
+
 func (ts *memoryThreadMetadata) putValue(t thread.ID, key string, val interface{}) {
 	ts.dslock.Lock()
 	defer ts.dslock.Unlock()
@@ -0,0 +1,88 @@
+package lstoremem
+
+import (
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+type outboxKey struct {
+	peer peer.ID
+	rec  cid.Cid
+}
+
+type memoryOutboxBook struct {
+	sync.RWMutex
+
+	entries map[thread.ID]map[outboxKey]core.OutboxEntry
+}
+
+var _ core.OutboxBook = (*memoryOutboxBook)(nil)
+
+func NewOutboxBook() core.OutboxBook {
+	return &memoryOutboxBook{
+		entries: map[thread.ID]map[outboxKey]core.OutboxEntry{},
+	}
+}
+
+func (mob *memoryOutboxBook) PutOutbox(t thread.ID, e core.OutboxEntry) error {
+	mob.Lock()
+	defer mob.Unlock()
+
+	emap := mob.entries[t]
+	if emap == nil {
+		emap = make(map[outboxKey]core.OutboxEntry, 1)
+		mob.entries[t] = emap
+	}
+	emap[outboxKey{peer: e.Peer, rec: e.Record}] = e
+	return nil
+}
+
+func (mob *memoryOutboxBook) RemoveOutbox(t thread.ID, p peer.ID, rec cid.Cid) error {
+	mob.Lock()
+	defer mob.Unlock()
+
+	emap := mob.entries[t]
+	if emap == nil {
+		return nil
+	}
+	delete(emap, outboxKey{peer: p, rec: rec})
+	if len(emap) == 0 {
+		delete(mob.entries, t)
+	}
+	return nil
+}
+
+func (mob *memoryOutboxBook) Outbox(t thread.ID) ([]core.OutboxEntry, error) {
+	mob.RLock()
+	defer mob.RUnlock()
+
+	emap := mob.entries[t]
+	entries := make([]core.OutboxEntry, 0, len(emap))
+	for _, e := range emap {
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (mob *memoryOutboxBook) ThreadsFromOutbox() (thread.IDSlice, error) {
+	mob.RLock()
+	defer mob.RUnlock()
+
+	ids := make(thread.IDSlice, 0, len(mob.entries))
+	for t := range mob.entries {
+		ids = append(ids, t)
+	}
+	return ids, nil
+}
+
+func (mob *memoryOutboxBook) ClearOutbox(t thread.ID) error {
+	mob.Lock()
+	defer mob.Unlock()
+
+	delete(mob.entries, t)
+	return nil
+}
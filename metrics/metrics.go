@@ -0,0 +1,217 @@
+// Package metrics defines the Prometheus collectors of the thread service,
+// stores and APIs, and serves them over HTTP.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	logging "github.com/ipfs/go-log"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/textileio/go-threads/util"
+)
+
+var log = logging.Logger("metrics")
+
+const namespace = "threads"
+
+// Service collectors.
+var (
+	// Pulls counts thread pulls by result.
+	Pulls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "pulls_total",
+		Help:      "Thread pulls by result.",
+	}, []string{"result"})
+
+	// PullDuration observes the duration of thread pulls.
+	PullDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "pull_duration_seconds",
+		Help:      "Duration of thread pulls.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	// Pushes counts pushes to peers by kind (log or record) and result.
+	Pushes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "pushes_total",
+		Help:      "Log and record pushes to peers by kind and result.",
+	}, []string{"kind", "result"})
+
+	// RecordsReceived counts records pushed by peers.
+	RecordsReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "records_received_total",
+		Help:      "Records pushed by peers.",
+	})
+
+	// RecordsRejected counts records from peers that weren't added, by reason.
+	RecordsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "records_rejected_total",
+		Help:      "Records from peers that weren't added, by reason.",
+	}, []string{"reason"})
+
+	// PutRecordDuration observes the duration of adding records and their
+	// unknown ancestors.
+	PutRecordDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "put_record_duration_seconds",
+		Help:      "Duration of adding a record and its unknown ancestors.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	// PubsubMessages counts validated pubsub messages by result.
+	PubsubMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "pubsub_messages_total",
+		Help:      "Validated pubsub messages by result.",
+	}, []string{"result"})
+
+	// SubscriberDrops counts records that subscribers didn't receive in time.
+	SubscriberDrops = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "subscriber_drops_total",
+		Help:      "Records that subscribers didn't receive in time.",
+	})
+)
+
+// BroadcastSendTimeouts counts broadcast values that a listener didn't
+// receive in time.
+var BroadcastSendTimeouts = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "broadcast",
+	Name:      "send_timeouts_total",
+	Help:      "Broadcast values that a listener didn't receive in time.",
+})
+
+// Store collectors.
+var (
+	// DispatchDuration observes the duration of persisting and reducing events.
+	DispatchDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "store",
+		Name:      "dispatch_duration_seconds",
+		Help:      "Duration of persisting and reducing a batch of events.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	// ReduceDuration observes the duration of reducing events into models.
+	ReduceDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "store",
+		Name:      "reduce_duration_seconds",
+		Help:      "Duration of reducing a batch of events into models.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	// QueryDuration observes the duration of model queries by model.
+	QueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "store",
+		Name:      "query_duration_seconds",
+		Help:      "Duration of model queries by model.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"model"})
+)
+
+// ActiveStreams tracks the open gRPC streams of an API by method.
+var ActiveStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "api",
+	Name:      "active_streams",
+	Help:      "Open gRPC streams by API and method.",
+}, []string{"api", "method"})
+
+// RegisterService registers the service collectors.
+func RegisterService() {
+	register(
+		Pulls,
+		PullDuration,
+		Pushes,
+		RecordsReceived,
+		RecordsRejected,
+		PutRecordDuration,
+		PubsubMessages,
+		SubscriberDrops,
+		BroadcastSendTimeouts)
+}
+
+// RegisterStore registers the store collectors.
+func RegisterStore() {
+	register(
+		DispatchDuration,
+		ReduceDuration,
+		QueryDuration,
+		BroadcastSendTimeouts)
+}
+
+// RegisterAPI registers the API collectors.
+func RegisterAPI() {
+	register(ActiveStreams)
+}
+
+// register adds collectors to the default registry.
+// Collectors that are already registered are skipped.
+func register(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		if err := prometheus.Register(c); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+				log.Errorf("error registering collector: %s", err)
+			}
+		}
+	}
+}
+
+// Result returns the result label value of err.
+func Result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+// ObserveSince observes the seconds elapsed since start.
+func ObserveSince(o prometheus.Observer, start time.Time) {
+	o.Observe(time.Since(start).Seconds())
+}
+
+// TrackStream counts an open stream of an API method.
+// The returned func ends the stream.
+func TrackStream(api, method string) func() {
+	g := ActiveStreams.WithLabelValues(api, method)
+	g.Inc()
+	return g.Dec
+}
+
+// NewServer serves the registered collectors in the Prometheus text format
+// at /metrics on addr.
+func NewServer(addr ma.Multiaddr) (*http.Server, error) {
+	haddr, err := util.TCPAddrFromMultiAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	s := &http.Server{
+		Addr:    haddr,
+		Handler: mux,
+	}
+	go func() {
+		if err := s.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Errorf("metrics server error: %s", err)
+		}
+	}()
+	return s, nil
+}
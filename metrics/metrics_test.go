@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRegister(t *testing.T) {
+	// Registering twice, as with several services in a process, is a no-op
+	RegisterService()
+	RegisterService()
+	RegisterStore()
+	RegisterAPI()
+}
+
+func TestTrackStream(t *testing.T) {
+	g := ActiveStreams.WithLabelValues("test", "Stream")
+	end1 := TrackStream("test", "Stream")
+	end2 := TrackStream("test", "Stream")
+	if v := testutil.ToFloat64(g); v != 2 {
+		t.Fatalf("expected 2 active streams got %v", v)
+	}
+	end1()
+	end2()
+	if v := testutil.ToFloat64(g); v != 0 {
+		t.Fatalf("expected 0 active streams got %v", v)
+	}
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	format "github.com/ipfs/go-ipld-format"
@@ -11,6 +12,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/cbor"
+	lstore "github.com/textileio/go-threads/core/logstore"
 	core "github.com/textileio/go-threads/core/service"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/crypto"
@@ -116,9 +118,133 @@ func (c *Client) PullThread(ctx context.Context, id thread.ID) error {
 	return err
 }
 
-func (c *Client) DeleteThread(ctx context.Context, id thread.ID) error {
+func (c *Client) DeleteThread(ctx context.Context, id thread.ID, opts ...core.DeleteOption) error {
+	args := &core.DeleteOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
 	_, err := c.c.DeleteThread(ctx, &pb.DeleteThreadRequest{
+		ThreadID:     id.Bytes(),
+		DeleteBlocks: args.Blocks,
+	})
+	return err
+}
+
+func (c *Client) GetKeysAt(ctx context.Context, id thread.ID, epoch uint64) (fk *symmetric.Key, rk *symmetric.Key, err error) {
+	resp, err := c.c.GetKeysAt(ctx, &pb.GetKeysAtRequest{
+		ThreadID: id.Bytes(),
+		KeyEpoch: epoch,
+	})
+	if err != nil {
+		return
+	}
+	if resp.FollowKey != nil {
+		if fk, err = symmetric.NewKey(resp.FollowKey); err != nil {
+			return
+		}
+	}
+	if resp.ReadKey != nil {
+		if rk, err = symmetric.NewKey(resp.ReadKey); err != nil {
+			return
+		}
+	}
+	return fk, rk, nil
+}
+
+func (c *Client) RotateKeys(ctx context.Context, id thread.ID, opts ...core.RotateOption) (info thread.Info, err error) {
+	args := &core.RotateOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	remove := make([][]byte, len(args.Remove))
+	for i, lid := range args.Remove {
+		remove[i], err = lid.Marshal()
+		if err != nil {
+			return
+		}
+	}
+	resp, err := c.c.RotateKeys(ctx, &pb.RotateKeysRequest{
+		ThreadID:     id.Bytes(),
+		RemoveLogIDs: remove,
+	})
+	if err != nil {
+		return
+	}
+	return threadInfoFromProto(resp)
+}
+
+func (c *Client) GetACL(ctx context.Context, id thread.ID) (list thread.ACL, err error) {
+	resp, err := c.c.GetACL(ctx, &pb.GetACLRequest{
+		ThreadID: id.Bytes(),
+	})
+	if err != nil {
+		return
+	}
+	list.Version = resp.Version
+	list.Roles = make(map[peer.ID]thread.Role, len(resp.Entries))
+	for _, e := range resp.Entries {
+		pid, err := peer.IDFromBytes(e.ID)
+		if err != nil {
+			return list, err
+		}
+		list.Roles[pid] = thread.Role(e.Role)
+	}
+	return list, nil
+}
+
+func (c *Client) UpdateACL(ctx context.Context, id thread.ID, roles map[peer.ID]thread.Role) (core.ThreadRecord, error) {
+	entries := make([]*pb.ACLEntry, 0, len(roles))
+	for pid, role := range roles {
+		pidb, err := pid.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &pb.ACLEntry{
+			ID:   pidb,
+			Role: int32(role),
+		})
+	}
+	resp, err := c.c.UpdateACL(ctx, &pb.UpdateACLRequest{
 		ThreadID: id.Bytes(),
+		Entries:  entries,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return threadRecordFromProto(resp, core.FollowKeys(ctx, c, id))
+}
+
+func (c *Client) GetPeerPolicy(ctx context.Context, id thread.ID) (policy thread.PeerPolicy, err error) {
+	resp, err := c.c.GetPeerPolicy(ctx, &pb.GetPeerPolicyRequest{
+		ThreadID: id.Bytes(),
+	})
+	if err != nil {
+		return
+	}
+	if policy.Allow, err = peerIDsFromBytes(resp.Allow); err != nil {
+		return
+	}
+	if policy.Deny, err = peerIDsFromBytes(resp.Deny); err != nil {
+		return
+	}
+	policy.LogPeersOnly = resp.LogPeersOnly
+	return policy, nil
+}
+
+func (c *Client) SetPeerPolicy(ctx context.Context, id thread.ID, policy thread.PeerPolicy) error {
+	allow, err := peerIDsToBytes(policy.Allow)
+	if err != nil {
+		return err
+	}
+	deny, err := peerIDsToBytes(policy.Deny)
+	if err != nil {
+		return err
+	}
+	_, err = c.c.SetPeerPolicy(ctx, &pb.SetPeerPolicyRequest{
+		ThreadID:     id.Bytes(),
+		Allow:        allow,
+		Deny:         deny,
+		LogPeersOnly: policy.LogPeersOnly,
 	})
 	return err
 }
@@ -134,19 +260,73 @@ func (c *Client) AddFollower(ctx context.Context, id thread.ID, paddr ma.Multiad
 	return peer.IDFromBytes(resp.PeerID)
 }
 
-func (c *Client) CreateRecord(ctx context.Context, id thread.ID, body format.Node) (core.ThreadRecord, error) {
-	info, err := c.GetThread(ctx, id)
+// AddReplicator to a thread. Replicators are only sent the follow-key.
+func (c *Client) AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multiaddr) (peer.ID, error) {
+	resp, err := c.c.AddReplicator(ctx, &pb.AddReplicatorRequest{
+		ThreadID: id.Bytes(),
+		Addr:     paddr.Bytes(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return peer.IDFromBytes(resp.PeerID)
+}
+
+func (c *Client) CreateInvite(ctx context.Context, id thread.ID, invitee peer.ID, ttl time.Duration) ([]byte, error) {
+	inviteeb, err := invitee.Marshal()
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.c.CreateRecord(ctx, &pb.CreateRecordRequest{
+	resp, err := c.c.CreateInvite(ctx, &pb.CreateInviteRequest{
 		ThreadID: id.Bytes(),
-		Body:     body.RawData(),
+		Invitee:  inviteeb,
+		Ttl:      int64(ttl),
 	})
 	if err != nil {
 		return nil, err
 	}
-	return threadRecordFromProto(resp, info.FollowKey)
+	return resp.Invite, nil
+}
+
+func (c *Client) AcceptInvite(ctx context.Context, invite []byte) (info thread.Info, err error) {
+	resp, err := c.c.AcceptInvite(ctx, &pb.AcceptInviteRequest{
+		Invite: invite,
+	})
+	if err != nil {
+		return
+	}
+	return threadInfoFromProto(resp)
+}
+
+func (c *Client) CreateRecord(
+	ctx context.Context,
+	id thread.ID,
+	body format.Node,
+	opts ...core.EventOption,
+) (core.ThreadRecord, error) {
+	args := &core.EventOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	req := &pb.CreateRecordRequest{
+		ThreadID:      id.Bytes(),
+		Body:          body.RawData(),
+		ContentType:   args.ContentType,
+		Codec:         args.Codec,
+		SchemaVersion: args.SchemaVersion,
+		ChunkSize:     int64(args.ChunkSize),
+	}
+	if args.Author != "" {
+		var err error
+		if req.Author, err = args.Author.Marshal(); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := c.c.CreateRecord(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return threadRecordFromProto(resp, core.FollowKeys(ctx, c, id))
 }
 
 func (c *Client) AddRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core.Record) error {
@@ -164,10 +344,6 @@ func (c *Client) AddRecord(ctx context.Context, id thread.ID, lid peer.ID, rec c
 }
 
 func (c *Client) GetRecord(ctx context.Context, id thread.ID, rid cid.Cid) (core.Record, error) {
-	info, err := c.GetThread(ctx, id)
-	if err != nil {
-		return nil, err
-	}
 	resp, err := c.c.GetRecord(ctx, &pb.GetRecordRequest{
 		ThreadID: id.Bytes(),
 		RecordID: rid.Bytes(),
@@ -175,7 +351,76 @@ func (c *Client) GetRecord(ctx context.Context, id thread.ID, rid cid.Cid) (core
 	if err != nil {
 		return nil, err
 	}
-	return cbor.RecordFromProto(util.RecToServiceRec(resp.Record), info.FollowKey)
+	return cbor.RecordFromProto(util.RecToServiceRec(resp.Record), core.FollowKeys(ctx, c, id))
+}
+
+func (c *Client) ListRecords(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	from, to cid.Cid,
+	limit int,
+) ([]core.Record, cid.Cid, error) {
+	lidb, err := lid.Marshal()
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	req := &pb.ListRecordsRequest{
+		ThreadID: id.Bytes(),
+		LogID:    lidb,
+		Limit:    int32(limit),
+	}
+	if from.Defined() {
+		req.From = from.Bytes()
+	}
+	if to.Defined() {
+		req.To = to.Bytes()
+	}
+	resp, err := c.c.ListRecords(ctx, req)
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	keys := core.FollowKeys(ctx, c, id)
+	recs := make([]core.Record, len(resp.Records))
+	for i, r := range resp.Records {
+		recs[i], err = cbor.RecordFromProto(util.RecToServiceRec(r), keys)
+		if err != nil {
+			return nil, cid.Undef, err
+		}
+	}
+	next := cid.Undef
+	if len(resp.Next) != 0 {
+		next, err = cid.Cast(resp.Next)
+		if err != nil {
+			return nil, cid.Undef, err
+		}
+	}
+	return recs, next, nil
+}
+
+func (c *Client) ListThreadRecords(
+	ctx context.Context,
+	id thread.ID,
+	token string,
+	limit int,
+) ([]core.ThreadRecord, string, error) {
+	resp, err := c.c.ListThreadRecords(ctx, &pb.ListThreadRecordsRequest{
+		ThreadID: id.Bytes(),
+		Token:    token,
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	keys := core.FollowKeys(ctx, c, id)
+	recs := make([]core.ThreadRecord, len(resp.Records))
+	for i, r := range resp.Records {
+		recs[i], err = threadRecordFromProto(r, keys)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return recs, resp.Next, nil
 }
 
 func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan core.ThreadRecord, error) {
@@ -187,13 +432,29 @@ func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan
 	for i, id := range args.ThreadIDs {
 		threadIDs[i] = id.Bytes()
 	}
+	var cursor []*pb.LogCursor
+	for lid, rid := range args.Cursor {
+		if !rid.Defined() {
+			continue // Replayed from the first record
+		}
+		lidb, err := lid.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		cursor = append(cursor, &pb.LogCursor{
+			LogID:    lidb,
+			RecordID: rid.Bytes(),
+		})
+	}
 	stream, err := c.c.Subscribe(ctx, &pb.SubscribeRequest{
 		ThreadIDs: threadIDs,
+		Replay:    args.Cursor != nil,
+		Cursor:    cursor,
 	})
 	if err != nil {
 		return nil, err
 	}
-	threads := make(map[thread.ID]*symmetric.Key) // Follow-key cache
+	threads := make(map[thread.ID]crypto.KeyRing) // Follow-key cache
 	channel := make(chan core.ThreadRecord)
 	go func() {
 		defer close(channel)
@@ -214,19 +475,10 @@ func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan
 				log.Errorf("error casting thread ID: %v", err)
 				continue
 			}
-			var fk *symmetric.Key
+			var fk crypto.KeyRing
 			var ok bool
 			if fk, ok = threads[threadID]; !ok {
-				info, err := c.GetThread(ctx, threadID)
-				if err != nil {
-					log.Errorf("error getting thread: %v", err)
-					continue
-				}
-				if info.FollowKey == nil {
-					log.Error("follow-key not found")
-					continue
-				}
-				fk = info.FollowKey
+				fk = core.FollowKeys(ctx, c, threadID)
 				threads[threadID] = fk
 			}
 			rec, err := threadRecordFromProto(resp, fk)
@@ -240,6 +492,42 @@ func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan
 	return channel, nil
 }
 
+func (c *Client) GetOutbox(ctx context.Context, id thread.ID) ([]lstore.OutboxEntry, error) {
+	resp, err := c.c.GetOutbox(ctx, &pb.GetOutboxRequest{
+		ThreadID: id.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]lstore.OutboxEntry, len(resp.Entries))
+	for i, e := range resp.Entries {
+		entries[i] = lstore.OutboxEntry{
+			Attempts:    int(e.Attempts),
+			NextAttempt: time.Unix(0, e.NextAttempt),
+			LastError:   e.LastError,
+		}
+		if entries[i].Log, err = peer.IDFromBytes(e.LogID); err != nil {
+			return nil, err
+		}
+		if entries[i].Record, err = cid.Cast(e.RecordID); err != nil {
+			return nil, err
+		}
+		if entries[i].Peer, err = peer.IDFromBytes(e.PeerID); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// GC collects the blockstore garbage and returns the number of removed blocks.
+func (c *Client) GC(ctx context.Context) (int, error) {
+	resp, err := c.c.GC(ctx, &pb.GCRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Removed), nil
+}
+
 func getThreadKeys(args *core.KeyOptions) (*pb.ThreadKeys, error) {
 	keys := &pb.ThreadKeys{}
 	if args.FollowKey != nil {
@@ -255,6 +543,7 @@ func getThreadKeys(args *core.KeyOptions) (*pb.ThreadKeys, error) {
 			return nil, err
 		}
 	}
+	keys.KeyEpoch = args.KeyEpoch
 	return keys, nil
 }
 
@@ -318,10 +607,11 @@ func threadInfoFromProto(reply *pb.ThreadInfoReply) (info thread.Info, err error
 		Logs:      logs,
 		FollowKey: fk,
 		ReadKey:   rk,
+		KeyEpoch:  reply.KeyEpoch,
 	}, nil
 }
 
-func threadRecordFromProto(reply *pb.NewRecordReply, key crypto.DecryptionKey) (core.ThreadRecord, error) {
+func threadRecordFromProto(reply *pb.NewRecordReply, keys crypto.KeyRing) (core.ThreadRecord, error) {
 	threadID, err := thread.Cast(reply.ThreadID)
 	if err != nil {
 		return nil, err
@@ -330,9 +620,33 @@ func threadRecordFromProto(reply *pb.NewRecordReply, key crypto.DecryptionKey) (
 	if err != nil {
 		return nil, err
 	}
-	rec, err := cbor.RecordFromProto(util.RecToServiceRec(reply.Record), key)
+	rec, err := cbor.RecordFromProto(util.RecToServiceRec(reply.Record), keys)
 	if err != nil {
 		return nil, err
 	}
 	return service.NewRecord(rec, threadID, logID), nil
 }
+
+func peerIDsToBytes(ids []peer.ID) ([][]byte, error) {
+	bs := make([][]byte, len(ids))
+	for i, id := range ids {
+		b, err := id.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		bs[i] = b
+	}
+	return bs, nil
+}
+
+func peerIDsFromBytes(bs [][]byte) ([]peer.ID, error) {
+	ids := make([]peer.ID, len(bs))
+	for i, b := range bs {
+		id, err := peer.IDFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	crand "crypto/rand"
 	"fmt"
@@ -10,7 +11,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -232,11 +232,11 @@ func TestClient_AddRecord(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		event, err := cbor.CreateEvent(context.Background(), nil, body, rk)
+		event, err := cbor.CreateEvent(context.Background(), nil, body, rk, 0, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
-		rec, err := cbor.CreateRecord(context.Background(), nil, event, cid.Undef, sk, fk)
+		rec, err := cbor.CreateRecord(context.Background(), nil, event, nil, sk, fk, 0)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -290,6 +290,44 @@ func TestClient_GetRecord(t *testing.T) {
 	})
 }
 
+func TestClient_GetRecordRotated(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := client.CreateRecord(context.Background(), info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.RotateKeys(context.Background(), info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test get record from previous key epoch", func(t *testing.T) {
+		fk, _, err := client.GetKeysAt(context.Background(), info.ID, info.KeyEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fk == nil || !bytes.Equal(fk.Bytes(), info.FollowKey.Bytes()) {
+			t.Fatal("expected follow-key of previous key epoch")
+		}
+		rec2, err := client.GetRecord(context.Background(), info.ID, rec.Value().Cid())
+		if err != nil {
+			t.Fatalf("failed to get record: %v", err)
+		}
+		if !rec2.Cid().Equals(rec.Value().Cid()) {
+			t.Fatal("got bad record from get record")
+		}
+	})
+}
+
 func TestClient_Subscribe(t *testing.T) {
 	t.Parallel()
 	_, client1, done1 := setup(t)
@@ -98,6 +98,7 @@ type ThreadKeys struct {
 	ReadKey              []byte   `protobuf:"bytes,1,opt,name=readKey,proto3" json:"readKey,omitempty"`
 	FollowKey            []byte   `protobuf:"bytes,2,opt,name=followKey,proto3" json:"followKey,omitempty"`
 	LogKey               []byte   `protobuf:"bytes,3,opt,name=logKey,proto3" json:"logKey,omitempty"`
+	KeyEpoch             uint64   `protobuf:"varint,4,opt,name=keyEpoch,proto3" json:"keyEpoch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -149,6 +150,13 @@ func (m *ThreadKeys) GetLogKey() []byte {
 	return nil
 }
 
+func (m *ThreadKeys) GetKeyEpoch() uint64 {
+	if m != nil {
+		return m.KeyEpoch
+	}
+	return 0
+}
+
 type CreateThreadRequest struct {
 	ThreadID             []byte      `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
 	Keys                 *ThreadKeys `protobuf:"bytes,2,opt,name=keys,proto3" json:"keys,omitempty"`
@@ -272,6 +280,7 @@ type ThreadInfoReply struct {
 	Logs                 []*LogInfo `protobuf:"bytes,2,rep,name=logs,proto3" json:"logs,omitempty"`
 	ReadKey              []byte     `protobuf:"bytes,3,opt,name=readKey,proto3" json:"readKey,omitempty"`
 	FollowKey            []byte     `protobuf:"bytes,4,opt,name=followKey,proto3" json:"followKey,omitempty"`
+	KeyEpoch             uint64     `protobuf:"varint,5,opt,name=keyEpoch,proto3" json:"keyEpoch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
@@ -330,6 +339,13 @@ func (m *ThreadInfoReply) GetFollowKey() []byte {
 	return nil
 }
 
+func (m *ThreadInfoReply) GetKeyEpoch() uint64 {
+	if m != nil {
+		return m.KeyEpoch
+	}
+	return 0
+}
+
 type AddThreadRequest struct {
 	Addr                 []byte      `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
 	Keys                 *ThreadKeys `protobuf:"bytes,2,opt,name=keys,proto3" json:"keys,omitempty"`
@@ -488,6 +504,7 @@ var xxx_messageInfo_PullThreadReply proto.InternalMessageInfo
 
 type DeleteThreadRequest struct {
 	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	DeleteBlocks         bool     `protobuf:"varint,2,opt,name=deleteBlocks,proto3" json:"deleteBlocks,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -525,6 +542,13 @@ func (m *DeleteThreadRequest) GetThreadID() []byte {
 	return nil
 }
 
+func (m *DeleteThreadRequest) GetDeleteBlocks() bool {
+	if m != nil {
+		return m.DeleteBlocks
+	}
+	return false
+}
+
 type DeleteThreadReply struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -556,360 +580,1081 @@ func (m *DeleteThreadReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeleteThreadReply proto.InternalMessageInfo
 
-type AddFollowerRequest struct {
+type GetKeysAtRequest struct {
 	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	Addr                 []byte   `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	KeyEpoch             uint64   `protobuf:"varint,2,opt,name=keyEpoch,proto3" json:"keyEpoch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddFollowerRequest) Reset()         { *m = AddFollowerRequest{} }
-func (m *AddFollowerRequest) String() string { return proto.CompactTextString(m) }
-func (*AddFollowerRequest) ProtoMessage()    {}
-func (*AddFollowerRequest) Descriptor() ([]byte, []int) {
+func (m *GetKeysAtRequest) Reset()         { *m = GetKeysAtRequest{} }
+func (m *GetKeysAtRequest) String() string { return proto.CompactTextString(m) }
+func (*GetKeysAtRequest) ProtoMessage()    {}
+func (*GetKeysAtRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
 }
 
-func (m *AddFollowerRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddFollowerRequest.Unmarshal(m, b)
+func (m *GetKeysAtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetKeysAtRequest.Unmarshal(m, b)
 }
-func (m *AddFollowerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddFollowerRequest.Marshal(b, m, deterministic)
+func (m *GetKeysAtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetKeysAtRequest.Marshal(b, m, deterministic)
 }
-func (m *AddFollowerRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddFollowerRequest.Merge(m, src)
+func (m *GetKeysAtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetKeysAtRequest.Merge(m, src)
 }
-func (m *AddFollowerRequest) XXX_Size() int {
-	return xxx_messageInfo_AddFollowerRequest.Size(m)
+func (m *GetKeysAtRequest) XXX_Size() int {
+	return xxx_messageInfo_GetKeysAtRequest.Size(m)
 }
-func (m *AddFollowerRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddFollowerRequest.DiscardUnknown(m)
+func (m *GetKeysAtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetKeysAtRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddFollowerRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetKeysAtRequest proto.InternalMessageInfo
 
-func (m *AddFollowerRequest) GetThreadID() []byte {
+func (m *GetKeysAtRequest) GetThreadID() []byte {
 	if m != nil {
 		return m.ThreadID
 	}
 	return nil
 }
 
-func (m *AddFollowerRequest) GetAddr() []byte {
+func (m *GetKeysAtRequest) GetKeyEpoch() uint64 {
 	if m != nil {
-		return m.Addr
+		return m.KeyEpoch
 	}
-	return nil
+	return 0
 }
 
-type AddFollowerReply struct {
-	PeerID               []byte   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+type RotateKeysRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	RemoveLogIDs         [][]byte `protobuf:"bytes,2,rep,name=removeLogIDs,proto3" json:"removeLogIDs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddFollowerReply) Reset()         { *m = AddFollowerReply{} }
-func (m *AddFollowerReply) String() string { return proto.CompactTextString(m) }
-func (*AddFollowerReply) ProtoMessage()    {}
-func (*AddFollowerReply) Descriptor() ([]byte, []int) {
+func (m *RotateKeysRequest) Reset()         { *m = RotateKeysRequest{} }
+func (m *RotateKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateKeysRequest) ProtoMessage()    {}
+func (*RotateKeysRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
 }
 
-func (m *AddFollowerReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddFollowerReply.Unmarshal(m, b)
+func (m *RotateKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateKeysRequest.Unmarshal(m, b)
 }
-func (m *AddFollowerReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddFollowerReply.Marshal(b, m, deterministic)
+func (m *RotateKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateKeysRequest.Marshal(b, m, deterministic)
 }
-func (m *AddFollowerReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddFollowerReply.Merge(m, src)
+func (m *RotateKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateKeysRequest.Merge(m, src)
 }
-func (m *AddFollowerReply) XXX_Size() int {
-	return xxx_messageInfo_AddFollowerReply.Size(m)
+func (m *RotateKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_RotateKeysRequest.Size(m)
 }
-func (m *AddFollowerReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddFollowerReply.DiscardUnknown(m)
+func (m *RotateKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateKeysRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddFollowerReply proto.InternalMessageInfo
+var xxx_messageInfo_RotateKeysRequest proto.InternalMessageInfo
 
-func (m *AddFollowerReply) GetPeerID() []byte {
+func (m *RotateKeysRequest) GetThreadID() []byte {
 	if m != nil {
-		return m.PeerID
+		return m.ThreadID
 	}
 	return nil
 }
 
-type CreateRecordRequest struct {
-	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	Body                 []byte   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+func (m *RotateKeysRequest) GetRemoveLogIDs() [][]byte {
+	if m != nil {
+		return m.RemoveLogIDs
+	}
+	return nil
+}
+
+type ACLEntry struct {
+	ID                   []byte   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Role                 int32    `protobuf:"varint,2,opt,name=role,proto3" json:"role,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateRecordRequest) Reset()         { *m = CreateRecordRequest{} }
-func (m *CreateRecordRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateRecordRequest) ProtoMessage()    {}
-func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
+func (m *ACLEntry) Reset()         { *m = ACLEntry{} }
+func (m *ACLEntry) String() string { return proto.CompactTextString(m) }
+func (*ACLEntry) ProtoMessage()    {}
+func (*ACLEntry) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
 }
 
-func (m *CreateRecordRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateRecordRequest.Unmarshal(m, b)
+func (m *ACLEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ACLEntry.Unmarshal(m, b)
 }
-func (m *CreateRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateRecordRequest.Marshal(b, m, deterministic)
+func (m *ACLEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ACLEntry.Marshal(b, m, deterministic)
 }
-func (m *CreateRecordRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateRecordRequest.Merge(m, src)
+func (m *ACLEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ACLEntry.Merge(m, src)
 }
-func (m *CreateRecordRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateRecordRequest.Size(m)
+func (m *ACLEntry) XXX_Size() int {
+	return xxx_messageInfo_ACLEntry.Size(m)
 }
-func (m *CreateRecordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateRecordRequest.DiscardUnknown(m)
+func (m *ACLEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ACLEntry.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateRecordRequest proto.InternalMessageInfo
+var xxx_messageInfo_ACLEntry proto.InternalMessageInfo
 
-func (m *CreateRecordRequest) GetThreadID() []byte {
+func (m *ACLEntry) GetID() []byte {
 	if m != nil {
-		return m.ThreadID
+		return m.ID
 	}
 	return nil
 }
 
-func (m *CreateRecordRequest) GetBody() []byte {
+func (m *ACLEntry) GetRole() int32 {
 	if m != nil {
-		return m.Body
+		return m.Role
 	}
-	return nil
+	return 0
 }
 
-type Record struct {
-	RecordNode           []byte   `protobuf:"bytes,1,opt,name=recordNode,proto3" json:"recordNode,omitempty"`
-	EventNode            []byte   `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
-	HeaderNode           []byte   `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
-	BodyNode             []byte   `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
+type GetACLRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Record) Reset()         { *m = Record{} }
-func (m *Record) String() string { return proto.CompactTextString(m) }
-func (*Record) ProtoMessage()    {}
-func (*Record) Descriptor() ([]byte, []int) {
+func (m *GetACLRequest) Reset()         { *m = GetACLRequest{} }
+func (m *GetACLRequest) String() string { return proto.CompactTextString(m) }
+func (*GetACLRequest) ProtoMessage()    {}
+func (*GetACLRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
 }
 
-func (m *Record) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Record.Unmarshal(m, b)
+func (m *GetACLRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetACLRequest.Unmarshal(m, b)
 }
-func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Record.Marshal(b, m, deterministic)
+func (m *GetACLRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetACLRequest.Marshal(b, m, deterministic)
 }
-func (m *Record) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Record.Merge(m, src)
+func (m *GetACLRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetACLRequest.Merge(m, src)
 }
-func (m *Record) XXX_Size() int {
-	return xxx_messageInfo_Record.Size(m)
+func (m *GetACLRequest) XXX_Size() int {
+	return xxx_messageInfo_GetACLRequest.Size(m)
 }
-func (m *Record) XXX_DiscardUnknown() {
-	xxx_messageInfo_Record.DiscardUnknown(m)
+func (m *GetACLRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetACLRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Record proto.InternalMessageInfo
+var xxx_messageInfo_GetACLRequest proto.InternalMessageInfo
 
-func (m *Record) GetRecordNode() []byte {
+func (m *GetACLRequest) GetThreadID() []byte {
 	if m != nil {
-		return m.RecordNode
+		return m.ThreadID
 	}
 	return nil
 }
 
-func (m *Record) GetEventNode() []byte {
+type ACLReply struct {
+	Version              uint64      `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Entries              []*ACLEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ACLReply) Reset()         { *m = ACLReply{} }
+func (m *ACLReply) String() string { return proto.CompactTextString(m) }
+func (*ACLReply) ProtoMessage()    {}
+func (*ACLReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+}
+
+func (m *ACLReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ACLReply.Unmarshal(m, b)
+}
+func (m *ACLReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ACLReply.Marshal(b, m, deterministic)
+}
+func (m *ACLReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ACLReply.Merge(m, src)
+}
+func (m *ACLReply) XXX_Size() int {
+	return xxx_messageInfo_ACLReply.Size(m)
+}
+func (m *ACLReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ACLReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ACLReply proto.InternalMessageInfo
+
+func (m *ACLReply) GetVersion() uint64 {
 	if m != nil {
-		return m.EventNode
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ACLReply) GetEntries() []*ACLEntry {
+	if m != nil {
+		return m.Entries
 	}
 	return nil
 }
 
-func (m *Record) GetHeaderNode() []byte {
+type UpdateACLRequest struct {
+	ThreadID             []byte      `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Entries              []*ACLEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *UpdateACLRequest) Reset()         { *m = UpdateACLRequest{} }
+func (m *UpdateACLRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateACLRequest) ProtoMessage()    {}
+func (*UpdateACLRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+}
+
+func (m *UpdateACLRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateACLRequest.Unmarshal(m, b)
+}
+func (m *UpdateACLRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateACLRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateACLRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateACLRequest.Merge(m, src)
+}
+func (m *UpdateACLRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateACLRequest.Size(m)
+}
+func (m *UpdateACLRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateACLRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateACLRequest proto.InternalMessageInfo
+
+func (m *UpdateACLRequest) GetThreadID() []byte {
 	if m != nil {
-		return m.HeaderNode
+		return m.ThreadID
 	}
 	return nil
 }
 
-func (m *Record) GetBodyNode() []byte {
+func (m *UpdateACLRequest) GetEntries() []*ACLEntry {
 	if m != nil {
-		return m.BodyNode
+		return m.Entries
 	}
 	return nil
 }
 
-type NewRecordReply struct {
+type GetPeerPolicyRequest struct {
 	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	LogID                []byte   `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
-	Record               *Record  `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NewRecordReply) Reset()         { *m = NewRecordReply{} }
-func (m *NewRecordReply) String() string { return proto.CompactTextString(m) }
-func (*NewRecordReply) ProtoMessage()    {}
-func (*NewRecordReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+func (m *GetPeerPolicyRequest) Reset()         { *m = GetPeerPolicyRequest{} }
+func (m *GetPeerPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPeerPolicyRequest) ProtoMessage()    {}
+func (*GetPeerPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
 }
 
-func (m *NewRecordReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewRecordReply.Unmarshal(m, b)
+func (m *GetPeerPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPeerPolicyRequest.Unmarshal(m, b)
 }
-func (m *NewRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewRecordReply.Marshal(b, m, deterministic)
+func (m *GetPeerPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPeerPolicyRequest.Marshal(b, m, deterministic)
 }
-func (m *NewRecordReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewRecordReply.Merge(m, src)
+func (m *GetPeerPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPeerPolicyRequest.Merge(m, src)
 }
-func (m *NewRecordReply) XXX_Size() int {
-	return xxx_messageInfo_NewRecordReply.Size(m)
+func (m *GetPeerPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPeerPolicyRequest.Size(m)
 }
-func (m *NewRecordReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewRecordReply.DiscardUnknown(m)
+func (m *GetPeerPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPeerPolicyRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NewRecordReply proto.InternalMessageInfo
+var xxx_messageInfo_GetPeerPolicyRequest proto.InternalMessageInfo
 
-func (m *NewRecordReply) GetThreadID() []byte {
+func (m *GetPeerPolicyRequest) GetThreadID() []byte {
 	if m != nil {
 		return m.ThreadID
 	}
 	return nil
 }
 
-func (m *NewRecordReply) GetLogID() []byte {
+type PeerPolicyReply struct {
+	Allow                [][]byte `protobuf:"bytes,1,rep,name=allow,proto3" json:"allow,omitempty"`
+	Deny                 [][]byte `protobuf:"bytes,2,rep,name=deny,proto3" json:"deny,omitempty"`
+	LogPeersOnly         bool     `protobuf:"varint,3,opt,name=logPeersOnly,proto3" json:"logPeersOnly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerPolicyReply) Reset()         { *m = PeerPolicyReply{} }
+func (m *PeerPolicyReply) String() string { return proto.CompactTextString(m) }
+func (*PeerPolicyReply) ProtoMessage()    {}
+func (*PeerPolicyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+}
+
+func (m *PeerPolicyReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerPolicyReply.Unmarshal(m, b)
+}
+func (m *PeerPolicyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerPolicyReply.Marshal(b, m, deterministic)
+}
+func (m *PeerPolicyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerPolicyReply.Merge(m, src)
+}
+func (m *PeerPolicyReply) XXX_Size() int {
+	return xxx_messageInfo_PeerPolicyReply.Size(m)
+}
+func (m *PeerPolicyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerPolicyReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerPolicyReply proto.InternalMessageInfo
+
+func (m *PeerPolicyReply) GetAllow() [][]byte {
 	if m != nil {
-		return m.LogID
+		return m.Allow
 	}
 	return nil
 }
 
-func (m *NewRecordReply) GetRecord() *Record {
+func (m *PeerPolicyReply) GetDeny() [][]byte {
 	if m != nil {
-		return m.Record
+		return m.Deny
 	}
 	return nil
 }
 
-type AddRecordRequest struct {
+func (m *PeerPolicyReply) GetLogPeersOnly() bool {
+	if m != nil {
+		return m.LogPeersOnly
+	}
+	return false
+}
+
+type SetPeerPolicyRequest struct {
 	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	LogID                []byte   `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
-	Record               *Record  `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	Allow                [][]byte `protobuf:"bytes,2,rep,name=allow,proto3" json:"allow,omitempty"`
+	Deny                 [][]byte `protobuf:"bytes,3,rep,name=deny,proto3" json:"deny,omitempty"`
+	LogPeersOnly         bool     `protobuf:"varint,4,opt,name=logPeersOnly,proto3" json:"logPeersOnly,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddRecordRequest) Reset()         { *m = AddRecordRequest{} }
-func (m *AddRecordRequest) String() string { return proto.CompactTextString(m) }
-func (*AddRecordRequest) ProtoMessage()    {}
-func (*AddRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+func (m *SetPeerPolicyRequest) Reset()         { *m = SetPeerPolicyRequest{} }
+func (m *SetPeerPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*SetPeerPolicyRequest) ProtoMessage()    {}
+func (*SetPeerPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
 }
 
-func (m *AddRecordRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddRecordRequest.Unmarshal(m, b)
+func (m *SetPeerPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetPeerPolicyRequest.Unmarshal(m, b)
 }
-func (m *AddRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddRecordRequest.Marshal(b, m, deterministic)
+func (m *SetPeerPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetPeerPolicyRequest.Marshal(b, m, deterministic)
 }
-func (m *AddRecordRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddRecordRequest.Merge(m, src)
+func (m *SetPeerPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetPeerPolicyRequest.Merge(m, src)
 }
-func (m *AddRecordRequest) XXX_Size() int {
-	return xxx_messageInfo_AddRecordRequest.Size(m)
+func (m *SetPeerPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_SetPeerPolicyRequest.Size(m)
 }
-func (m *AddRecordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddRecordRequest.DiscardUnknown(m)
+func (m *SetPeerPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetPeerPolicyRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddRecordRequest proto.InternalMessageInfo
+var xxx_messageInfo_SetPeerPolicyRequest proto.InternalMessageInfo
 
-func (m *AddRecordRequest) GetThreadID() []byte {
+func (m *SetPeerPolicyRequest) GetThreadID() []byte {
 	if m != nil {
 		return m.ThreadID
 	}
 	return nil
 }
 
-func (m *AddRecordRequest) GetLogID() []byte {
+func (m *SetPeerPolicyRequest) GetAllow() [][]byte {
 	if m != nil {
-		return m.LogID
+		return m.Allow
 	}
 	return nil
 }
 
-func (m *AddRecordRequest) GetRecord() *Record {
+func (m *SetPeerPolicyRequest) GetDeny() [][]byte {
 	if m != nil {
-		return m.Record
+		return m.Deny
 	}
 	return nil
 }
 
-type AddRecordReply struct {
+func (m *SetPeerPolicyRequest) GetLogPeersOnly() bool {
+	if m != nil {
+		return m.LogPeersOnly
+	}
+	return false
+}
+
+type SetPeerPolicyReply struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddRecordReply) Reset()         { *m = AddRecordReply{} }
-func (m *AddRecordReply) String() string { return proto.CompactTextString(m) }
-func (*AddRecordReply) ProtoMessage()    {}
-func (*AddRecordReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+func (m *SetPeerPolicyReply) Reset()         { *m = SetPeerPolicyReply{} }
+func (m *SetPeerPolicyReply) String() string { return proto.CompactTextString(m) }
+func (*SetPeerPolicyReply) ProtoMessage()    {}
+func (*SetPeerPolicyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
 }
 
-func (m *AddRecordReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddRecordReply.Unmarshal(m, b)
+func (m *SetPeerPolicyReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetPeerPolicyReply.Unmarshal(m, b)
 }
-func (m *AddRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddRecordReply.Marshal(b, m, deterministic)
+func (m *SetPeerPolicyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetPeerPolicyReply.Marshal(b, m, deterministic)
 }
-func (m *AddRecordReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddRecordReply.Merge(m, src)
+func (m *SetPeerPolicyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetPeerPolicyReply.Merge(m, src)
 }
-func (m *AddRecordReply) XXX_Size() int {
-	return xxx_messageInfo_AddRecordReply.Size(m)
+func (m *SetPeerPolicyReply) XXX_Size() int {
+	return xxx_messageInfo_SetPeerPolicyReply.Size(m)
 }
-func (m *AddRecordReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddRecordReply.DiscardUnknown(m)
+func (m *SetPeerPolicyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetPeerPolicyReply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddRecordReply proto.InternalMessageInfo
+var xxx_messageInfo_SetPeerPolicyReply proto.InternalMessageInfo
 
-type GetRecordRequest struct {
+type AddFollowerRequest struct {
 	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	RecordID             []byte   `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetRecordRequest) Reset()         { *m = GetRecordRequest{} }
-func (m *GetRecordRequest) String() string { return proto.CompactTextString(m) }
-func (*GetRecordRequest) ProtoMessage()    {}
-func (*GetRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+func (m *AddFollowerRequest) Reset()         { *m = AddFollowerRequest{} }
+func (m *AddFollowerRequest) String() string { return proto.CompactTextString(m) }
+func (*AddFollowerRequest) ProtoMessage()    {}
+func (*AddFollowerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
 }
 
-func (m *GetRecordRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetRecordRequest.Unmarshal(m, b)
+func (m *AddFollowerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddFollowerRequest.Unmarshal(m, b)
+}
+func (m *AddFollowerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddFollowerRequest.Marshal(b, m, deterministic)
+}
+func (m *AddFollowerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddFollowerRequest.Merge(m, src)
+}
+func (m *AddFollowerRequest) XXX_Size() int {
+	return xxx_messageInfo_AddFollowerRequest.Size(m)
+}
+func (m *AddFollowerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddFollowerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddFollowerRequest proto.InternalMessageInfo
+
+func (m *AddFollowerRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *AddFollowerRequest) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+type AddFollowerReply struct {
+	PeerID               []byte   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddFollowerReply) Reset()         { *m = AddFollowerReply{} }
+func (m *AddFollowerReply) String() string { return proto.CompactTextString(m) }
+func (*AddFollowerReply) ProtoMessage()    {}
+func (*AddFollowerReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+}
+
+func (m *AddFollowerReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddFollowerReply.Unmarshal(m, b)
+}
+func (m *AddFollowerReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddFollowerReply.Marshal(b, m, deterministic)
+}
+func (m *AddFollowerReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddFollowerReply.Merge(m, src)
+}
+func (m *AddFollowerReply) XXX_Size() int {
+	return xxx_messageInfo_AddFollowerReply.Size(m)
+}
+func (m *AddFollowerReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddFollowerReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddFollowerReply proto.InternalMessageInfo
+
+func (m *AddFollowerReply) GetPeerID() []byte {
+	if m != nil {
+		return m.PeerID
+	}
+	return nil
+}
+
+type AddReplicatorRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddReplicatorRequest) Reset()         { *m = AddReplicatorRequest{} }
+func (m *AddReplicatorRequest) String() string { return proto.CompactTextString(m) }
+func (*AddReplicatorRequest) ProtoMessage()    {}
+func (*AddReplicatorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+}
+
+func (m *AddReplicatorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddReplicatorRequest.Unmarshal(m, b)
+}
+func (m *AddReplicatorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddReplicatorRequest.Marshal(b, m, deterministic)
+}
+func (m *AddReplicatorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddReplicatorRequest.Merge(m, src)
+}
+func (m *AddReplicatorRequest) XXX_Size() int {
+	return xxx_messageInfo_AddReplicatorRequest.Size(m)
+}
+func (m *AddReplicatorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddReplicatorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddReplicatorRequest proto.InternalMessageInfo
+
+func (m *AddReplicatorRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *AddReplicatorRequest) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+type AddReplicatorReply struct {
+	PeerID               []byte   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddReplicatorReply) Reset()         { *m = AddReplicatorReply{} }
+func (m *AddReplicatorReply) String() string { return proto.CompactTextString(m) }
+func (*AddReplicatorReply) ProtoMessage()    {}
+func (*AddReplicatorReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+}
+
+func (m *AddReplicatorReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddReplicatorReply.Unmarshal(m, b)
+}
+func (m *AddReplicatorReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddReplicatorReply.Marshal(b, m, deterministic)
+}
+func (m *AddReplicatorReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddReplicatorReply.Merge(m, src)
+}
+func (m *AddReplicatorReply) XXX_Size() int {
+	return xxx_messageInfo_AddReplicatorReply.Size(m)
+}
+func (m *AddReplicatorReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddReplicatorReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddReplicatorReply proto.InternalMessageInfo
+
+func (m *AddReplicatorReply) GetPeerID() []byte {
+	if m != nil {
+		return m.PeerID
+	}
+	return nil
+}
+
+type CreateInviteRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Invitee              []byte   `protobuf:"bytes,2,opt,name=invitee,proto3" json:"invitee,omitempty"`
+	Ttl                  int64    `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateInviteRequest) Reset()         { *m = CreateInviteRequest{} }
+func (m *CreateInviteRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateInviteRequest) ProtoMessage()    {}
+func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+}
+
+func (m *CreateInviteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateInviteRequest.Unmarshal(m, b)
+}
+func (m *CreateInviteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateInviteRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateInviteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateInviteRequest.Merge(m, src)
+}
+func (m *CreateInviteRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateInviteRequest.Size(m)
+}
+func (m *CreateInviteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateInviteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateInviteRequest proto.InternalMessageInfo
+
+func (m *CreateInviteRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *CreateInviteRequest) GetInvitee() []byte {
+	if m != nil {
+		return m.Invitee
+	}
+	return nil
+}
+
+func (m *CreateInviteRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type CreateInviteReply struct {
+	Invite               []byte   `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateInviteReply) Reset()         { *m = CreateInviteReply{} }
+func (m *CreateInviteReply) String() string { return proto.CompactTextString(m) }
+func (*CreateInviteReply) ProtoMessage()    {}
+func (*CreateInviteReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+}
+
+func (m *CreateInviteReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateInviteReply.Unmarshal(m, b)
+}
+func (m *CreateInviteReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateInviteReply.Marshal(b, m, deterministic)
+}
+func (m *CreateInviteReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateInviteReply.Merge(m, src)
+}
+func (m *CreateInviteReply) XXX_Size() int {
+	return xxx_messageInfo_CreateInviteReply.Size(m)
+}
+func (m *CreateInviteReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateInviteReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateInviteReply proto.InternalMessageInfo
+
+func (m *CreateInviteReply) GetInvite() []byte {
+	if m != nil {
+		return m.Invite
+	}
+	return nil
+}
+
+type AcceptInviteRequest struct {
+	Invite               []byte   `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcceptInviteRequest) Reset()         { *m = AcceptInviteRequest{} }
+func (m *AcceptInviteRequest) String() string { return proto.CompactTextString(m) }
+func (*AcceptInviteRequest) ProtoMessage()    {}
+func (*AcceptInviteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+}
+
+func (m *AcceptInviteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcceptInviteRequest.Unmarshal(m, b)
+}
+func (m *AcceptInviteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcceptInviteRequest.Marshal(b, m, deterministic)
+}
+func (m *AcceptInviteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcceptInviteRequest.Merge(m, src)
+}
+func (m *AcceptInviteRequest) XXX_Size() int {
+	return xxx_messageInfo_AcceptInviteRequest.Size(m)
+}
+func (m *AcceptInviteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcceptInviteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcceptInviteRequest proto.InternalMessageInfo
+
+func (m *AcceptInviteRequest) GetInvite() []byte {
+	if m != nil {
+		return m.Invite
+	}
+	return nil
+}
+
+type CreateRecordRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Body                 []byte   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	ContentType          string   `protobuf:"bytes,3,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Codec                string   `protobuf:"bytes,4,opt,name=codec,proto3" json:"codec,omitempty"`
+	SchemaVersion        string   `protobuf:"bytes,5,opt,name=schemaVersion,proto3" json:"schemaVersion,omitempty"`
+	Author               []byte   `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	ChunkSize            int64    `protobuf:"varint,7,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRecordRequest) Reset()         { *m = CreateRecordRequest{} }
+func (m *CreateRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRecordRequest) ProtoMessage()    {}
+func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+}
+
+func (m *CreateRecordRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRecordRequest.Unmarshal(m, b)
+}
+func (m *CreateRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRecordRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRecordRequest.Merge(m, src)
+}
+func (m *CreateRecordRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRecordRequest.Size(m)
+}
+func (m *CreateRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRecordRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRecordRequest proto.InternalMessageInfo
+
+func (m *CreateRecordRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *CreateRecordRequest) GetBody() []byte {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *CreateRecordRequest) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *CreateRecordRequest) GetCodec() string {
+	if m != nil {
+		return m.Codec
+	}
+	return ""
+}
+
+func (m *CreateRecordRequest) GetSchemaVersion() string {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return ""
+}
+
+func (m *CreateRecordRequest) GetAuthor() []byte {
+	if m != nil {
+		return m.Author
+	}
+	return nil
+}
+
+func (m *CreateRecordRequest) GetChunkSize() int64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+type Record struct {
+	RecordNode           []byte   `protobuf:"bytes,1,opt,name=recordNode,proto3" json:"recordNode,omitempty"`
+	EventNode            []byte   `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
+	HeaderNode           []byte   `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
+	BodyNode             []byte   `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Record) Reset()         { *m = Record{} }
+func (m *Record) String() string { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()    {}
+func (*Record) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+}
+
+func (m *Record) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Record.Unmarshal(m, b)
+}
+func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Record.Marshal(b, m, deterministic)
+}
+func (m *Record) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Record.Merge(m, src)
+}
+func (m *Record) XXX_Size() int {
+	return xxx_messageInfo_Record.Size(m)
+}
+func (m *Record) XXX_DiscardUnknown() {
+	xxx_messageInfo_Record.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Record proto.InternalMessageInfo
+
+func (m *Record) GetRecordNode() []byte {
+	if m != nil {
+		return m.RecordNode
+	}
+	return nil
+}
+
+func (m *Record) GetEventNode() []byte {
+	if m != nil {
+		return m.EventNode
+	}
+	return nil
+}
+
+func (m *Record) GetHeaderNode() []byte {
+	if m != nil {
+		return m.HeaderNode
+	}
+	return nil
+}
+
+func (m *Record) GetBodyNode() []byte {
+	if m != nil {
+		return m.BodyNode
+	}
+	return nil
+}
+
+type NewRecordReply struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID                []byte   `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Record               *Record  `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewRecordReply) Reset()         { *m = NewRecordReply{} }
+func (m *NewRecordReply) String() string { return proto.CompactTextString(m) }
+func (*NewRecordReply) ProtoMessage()    {}
+func (*NewRecordReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+}
+
+func (m *NewRecordReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewRecordReply.Unmarshal(m, b)
+}
+func (m *NewRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewRecordReply.Marshal(b, m, deterministic)
+}
+func (m *NewRecordReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewRecordReply.Merge(m, src)
+}
+func (m *NewRecordReply) XXX_Size() int {
+	return xxx_messageInfo_NewRecordReply.Size(m)
+}
+func (m *NewRecordReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewRecordReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewRecordReply proto.InternalMessageInfo
+
+func (m *NewRecordReply) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *NewRecordReply) GetLogID() []byte {
+	if m != nil {
+		return m.LogID
+	}
+	return nil
+}
+
+func (m *NewRecordReply) GetRecord() *Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+type AddRecordRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID                []byte   `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Record               *Record  `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddRecordRequest) Reset()         { *m = AddRecordRequest{} }
+func (m *AddRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*AddRecordRequest) ProtoMessage()    {}
+func (*AddRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+}
+
+func (m *AddRecordRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddRecordRequest.Unmarshal(m, b)
+}
+func (m *AddRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddRecordRequest.Marshal(b, m, deterministic)
+}
+func (m *AddRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddRecordRequest.Merge(m, src)
+}
+func (m *AddRecordRequest) XXX_Size() int {
+	return xxx_messageInfo_AddRecordRequest.Size(m)
+}
+func (m *AddRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddRecordRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddRecordRequest proto.InternalMessageInfo
+
+func (m *AddRecordRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *AddRecordRequest) GetLogID() []byte {
+	if m != nil {
+		return m.LogID
+	}
+	return nil
+}
+
+func (m *AddRecordRequest) GetRecord() *Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+type AddRecordReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddRecordReply) Reset()         { *m = AddRecordReply{} }
+func (m *AddRecordReply) String() string { return proto.CompactTextString(m) }
+func (*AddRecordReply) ProtoMessage()    {}
+func (*AddRecordReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+}
+
+func (m *AddRecordReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddRecordReply.Unmarshal(m, b)
+}
+func (m *AddRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddRecordReply.Marshal(b, m, deterministic)
+}
+func (m *AddRecordReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddRecordReply.Merge(m, src)
+}
+func (m *AddRecordReply) XXX_Size() int {
+	return xxx_messageInfo_AddRecordReply.Size(m)
+}
+func (m *AddRecordReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddRecordReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddRecordReply proto.InternalMessageInfo
+
+type GetRecordRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	RecordID             []byte   `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRecordRequest) Reset()         { *m = GetRecordRequest{} }
+func (m *GetRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRecordRequest) ProtoMessage()    {}
+func (*GetRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+}
+
+func (m *GetRecordRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRecordRequest.Unmarshal(m, b)
 }
 func (m *GetRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_GetRecordRequest.Marshal(b, m, deterministic)
@@ -917,80 +1662,349 @@ func (m *GetRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 func (m *GetRecordRequest) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_GetRecordRequest.Merge(m, src)
 }
-func (m *GetRecordRequest) XXX_Size() int {
-	return xxx_messageInfo_GetRecordRequest.Size(m)
+func (m *GetRecordRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRecordRequest.Size(m)
+}
+func (m *GetRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecordRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRecordRequest proto.InternalMessageInfo
+
+func (m *GetRecordRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *GetRecordRequest) GetRecordID() []byte {
+	if m != nil {
+		return m.RecordID
+	}
+	return nil
+}
+
+type GetRecordReply struct {
+	Record               *Record  `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRecordReply) Reset()         { *m = GetRecordReply{} }
+func (m *GetRecordReply) String() string { return proto.CompactTextString(m) }
+func (*GetRecordReply) ProtoMessage()    {}
+func (*GetRecordReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
+}
+
+func (m *GetRecordReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRecordReply.Unmarshal(m, b)
+}
+func (m *GetRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRecordReply.Marshal(b, m, deterministic)
+}
+func (m *GetRecordReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecordReply.Merge(m, src)
+}
+func (m *GetRecordReply) XXX_Size() int {
+	return xxx_messageInfo_GetRecordReply.Size(m)
+}
+func (m *GetRecordReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecordReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRecordReply proto.InternalMessageInfo
+
+func (m *GetRecordReply) GetRecord() *Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+type ListRecordsRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID                []byte   `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	From                 []byte   `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To                   []byte   `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Limit                int32    `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRecordsRequest) Reset()         { *m = ListRecordsRequest{} }
+func (m *ListRecordsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRecordsRequest) ProtoMessage()    {}
+func (*ListRecordsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
+}
+
+func (m *ListRecordsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRecordsRequest.Unmarshal(m, b)
+}
+func (m *ListRecordsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRecordsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRecordsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRecordsRequest.Merge(m, src)
+}
+func (m *ListRecordsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRecordsRequest.Size(m)
+}
+func (m *ListRecordsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRecordsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRecordsRequest proto.InternalMessageInfo
+
+func (m *ListRecordsRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *ListRecordsRequest) GetLogID() []byte {
+	if m != nil {
+		return m.LogID
+	}
+	return nil
+}
+
+func (m *ListRecordsRequest) GetFrom() []byte {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *ListRecordsRequest) GetTo() []byte {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *ListRecordsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListRecordsReply struct {
+	Records              []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	Next                 []byte    `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListRecordsReply) Reset()         { *m = ListRecordsReply{} }
+func (m *ListRecordsReply) String() string { return proto.CompactTextString(m) }
+func (*ListRecordsReply) ProtoMessage()    {}
+func (*ListRecordsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
+}
+
+func (m *ListRecordsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRecordsReply.Unmarshal(m, b)
+}
+func (m *ListRecordsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRecordsReply.Marshal(b, m, deterministic)
+}
+func (m *ListRecordsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRecordsReply.Merge(m, src)
+}
+func (m *ListRecordsReply) XXX_Size() int {
+	return xxx_messageInfo_ListRecordsReply.Size(m)
+}
+func (m *ListRecordsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRecordsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRecordsReply proto.InternalMessageInfo
+
+func (m *ListRecordsReply) GetRecords() []*Record {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *ListRecordsReply) GetNext() []byte {
+	if m != nil {
+		return m.Next
+	}
+	return nil
+}
+
+type ListThreadRecordsRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListThreadRecordsRequest) Reset()         { *m = ListThreadRecordsRequest{} }
+func (m *ListThreadRecordsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListThreadRecordsRequest) ProtoMessage()    {}
+func (*ListThreadRecordsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+}
+
+func (m *ListThreadRecordsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListThreadRecordsRequest.Unmarshal(m, b)
+}
+func (m *ListThreadRecordsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListThreadRecordsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListThreadRecordsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListThreadRecordsRequest.Merge(m, src)
 }
-func (m *GetRecordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRecordRequest.DiscardUnknown(m)
+func (m *ListThreadRecordsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListThreadRecordsRequest.Size(m)
+}
+func (m *ListThreadRecordsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListThreadRecordsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRecordRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListThreadRecordsRequest proto.InternalMessageInfo
 
-func (m *GetRecordRequest) GetThreadID() []byte {
+func (m *ListThreadRecordsRequest) GetThreadID() []byte {
 	if m != nil {
 		return m.ThreadID
 	}
 	return nil
 }
 
-func (m *GetRecordRequest) GetRecordID() []byte {
+func (m *ListThreadRecordsRequest) GetToken() string {
 	if m != nil {
-		return m.RecordID
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ListThreadRecordsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListThreadRecordsReply struct {
+	Records              []*NewRecordReply `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	Next                 string            `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListThreadRecordsReply) Reset()         { *m = ListThreadRecordsReply{} }
+func (m *ListThreadRecordsReply) String() string { return proto.CompactTextString(m) }
+func (*ListThreadRecordsReply) ProtoMessage()    {}
+func (*ListThreadRecordsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+}
+
+func (m *ListThreadRecordsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListThreadRecordsReply.Unmarshal(m, b)
+}
+func (m *ListThreadRecordsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListThreadRecordsReply.Marshal(b, m, deterministic)
+}
+func (m *ListThreadRecordsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListThreadRecordsReply.Merge(m, src)
+}
+func (m *ListThreadRecordsReply) XXX_Size() int {
+	return xxx_messageInfo_ListThreadRecordsReply.Size(m)
+}
+func (m *ListThreadRecordsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListThreadRecordsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListThreadRecordsReply proto.InternalMessageInfo
+
+func (m *ListThreadRecordsReply) GetRecords() []*NewRecordReply {
+	if m != nil {
+		return m.Records
 	}
 	return nil
 }
 
-type GetRecordReply struct {
-	Record               *Record  `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+func (m *ListThreadRecordsReply) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+type LogCursor struct {
+	LogID                []byte   `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	RecordID             []byte   `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetRecordReply) Reset()         { *m = GetRecordReply{} }
-func (m *GetRecordReply) String() string { return proto.CompactTextString(m) }
-func (*GetRecordReply) ProtoMessage()    {}
-func (*GetRecordReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+func (m *LogCursor) Reset()         { *m = LogCursor{} }
+func (m *LogCursor) String() string { return proto.CompactTextString(m) }
+func (*LogCursor) ProtoMessage()    {}
+func (*LogCursor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
 }
 
-func (m *GetRecordReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetRecordReply.Unmarshal(m, b)
+func (m *LogCursor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogCursor.Unmarshal(m, b)
 }
-func (m *GetRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetRecordReply.Marshal(b, m, deterministic)
+func (m *LogCursor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogCursor.Marshal(b, m, deterministic)
 }
-func (m *GetRecordReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRecordReply.Merge(m, src)
+func (m *LogCursor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogCursor.Merge(m, src)
 }
-func (m *GetRecordReply) XXX_Size() int {
-	return xxx_messageInfo_GetRecordReply.Size(m)
+func (m *LogCursor) XXX_Size() int {
+	return xxx_messageInfo_LogCursor.Size(m)
 }
-func (m *GetRecordReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRecordReply.DiscardUnknown(m)
+func (m *LogCursor) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogCursor.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRecordReply proto.InternalMessageInfo
+var xxx_messageInfo_LogCursor proto.InternalMessageInfo
 
-func (m *GetRecordReply) GetRecord() *Record {
+func (m *LogCursor) GetLogID() []byte {
 	if m != nil {
-		return m.Record
+		return m.LogID
+	}
+	return nil
+}
+
+func (m *LogCursor) GetRecordID() []byte {
+	if m != nil {
+		return m.RecordID
 	}
 	return nil
 }
 
 type SubscribeRequest struct {
-	ThreadIDs            [][]byte `protobuf:"bytes,1,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ThreadIDs            [][]byte     `protobuf:"bytes,1,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
+	Replay               bool         `protobuf:"varint,2,opt,name=replay,proto3" json:"replay,omitempty"`
+	Cursor               []*LogCursor `protobuf:"bytes,3,rep,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
 func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
 func (*SubscribeRequest) ProtoMessage()    {}
 func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
 }
 
 func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
@@ -1018,6 +2032,247 @@ func (m *SubscribeRequest) GetThreadIDs() [][]byte {
 	return nil
 }
 
+func (m *SubscribeRequest) GetReplay() bool {
+	if m != nil {
+		return m.Replay
+	}
+	return false
+}
+
+func (m *SubscribeRequest) GetCursor() []*LogCursor {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
+type GetOutboxRequest struct {
+	ThreadID             []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOutboxRequest) Reset()         { *m = GetOutboxRequest{} }
+func (m *GetOutboxRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOutboxRequest) ProtoMessage()    {}
+func (*GetOutboxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+}
+
+func (m *GetOutboxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOutboxRequest.Unmarshal(m, b)
+}
+func (m *GetOutboxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOutboxRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOutboxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOutboxRequest.Merge(m, src)
+}
+func (m *GetOutboxRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOutboxRequest.Size(m)
+}
+func (m *GetOutboxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOutboxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOutboxRequest proto.InternalMessageInfo
+
+func (m *GetOutboxRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+type OutboxEntry struct {
+	LogID                []byte   `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	RecordID             []byte   `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`
+	PeerID               []byte   `protobuf:"bytes,3,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Attempts             int32    `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	NextAttempt          int64    `protobuf:"varint,5,opt,name=nextAttempt,proto3" json:"nextAttempt,omitempty"`
+	LastError            string   `protobuf:"bytes,6,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OutboxEntry) Reset()         { *m = OutboxEntry{} }
+func (m *OutboxEntry) String() string { return proto.CompactTextString(m) }
+func (*OutboxEntry) ProtoMessage()    {}
+func (*OutboxEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
+}
+
+func (m *OutboxEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutboxEntry.Unmarshal(m, b)
+}
+func (m *OutboxEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutboxEntry.Marshal(b, m, deterministic)
+}
+func (m *OutboxEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutboxEntry.Merge(m, src)
+}
+func (m *OutboxEntry) XXX_Size() int {
+	return xxx_messageInfo_OutboxEntry.Size(m)
+}
+func (m *OutboxEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutboxEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutboxEntry proto.InternalMessageInfo
+
+func (m *OutboxEntry) GetLogID() []byte {
+	if m != nil {
+		return m.LogID
+	}
+	return nil
+}
+
+func (m *OutboxEntry) GetRecordID() []byte {
+	if m != nil {
+		return m.RecordID
+	}
+	return nil
+}
+
+func (m *OutboxEntry) GetPeerID() []byte {
+	if m != nil {
+		return m.PeerID
+	}
+	return nil
+}
+
+func (m *OutboxEntry) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *OutboxEntry) GetNextAttempt() int64 {
+	if m != nil {
+		return m.NextAttempt
+	}
+	return 0
+}
+
+func (m *OutboxEntry) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+type GetOutboxReply struct {
+	Entries              []*OutboxEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetOutboxReply) Reset()         { *m = GetOutboxReply{} }
+func (m *GetOutboxReply) String() string { return proto.CompactTextString(m) }
+func (*GetOutboxReply) ProtoMessage()    {}
+func (*GetOutboxReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+}
+
+func (m *GetOutboxReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOutboxReply.Unmarshal(m, b)
+}
+func (m *GetOutboxReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOutboxReply.Marshal(b, m, deterministic)
+}
+func (m *GetOutboxReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOutboxReply.Merge(m, src)
+}
+func (m *GetOutboxReply) XXX_Size() int {
+	return xxx_messageInfo_GetOutboxReply.Size(m)
+}
+func (m *GetOutboxReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOutboxReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOutboxReply proto.InternalMessageInfo
+
+func (m *GetOutboxReply) GetEntries() []*OutboxEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type GCRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GCRequest) Reset()         { *m = GCRequest{} }
+func (m *GCRequest) String() string { return proto.CompactTextString(m) }
+func (*GCRequest) ProtoMessage()    {}
+func (*GCRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+}
+
+func (m *GCRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GCRequest.Unmarshal(m, b)
+}
+func (m *GCRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GCRequest.Marshal(b, m, deterministic)
+}
+func (m *GCRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GCRequest.Merge(m, src)
+}
+func (m *GCRequest) XXX_Size() int {
+	return xxx_messageInfo_GCRequest.Size(m)
+}
+func (m *GCRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GCRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GCRequest proto.InternalMessageInfo
+
+type GCReply struct {
+	Removed              int64    `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GCReply) Reset()         { *m = GCReply{} }
+func (m *GCReply) String() string { return proto.CompactTextString(m) }
+func (*GCReply) ProtoMessage()    {}
+func (*GCReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+}
+
+func (m *GCReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GCReply.Unmarshal(m, b)
+}
+func (m *GCReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GCReply.Marshal(b, m, deterministic)
+}
+func (m *GCReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GCReply.Merge(m, src)
+}
+func (m *GCReply) XXX_Size() int {
+	return xxx_messageInfo_GCReply.Size(m)
+}
+func (m *GCReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GCReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GCReply proto.InternalMessageInfo
+
+func (m *GCReply) GetRemoved() int64 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*GetHostIDRequest)(nil), "api.service.pb.GetHostIDRequest")
 	proto.RegisterType((*GetHostIDReply)(nil), "api.service.pb.GetHostIDReply")
@@ -1031,8 +2286,23 @@ func init() {
 	proto.RegisterType((*PullThreadReply)(nil), "api.service.pb.PullThreadReply")
 	proto.RegisterType((*DeleteThreadRequest)(nil), "api.service.pb.DeleteThreadRequest")
 	proto.RegisterType((*DeleteThreadReply)(nil), "api.service.pb.DeleteThreadReply")
+	proto.RegisterType((*GetKeysAtRequest)(nil), "api.service.pb.GetKeysAtRequest")
+	proto.RegisterType((*RotateKeysRequest)(nil), "api.service.pb.RotateKeysRequest")
+	proto.RegisterType((*ACLEntry)(nil), "api.service.pb.ACLEntry")
+	proto.RegisterType((*GetACLRequest)(nil), "api.service.pb.GetACLRequest")
+	proto.RegisterType((*ACLReply)(nil), "api.service.pb.ACLReply")
+	proto.RegisterType((*UpdateACLRequest)(nil), "api.service.pb.UpdateACLRequest")
+	proto.RegisterType((*GetPeerPolicyRequest)(nil), "api.service.pb.GetPeerPolicyRequest")
+	proto.RegisterType((*PeerPolicyReply)(nil), "api.service.pb.PeerPolicyReply")
+	proto.RegisterType((*SetPeerPolicyRequest)(nil), "api.service.pb.SetPeerPolicyRequest")
+	proto.RegisterType((*SetPeerPolicyReply)(nil), "api.service.pb.SetPeerPolicyReply")
 	proto.RegisterType((*AddFollowerRequest)(nil), "api.service.pb.AddFollowerRequest")
 	proto.RegisterType((*AddFollowerReply)(nil), "api.service.pb.AddFollowerReply")
+	proto.RegisterType((*AddReplicatorRequest)(nil), "api.service.pb.AddReplicatorRequest")
+	proto.RegisterType((*AddReplicatorReply)(nil), "api.service.pb.AddReplicatorReply")
+	proto.RegisterType((*CreateInviteRequest)(nil), "api.service.pb.CreateInviteRequest")
+	proto.RegisterType((*CreateInviteReply)(nil), "api.service.pb.CreateInviteReply")
+	proto.RegisterType((*AcceptInviteRequest)(nil), "api.service.pb.AcceptInviteRequest")
 	proto.RegisterType((*CreateRecordRequest)(nil), "api.service.pb.CreateRecordRequest")
 	proto.RegisterType((*Record)(nil), "api.service.pb.Record")
 	proto.RegisterType((*NewRecordReply)(nil), "api.service.pb.NewRecordReply")
@@ -1040,62 +2310,125 @@ func init() {
 	proto.RegisterType((*AddRecordReply)(nil), "api.service.pb.AddRecordReply")
 	proto.RegisterType((*GetRecordRequest)(nil), "api.service.pb.GetRecordRequest")
 	proto.RegisterType((*GetRecordReply)(nil), "api.service.pb.GetRecordReply")
+	proto.RegisterType((*ListRecordsRequest)(nil), "api.service.pb.ListRecordsRequest")
+	proto.RegisterType((*ListRecordsReply)(nil), "api.service.pb.ListRecordsReply")
+	proto.RegisterType((*ListThreadRecordsRequest)(nil), "api.service.pb.ListThreadRecordsRequest")
+	proto.RegisterType((*ListThreadRecordsReply)(nil), "api.service.pb.ListThreadRecordsReply")
+	proto.RegisterType((*LogCursor)(nil), "api.service.pb.LogCursor")
 	proto.RegisterType((*SubscribeRequest)(nil), "api.service.pb.SubscribeRequest")
+	proto.RegisterType((*GetOutboxRequest)(nil), "api.service.pb.GetOutboxRequest")
+	proto.RegisterType((*OutboxEntry)(nil), "api.service.pb.OutboxEntry")
+	proto.RegisterType((*GetOutboxReply)(nil), "api.service.pb.GetOutboxReply")
+	proto.RegisterType((*GCRequest)(nil), "api.service.pb.GCRequest")
+	proto.RegisterType((*GCReply)(nil), "api.service.pb.GCReply")
 }
 
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 772 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4d, 0x6f, 0xda, 0x4a,
-	0x14, 0xc5, 0x40, 0x48, 0xb8, 0x20, 0x42, 0x26, 0x51, 0x1e, 0xb2, 0xa2, 0x84, 0xcc, 0xdb, 0xa0,
-	0xf7, 0x24, 0x37, 0xa5, 0xdb, 0x2e, 0x9a, 0xd4, 0x6d, 0x43, 0x5b, 0xa5, 0xd4, 0xa4, 0x51, 0x17,
-	0x95, 0x22, 0xc0, 0x13, 0x82, 0x6a, 0x65, 0xdc, 0xb1, 0x49, 0xca, 0xb6, 0x8b, 0xfe, 0x90, 0xf6,
-	0x8f, 0x56, 0xf3, 0xe1, 0x0f, 0x06, 0x87, 0x38, 0x52, 0x77, 0x73, 0xef, 0xcc, 0x9c, 0x7b, 0xee,
-	0xf5, 0x99, 0x03, 0x50, 0x1d, 0xfa, 0x53, 0xcb, 0x67, 0x34, 0xa4, 0xa8, 0xc1, 0x97, 0x01, 0x61,
-	0xb7, 0xd3, 0x31, 0xb1, 0xfc, 0x11, 0x46, 0xd0, 0x7c, 0x43, 0xc2, 0x53, 0x1a, 0x84, 0x3d, 0xdb,
-	0x21, 0xdf, 0x66, 0x24, 0x08, 0x71, 0x07, 0x1a, 0xa9, 0x9c, 0xef, 0xcd, 0xd1, 0x2e, 0x54, 0x7c,
-	0x42, 0x58, 0xcf, 0x6e, 0x19, 0x6d, 0xa3, 0x53, 0x77, 0x54, 0x84, 0xbf, 0x00, 0x9c, 0x5f, 0x33,
-	0x32, 0x74, 0xdf, 0x91, 0x79, 0x80, 0x5a, 0xb0, 0xae, 0xd6, 0xea, 0x58, 0x14, 0xa2, 0x3d, 0xa8,
-	0x5e, 0x51, 0xcf, 0xa3, 0x77, 0x7c, 0xaf, 0x28, 0xf6, 0x92, 0x04, 0x47, 0xf7, 0xe8, 0x84, 0x6f,
-	0x95, 0x24, 0xba, 0x8c, 0xf0, 0x10, 0xb6, 0x5f, 0x32, 0x32, 0x0c, 0x89, 0xac, 0xa1, 0xe8, 0x21,
-	0x13, 0x36, 0x42, 0x91, 0x88, 0xe9, 0xc4, 0x31, 0xb2, 0xa0, 0xfc, 0x95, 0xcc, 0x03, 0x51, 0xa3,
-	0xd6, 0x35, 0xad, 0xc5, 0x6e, 0xad, 0x84, 0xac, 0x23, 0xce, 0xe1, 0x3b, 0x58, 0x7f, 0x4f, 0x27,
-	0xbd, 0x9b, 0x2b, 0x8a, 0x1a, 0x50, 0x8c, 0x01, 0x8b, 0x3d, 0x5b, 0xf4, 0x3c, 0x1b, 0x25, 0x84,
-	0x55, 0xc4, 0xbb, 0xf4, 0xd9, 0xf4, 0x36, 0xa1, 0x1b, 0x85, 0x68, 0x07, 0xd6, 0x86, 0xae, 0xcb,
-	0x82, 0x56, 0xb9, 0x5d, 0xea, 0xd4, 0x1d, 0x19, 0xf0, 0xec, 0x35, 0x19, 0xba, 0x41, 0x6b, 0x4d,
-	0x66, 0x45, 0x80, 0x7f, 0x1a, 0xb0, 0x29, 0xd9, 0xf0, 0xe2, 0x72, 0xca, 0x3a, 0x83, 0xff, 0xa1,
-	0xec, 0xd1, 0x09, 0x6f, 0xa6, 0xd4, 0xa9, 0x75, 0xff, 0xd1, 0x9b, 0x51, 0xc4, 0x1d, 0x71, 0x28,
-	0x3d, 0xfc, 0xd2, 0x8a, 0xe1, 0x97, 0xb5, 0xe1, 0xe3, 0x0b, 0x68, 0x1e, 0xbb, 0xee, 0xe2, 0x84,
-	0x11, 0x94, 0x39, 0x77, 0x45, 0x45, 0xac, 0x1f, 0x3d, 0x59, 0x4b, 0x08, 0x2b, 0xf7, 0x97, 0xc3,
-	0x4f, 0x60, 0xab, 0x3f, 0xf3, 0xbc, 0xfc, 0x17, 0xb6, 0x60, 0x33, 0x7d, 0xc1, 0xf7, 0xe6, 0xf8,
-	0x29, 0x6c, 0xdb, 0xc4, 0x23, 0x8f, 0x10, 0x0c, 0xde, 0x86, 0xad, 0xc5, 0x2b, 0x1c, 0xc7, 0x06,
-	0x74, 0xec, 0xba, 0xaf, 0xc5, 0x8c, 0x08, 0xcb, 0xa3, 0xbb, 0x68, 0x62, 0xc5, 0x64, 0x62, 0xf8,
-	0x3f, 0x31, 0xd9, 0x04, 0x65, 0xd5, 0x43, 0x7a, 0x15, 0x49, 0xdd, 0x21, 0x63, 0xca, 0xdc, 0x9c,
-	0x25, 0x47, 0xd4, 0x8d, 0xd4, 0x29, 0xd6, 0xf8, 0x87, 0x01, 0x15, 0x89, 0x80, 0xf6, 0x01, 0x98,
-	0x58, 0x9d, 0x51, 0x97, 0xa8, 0xcb, 0xa9, 0x0c, 0x57, 0x05, 0xb9, 0x25, 0x37, 0xa1, 0xd8, 0x56,
-	0x4f, 0x32, 0x4e, 0xf0, 0xdb, 0x5c, 0xa7, 0x84, 0x89, 0x6d, 0x29, 0xa8, 0x54, 0x86, 0x13, 0xe3,
-	0x05, 0xc5, 0xae, 0x94, 0x54, 0x1c, 0x63, 0x06, 0x8d, 0x33, 0x72, 0x17, 0x35, 0xc2, 0xbb, 0x5e,
-	0xd5, 0xc6, 0x0e, 0xac, 0x79, 0x74, 0xd2, 0xb3, 0x15, 0x07, 0x19, 0x20, 0x0b, 0x2a, 0x92, 0xab,
-	0xa8, 0x5d, 0xeb, 0xee, 0xea, 0x7a, 0x53, 0xf0, 0xea, 0x14, 0x0e, 0xc5, 0xac, 0xf3, 0x0f, 0xef,
-	0xef, 0x54, 0x6d, 0x42, 0x23, 0x55, 0x95, 0x2b, 0xe7, 0xad, 0x50, 0x7d, 0x7e, 0x1e, 0x26, 0x6c,
-	0x48, 0xac, 0x98, 0x4a, 0x1c, 0xe3, 0x17, 0xc2, 0x86, 0xd3, 0x73, 0x4c, 0xf8, 0x19, 0xb9, 0xf8,
-	0x1d, 0x41, 0x73, 0x30, 0x1b, 0x05, 0x63, 0x36, 0x1d, 0x91, 0x88, 0xcd, 0x1e, 0x54, 0xa3, 0xea,
-	0x41, 0xcb, 0x10, 0x96, 0x94, 0x24, 0xba, 0xbf, 0xd7, 0xa1, 0x74, 0xdc, 0xef, 0xa1, 0x0f, 0x50,
-	0x8d, 0x7f, 0x02, 0x50, 0x5b, 0x2f, 0xa3, 0xff, 0x62, 0x98, 0xfb, 0x2b, 0x4e, 0xf0, 0xb1, 0x14,
-	0xd0, 0x05, 0xd4, 0xd3, 0x5e, 0x8e, 0xfe, 0xd5, 0x6f, 0x64, 0x38, 0xbd, 0x79, 0x90, 0xed, 0x32,
-	0xb1, 0x63, 0xe2, 0x02, 0xea, 0x43, 0x35, 0xb6, 0xaf, 0x65, 0xa2, 0xba, 0xb3, 0xe5, 0x44, 0x8c,
-	0x8d, 0x2b, 0xb3, 0xf5, 0x47, 0x23, 0x3a, 0x00, 0x89, 0x53, 0xa1, 0x43, 0xfd, 0xc2, 0x92, 0xed,
-	0x2d, 0x63, 0xea, 0x46, 0x57, 0x40, 0x9f, 0xa1, 0x9e, 0xf6, 0xad, 0xe5, 0x79, 0x66, 0x18, 0xa1,
-	0x79, 0xb8, 0xfa, 0x90, 0x44, 0xfe, 0x04, 0xb5, 0x94, 0x6d, 0x21, 0x9c, 0x31, 0x53, 0xcd, 0x19,
-	0xcd, 0xf6, 0xca, 0x33, 0x11, 0x6c, 0x3d, 0xed, 0x70, 0xf7, 0x09, 0x60, 0xe1, 0xe9, 0x2c, 0xeb,
-	0x6a, 0xd1, 0x58, 0x70, 0x81, 0x0b, 0x35, 0x7e, 0x82, 0x99, 0xdf, 0xff, 0x01, 0x40, 0xed, 0xfd,
-	0x16, 0x94, 0xf2, 0xef, 0x03, 0xd4, 0x1f, 0x77, 0xa6, 0xf2, 0x17, 0x01, 0x3f, 0x42, 0x35, 0x7e,
-	0x84, 0xcb, 0x80, 0xfa, 0xfb, 0x7c, 0xb8, 0xe5, 0x23, 0xe3, 0xe4, 0x39, 0x1c, 0x4c, 0xa9, 0x15,
-	0x92, 0xef, 0xe1, 0xd4, 0x23, 0x96, 0x7c, 0xbd, 0xc1, 0xa5, 0xba, 0x73, 0x39, 0x61, 0xfe, 0xf8,
-	0xa4, 0x21, 0x3f, 0x6a, 0x30, 0x90, 0xc9, 0xbe, 0xf1, 0xab, 0x58, 0x39, 0x3f, 0x75, 0xec, 0xc1,
-	0x60, 0x54, 0x11, 0xff, 0x04, 0x9f, 0xfd, 0x09, 0x00, 0x00, 0xff, 0xff, 0x7a, 0xa4, 0xc0, 0xb4,
-	0x16, 0x0a, 0x00, 0x00,
+	// 1619 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xeb, 0x6e, 0xdb, 0xc6,
+	0x12, 0xb6, 0x2e, 0x96, 0xcd, 0x91, 0x2f, 0xf2, 0xda, 0x48, 0x74, 0x78, 0x72, 0x1c, 0x65, 0x13,
+	0x1c, 0x18, 0x27, 0xa7, 0x6a, 0xea, 0xa2, 0x40, 0x51, 0xb4, 0x40, 0x15, 0x39, 0x71, 0xdc, 0x18,
+	0xb1, 0x4b, 0xc5, 0x41, 0x80, 0xa2, 0x08, 0x28, 0x72, 0x2d, 0x13, 0xa6, 0xb5, 0x2c, 0xb9, 0x72,
+	0xac, 0xf6, 0x4f, 0xd0, 0x47, 0xc9, 0x2b, 0xf4, 0x69, 0xfa, 0x10, 0x7d, 0x87, 0x62, 0x2f, 0xbc,
+	0xd3, 0x34, 0x1d, 0xf4, 0xdf, 0xce, 0x70, 0x76, 0xf6, 0x9b, 0xcb, 0xce, 0xce, 0x10, 0x34, 0xd3,
+	0x73, 0xfa, 0x9e, 0x4f, 0x19, 0x45, 0x6b, 0x7c, 0x19, 0x10, 0xff, 0xd2, 0xb1, 0x48, 0xdf, 0x1b,
+	0x63, 0x04, 0x9d, 0x7d, 0xc2, 0x5e, 0xd0, 0x80, 0x1d, 0xec, 0x19, 0xe4, 0x97, 0x19, 0x09, 0x18,
+	0xde, 0x81, 0xb5, 0x04, 0xcf, 0x73, 0xe7, 0xe8, 0x0e, 0xb4, 0x3c, 0x42, 0xfc, 0x83, 0xbd, 0x6e,
+	0xad, 0x57, 0xdb, 0x59, 0x31, 0x14, 0x85, 0xaf, 0x00, 0x5e, 0x9f, 0xf9, 0xc4, 0xb4, 0x5f, 0x92,
+	0x79, 0x80, 0xba, 0xb0, 0xa4, 0xd6, 0x4a, 0x2c, 0x24, 0xd1, 0x3d, 0xd0, 0x4e, 0xa9, 0xeb, 0xd2,
+	0xf7, 0xfc, 0x5b, 0x5d, 0x7c, 0x8b, 0x19, 0x5c, 0xbb, 0x4b, 0x27, 0xfc, 0x53, 0x43, 0x6a, 0x97,
+	0x14, 0xd2, 0x61, 0xf9, 0x9c, 0xcc, 0x9f, 0x79, 0xd4, 0x3a, 0xeb, 0x36, 0x7b, 0xb5, 0x9d, 0xa6,
+	0x11, 0xd1, 0xd8, 0x84, 0xcd, 0xa1, 0x4f, 0x4c, 0x46, 0xe4, 0xf9, 0x0a, 0x3a, 0xdf, 0xc2, 0x04,
+	0x23, 0x82, 0x1a, 0xd1, 0xa8, 0x0f, 0xcd, 0x73, 0x32, 0x0f, 0xc4, 0xf9, 0xed, 0x5d, 0xbd, 0x9f,
+	0xf6, 0x44, 0x3f, 0x36, 0xc4, 0x10, 0x72, 0xf8, 0x3d, 0x2c, 0x1d, 0xd2, 0xc9, 0xc1, 0xf4, 0x94,
+	0xa2, 0x35, 0xa8, 0x47, 0x0a, 0xeb, 0x07, 0x7b, 0xc2, 0x1f, 0xb3, 0x71, 0x6c, 0x8c, 0xa2, 0xb8,
+	0x07, 0x3c, 0xdf, 0xb9, 0x8c, 0x4d, 0x09, 0x49, 0xb4, 0x05, 0x8b, 0xa6, 0x6d, 0xfb, 0x41, 0xb7,
+	0xd9, 0x6b, 0xec, 0xac, 0x18, 0x92, 0xe0, 0xdc, 0x33, 0x62, 0xda, 0x41, 0x77, 0x51, 0x72, 0x05,
+	0x81, 0x3f, 0xd6, 0x60, 0x5d, 0xa2, 0xe1, 0x87, 0xcb, 0x08, 0x64, 0x11, 0x3c, 0x86, 0xa6, 0x4b,
+	0x27, 0xdc, 0x98, 0xc6, 0x4e, 0x7b, 0xf7, 0x6e, 0xd6, 0x18, 0x05, 0xdc, 0x10, 0x42, 0xc9, 0xc0,
+	0x34, 0x4a, 0x02, 0xd3, 0xcc, 0x06, 0x26, 0x19, 0x80, 0xc5, 0x4c, 0x00, 0xde, 0x40, 0x67, 0x60,
+	0xdb, 0x69, 0xef, 0x23, 0x68, 0x72, 0xbb, 0x14, 0x4c, 0xb1, 0xbe, 0xb5, 0xd7, 0xfb, 0x22, 0x21,
+	0x2b, 0x47, 0x15, 0x7f, 0x0e, 0x1b, 0xc7, 0x33, 0xd7, 0xad, 0xbe, 0x61, 0x03, 0xd6, 0x93, 0x1b,
+	0x3c, 0x77, 0x8e, 0x4f, 0x60, 0x73, 0x8f, 0xb8, 0xe4, 0x36, 0xc9, 0x84, 0x61, 0xc5, 0x16, 0x5b,
+	0x9e, 0xba, 0xd4, 0x3a, 0x97, 0xe6, 0x2d, 0x1b, 0x29, 0x1e, 0xde, 0x84, 0x8d, 0xb4, 0x5a, 0x7e,
+	0xd6, 0x0f, 0xc2, 0x3e, 0x6e, 0xf0, 0x80, 0x55, 0x39, 0x28, 0x19, 0x83, 0x7a, 0x26, 0x06, 0x23,
+	0xd8, 0x30, 0x28, 0x33, 0x19, 0x11, 0xfe, 0xab, 0x86, 0xda, 0x27, 0x17, 0xf4, 0x92, 0xf0, 0xfc,
+	0xd8, 0x93, 0xd9, 0xb3, 0x62, 0xa4, 0x78, 0xb8, 0x0f, 0xcb, 0x83, 0xe1, 0xe1, 0xb3, 0x29, 0xf3,
+	0xf3, 0x59, 0x87, 0xa0, 0xe9, 0x53, 0x97, 0x08, 0x20, 0x8b, 0x86, 0x58, 0xe3, 0xc7, 0xb0, 0xba,
+	0x4f, 0xd8, 0x60, 0x78, 0x58, 0xc5, 0xf9, 0x6f, 0x85, 0x72, 0x99, 0xd2, 0x5d, 0x58, 0xba, 0x24,
+	0x7e, 0xe0, 0xd0, 0xa9, 0x10, 0x6b, 0x1a, 0x21, 0x89, 0x76, 0x61, 0x89, 0x4c, 0x99, 0xef, 0x90,
+	0x30, 0xbf, 0xbb, 0xd9, 0xb4, 0x09, 0x11, 0x1a, 0xa1, 0x20, 0x1e, 0x43, 0xe7, 0xc4, 0xb3, 0x4d,
+	0x46, 0xaa, 0x21, 0xf9, 0xa4, 0x33, 0x76, 0x61, 0x6b, 0x9f, 0xb0, 0x63, 0x42, 0xfc, 0x63, 0xea,
+	0x3a, 0xd6, 0xbc, 0x8a, 0xc5, 0xef, 0x60, 0x3d, 0xb9, 0xc1, 0x73, 0x65, 0x2d, 0xe0, 0x57, 0xac,
+	0x5b, 0x53, 0xb5, 0x80, 0x13, 0xdc, 0xb7, 0x36, 0x99, 0xce, 0x55, 0x4c, 0xc4, 0x9a, 0xc7, 0xcb,
+	0xa5, 0x13, 0xbe, 0x3f, 0x38, 0x9a, 0xba, 0xf2, 0xf6, 0x2e, 0x1b, 0x29, 0x1e, 0xfe, 0x50, 0x83,
+	0xad, 0xd1, 0x2d, 0x51, 0xc5, 0x10, 0xea, 0x45, 0x10, 0x1a, 0x25, 0x10, 0x9a, 0x05, 0x10, 0xb6,
+	0x00, 0x65, 0x10, 0xf0, 0x4c, 0xdf, 0x03, 0x34, 0xb0, 0xed, 0xe7, 0xa2, 0x9a, 0x10, 0xbf, 0x0a,
+	0xaa, 0xb0, 0x7e, 0xd4, 0xe3, 0xfa, 0x81, 0xff, 0x07, 0x9d, 0x94, 0x96, 0xb2, 0xe7, 0xe8, 0x39,
+	0x6c, 0x0d, 0x6c, 0x71, 0xcf, 0x1c, 0xcb, 0x64, 0xf4, 0x93, 0xcf, 0xfc, 0x3f, 0xa0, 0x8c, 0x9e,
+	0xb2, 0x53, 0x7f, 0x0e, 0x9f, 0xa2, 0x83, 0xe9, 0xa5, 0xc3, 0x48, 0x95, 0x43, 0xbb, 0xb0, 0xe4,
+	0x08, 0x61, 0xa2, 0xce, 0x0d, 0x49, 0xd4, 0x81, 0x06, 0x63, 0xae, 0x08, 0x74, 0xc3, 0xe0, 0x4b,
+	0xfc, 0x18, 0x36, 0xd2, 0xea, 0x15, 0x16, 0xb9, 0x23, 0xc4, 0x22, 0x29, 0xfc, 0x19, 0x6c, 0x0e,
+	0x2c, 0x8b, 0x78, 0x2c, 0x8d, 0xe5, 0x3a, 0xf1, 0x3f, 0x6b, 0x21, 0x76, 0x83, 0x58, 0xd4, 0xb7,
+	0x2b, 0x3a, 0x6c, 0x4c, 0xed, 0xf0, 0xe5, 0x13, 0x6b, 0xd4, 0x83, 0xb6, 0x45, 0xa7, 0x8c, 0x4c,
+	0xd9, 0xeb, 0xb9, 0x47, 0x04, 0x7a, 0xcd, 0x48, 0xb2, 0x78, 0xc2, 0x59, 0xd4, 0x26, 0x96, 0xc8,
+	0x1f, 0xcd, 0x90, 0x04, 0x7a, 0x04, 0xab, 0x81, 0x75, 0x46, 0x2e, 0xcc, 0x37, 0xaa, 0x10, 0x2c,
+	0x8a, 0xaf, 0x69, 0x26, 0x47, 0x6f, 0xce, 0xd8, 0x19, 0xf5, 0xbb, 0x2d, 0x89, 0x5e, 0x52, 0xfc,
+	0xf1, 0xb2, 0xce, 0x66, 0xd3, 0xf3, 0x91, 0xf3, 0x2b, 0xe9, 0x2e, 0x09, 0x8f, 0xc5, 0x0c, 0xfc,
+	0x7b, 0x0d, 0x5a, 0xd2, 0x2a, 0xb4, 0x0d, 0xe0, 0x8b, 0xd5, 0x2b, 0x6a, 0x87, 0x2e, 0x48, 0x70,
+	0xb8, 0x22, 0x72, 0x49, 0xa6, 0x4c, 0x7c, 0x56, 0xed, 0x49, 0xc4, 0xe0, 0xbb, 0xf9, 0xbb, 0x4c,
+	0x7c, 0xf1, 0x59, 0x3e, 0xa0, 0x09, 0x0e, 0x77, 0x16, 0x77, 0x82, 0xf8, 0x2a, 0x9f, 0xd0, 0x88,
+	0xc6, 0x3e, 0xac, 0xbd, 0x22, 0xef, 0x43, 0xe7, 0xf2, 0xc8, 0xdd, 0x70, 0x2b, 0x5d, 0x5e, 0x84,
+	0x15, 0x06, 0x49, 0xa0, 0x3e, 0xb4, 0x24, 0x56, 0x71, 0x76, 0x7b, 0xf7, 0x4e, 0xb6, 0x50, 0x29,
+	0xf5, 0x4a, 0x0a, 0x33, 0x71, 0x63, 0xaa, 0x07, 0xf4, 0x9f, 0x39, 0xb5, 0x03, 0x6b, 0x89, 0x53,
+	0xe3, 0x97, 0xae, 0x3a, 0x0e, 0x1d, 0x96, 0xa5, 0xae, 0x08, 0x4a, 0x44, 0xe3, 0xef, 0x61, 0x2d,
+	0xa1, 0x8b, 0xfb, 0x31, 0xc6, 0x57, 0xab, 0x84, 0xef, 0x43, 0x0d, 0xd0, 0xa1, 0x13, 0x28, 0x1d,
+	0xc1, 0xa7, 0x3b, 0x06, 0x41, 0xf3, 0xd4, 0xa7, 0x17, 0x2a, 0x11, 0xc4, 0x9a, 0xbf, 0x93, 0x8c,
+	0xaa, 0xe0, 0xd7, 0x19, 0x15, 0x3b, 0x9d, 0x0b, 0x87, 0x89, 0x7c, 0x5e, 0x34, 0x24, 0x81, 0xdf,
+	0x42, 0x27, 0x85, 0x80, 0x9b, 0xf1, 0x84, 0xb7, 0x66, 0x82, 0x16, 0xaf, 0xc1, 0xf5, 0x76, 0x84,
+	0x62, 0xfc, 0xfc, 0x29, 0xb9, 0x62, 0xe1, 0xfd, 0xe3, 0x6b, 0x3c, 0x86, 0x2e, 0xd7, 0x1c, 0xf6,
+	0x19, 0xb7, 0xb1, 0x90, 0xd1, 0x73, 0x32, 0x15, 0xca, 0x34, 0x43, 0x12, 0x31, 0xfa, 0x46, 0x12,
+	0xfd, 0x29, 0xdc, 0x29, 0x38, 0x83, 0xdb, 0xf0, 0x75, 0xd6, 0x86, 0xed, 0xac, 0x0d, 0xe9, 0x3b,
+	0x50, 0x6c, 0x8b, 0xa6, 0x6c, 0xf9, 0x0e, 0xb4, 0x43, 0x3a, 0x19, 0xce, 0xfc, 0x80, 0xfa, 0x71,
+	0x08, 0x6a, 0xc9, 0x10, 0x94, 0x65, 0xca, 0x6f, 0xd0, 0x19, 0xcd, 0xc6, 0x81, 0xe5, 0x3b, 0xe3,
+	0xa8, 0xfc, 0xdd, 0x03, 0x2d, 0x34, 0x39, 0x50, 0x8f, 0x6e, 0xcc, 0xe0, 0xe5, 0xc5, 0x27, 0x9e,
+	0x6b, 0xce, 0x55, 0x13, 0xa7, 0x28, 0xf4, 0x05, 0xb4, 0x2c, 0x81, 0x42, 0xbc, 0x87, 0xed, 0xdd,
+	0x7f, 0x15, 0x34, 0xd9, 0x12, 0xa6, 0xa1, 0x04, 0x55, 0xf3, 0x7a, 0x34, 0x63, 0x63, 0x7a, 0x55,
+	0xa5, 0x39, 0xf8, 0xa3, 0x06, 0x6d, 0x29, 0x2d, 0xfb, 0xad, 0x5b, 0x9b, 0x9b, 0x78, 0x94, 0x1a,
+	0xc9, 0x47, 0x89, 0xef, 0x31, 0x19, 0x23, 0x17, 0x1e, 0x0b, 0x44, 0x5e, 0x2e, 0x1a, 0x11, 0xcd,
+	0xab, 0x35, 0xf7, 0xf4, 0x40, 0xd2, 0x22, 0x47, 0x1b, 0x46, 0x92, 0xc5, 0x1d, 0xe6, 0x9a, 0x01,
+	0x7b, 0xe6, 0xfb, 0xaa, 0xe8, 0x6a, 0x46, 0xcc, 0xc0, 0xfb, 0xe2, 0x32, 0x86, 0x56, 0xf2, 0x0c,
+	0xf8, 0x2a, 0x6e, 0xa6, 0x64, 0x06, 0xfc, 0x3b, 0xeb, 0xab, 0x84, 0x95, 0x71, 0x3f, 0xd5, 0x06,
+	0x6d, 0x7f, 0x18, 0x4e, 0x9d, 0x0f, 0x61, 0x69, 0x7f, 0x28, 0xd5, 0x89, 0x79, 0x85, 0xb7, 0xa4,
+	0xf2, 0x72, 0x37, 0x8c, 0x90, 0xdc, 0xfd, 0x6b, 0x1d, 0x1a, 0x83, 0xe3, 0x03, 0x74, 0x04, 0x5a,
+	0x34, 0xa2, 0xa2, 0x5e, 0xf6, 0xb0, 0xec, 0x44, 0xab, 0x6f, 0x97, 0x48, 0xf0, 0x52, 0xb5, 0x80,
+	0xde, 0xc0, 0x4a, 0x72, 0x9e, 0x44, 0x0f, 0xb3, 0x3b, 0x0a, 0xa6, 0x4d, 0xfd, 0x7e, 0xf1, 0x34,
+	0x13, 0x4d, 0x6d, 0x78, 0x01, 0x1d, 0x83, 0x16, 0x8d, 0x49, 0x79, 0xa0, 0xd9, 0x09, 0xaa, 0xa2,
+	0xc6, 0x68, 0x40, 0x2a, 0x34, 0xfd, 0xd6, 0x1a, 0x0d, 0x80, 0x78, 0x22, 0x42, 0x0f, 0xb2, 0x1b,
+	0x72, 0xe3, 0x95, 0x7e, 0xbf, 0x4c, 0x44, 0xea, 0x7c, 0x0b, 0x2b, 0xc9, 0xd9, 0x27, 0xef, 0xcf,
+	0x82, 0x81, 0x4b, 0x7f, 0x50, 0x2e, 0x24, 0x35, 0xbf, 0x14, 0xf6, 0xcb, 0x01, 0xaa, 0xd0, 0xfe,
+	0xd4, 0x6c, 0xa5, 0x97, 0x4c, 0x9c, 0xd2, 0xf4, 0x78, 0x82, 0xca, 0x9b, 0x9e, 0x9b, 0xae, 0xaa,
+	0xb8, 0x73, 0x08, 0x2d, 0x39, 0x10, 0xa1, 0xff, 0x14, 0xa0, 0x8b, 0xc7, 0x13, 0xbd, 0x68, 0xe2,
+	0x08, 0x95, 0x1c, 0x81, 0x16, 0x8d, 0x33, 0x79, 0x2b, 0xb3, 0x93, 0x8e, 0x7e, 0x43, 0xc5, 0x15,
+	0x01, 0x59, 0x4d, 0xcd, 0x2e, 0xe8, 0x51, 0x01, 0xb8, 0xdc, 0x10, 0x51, 0x10, 0xea, 0x4c, 0x97,
+	0xbf, 0x80, 0x7e, 0x82, 0xd5, 0x51, 0xb9, 0xe6, 0xa2, 0xf1, 0x44, 0xc7, 0x37, 0x48, 0x49, 0xe5,
+	0x27, 0xd0, 0x4e, 0xb4, 0xff, 0x08, 0x17, 0xdc, 0xa0, 0xcc, 0x84, 0xa1, 0xf7, 0x4a, 0x65, 0x22,
+	0xcc, 0xa9, 0x0e, 0x3f, 0x8f, 0xb9, 0x68, 0x90, 0xd0, 0xf1, 0x0d, 0x52, 0x51, 0xee, 0x27, 0x3b,
+	0xf6, 0xeb, 0x6a, 0x49, 0xaa, 0x45, 0xd7, 0x1f, 0x94, 0x0b, 0x45, 0x55, 0x2a, 0xd9, 0xde, 0xe7,
+	0x35, 0x17, 0x34, 0xff, 0x55, 0x52, 0xf6, 0x24, 0x44, 0xac, 0x1a, 0xe6, 0x6b, 0x10, 0xa7, 0x7a,
+	0xb9, 0x0a, 0x39, 0x77, 0x24, 0x8a, 0x9f, 0xd2, 0xd9, 0x2b, 0xf4, 0x5d, 0xa9, 0xc2, 0x4c, 0x43,
+	0xb9, 0xa0, 0xca, 0xfe, 0x75, 0x0a, 0xb3, 0xdd, 0xa6, 0xbe, 0x5d, 0x22, 0x11, 0xa5, 0x57, 0xa2,
+	0x25, 0xcb, 0xa7, 0x57, 0xbe, 0x63, 0xd4, 0x7b, 0xa5, 0x32, 0x52, 0xed, 0x04, 0x36, 0x72, 0xbd,
+	0x12, 0xda, 0x29, 0xda, 0x58, 0xd4, 0xb2, 0xe9, 0xff, 0xad, 0x20, 0x29, 0x0f, 0xfa, 0x11, 0xb4,
+	0xa8, 0xdb, 0xc9, 0x3b, 0x24, 0xdb, 0x08, 0xdd, 0x1c, 0xb2, 0x27, 0x35, 0xe5, 0x63, 0xf9, 0x5e,
+	0x17, 0xfa, 0x38, 0xd5, 0xde, 0xe8, 0xdb, 0x25, 0x12, 0x12, 0xe3, 0x37, 0x50, 0xdf, 0x1f, 0xa2,
+	0x5c, 0xf7, 0x14, 0xbd, 0xfc, 0xfa, 0xdd, 0xa2, 0x4f, 0x62, 0xef, 0xd3, 0x6f, 0xe1, 0xbe, 0x43,
+	0xfb, 0x8c, 0x5c, 0x31, 0xc7, 0x25, 0x7d, 0xd9, 0x37, 0x05, 0xef, 0x94, 0xe8, 0xbb, 0x89, 0xef,
+	0x59, 0x4f, 0xd7, 0xa4, 0x63, 0x82, 0x91, 0x64, 0x1e, 0xd7, 0x3e, 0xd6, 0x5b, 0xaf, 0x5f, 0x18,
+	0x7b, 0xa3, 0xd1, 0xb8, 0x25, 0xfe, 0x79, 0x7f, 0xf9, 0xf7, 0x00, 0x1e, 0xce, 0x74, 0xab, 0x00,
+	0x17, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1116,11 +2449,24 @@ type APIClient interface {
 	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
 	PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error)
 	DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error)
+	GetKeysAt(ctx context.Context, in *GetKeysAtRequest, opts ...grpc.CallOption) (*ThreadKeys, error)
+	RotateKeys(ctx context.Context, in *RotateKeysRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
+	GetACL(ctx context.Context, in *GetACLRequest, opts ...grpc.CallOption) (*ACLReply, error)
+	UpdateACL(ctx context.Context, in *UpdateACLRequest, opts ...grpc.CallOption) (*NewRecordReply, error)
+	GetPeerPolicy(ctx context.Context, in *GetPeerPolicyRequest, opts ...grpc.CallOption) (*PeerPolicyReply, error)
+	SetPeerPolicy(ctx context.Context, in *SetPeerPolicyRequest, opts ...grpc.CallOption) (*SetPeerPolicyReply, error)
 	AddFollower(ctx context.Context, in *AddFollowerRequest, opts ...grpc.CallOption) (*AddFollowerReply, error)
+	AddReplicator(ctx context.Context, in *AddReplicatorRequest, opts ...grpc.CallOption) (*AddReplicatorReply, error)
+	CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteReply, error)
+	AcceptInvite(ctx context.Context, in *AcceptInviteRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
 	CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*NewRecordReply, error)
 	AddRecord(ctx context.Context, in *AddRecordRequest, opts ...grpc.CallOption) (*AddRecordReply, error)
 	GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*GetRecordReply, error)
+	ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsReply, error)
+	ListThreadRecords(ctx context.Context, in *ListThreadRecordsRequest, opts ...grpc.CallOption) (*ListThreadRecordsReply, error)
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (API_SubscribeClient, error)
+	GetOutbox(ctx context.Context, in *GetOutboxRequest, opts ...grpc.CallOption) (*GetOutboxReply, error)
+	GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (*GCReply, error)
 }
 
 type aPIClient struct {
@@ -1140,54 +2486,135 @@ func (c *aPIClient) GetHostID(ctx context.Context, in *GetHostIDRequest, opts ..
 	return out, nil
 }
 
-func (c *aPIClient) CreateThread(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
-	out := new(ThreadInfoReply)
-	err := c.cc.Invoke(ctx, "/api.service.pb.API/CreateThread", in, out, opts...)
+func (c *aPIClient) CreateThread(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
+	out := new(ThreadInfoReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/CreateThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AddThread(ctx context.Context, in *AddThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
+	out := new(ThreadInfoReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/AddThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
+	out := new(ThreadInfoReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/GetThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error) {
+	out := new(PullThreadReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/PullThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error) {
+	out := new(DeleteThreadReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/DeleteThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetKeysAt(ctx context.Context, in *GetKeysAtRequest, opts ...grpc.CallOption) (*ThreadKeys, error) {
+	out := new(ThreadKeys)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/GetKeysAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RotateKeys(ctx context.Context, in *RotateKeysRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
+	out := new(ThreadInfoReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/RotateKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetACL(ctx context.Context, in *GetACLRequest, opts ...grpc.CallOption) (*ACLReply, error) {
+	out := new(ACLReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/GetACL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateACL(ctx context.Context, in *UpdateACLRequest, opts ...grpc.CallOption) (*NewRecordReply, error) {
+	out := new(NewRecordReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/UpdateACL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetPeerPolicy(ctx context.Context, in *GetPeerPolicyRequest, opts ...grpc.CallOption) (*PeerPolicyReply, error) {
+	out := new(PeerPolicyReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/GetPeerPolicy", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) AddThread(ctx context.Context, in *AddThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
-	out := new(ThreadInfoReply)
-	err := c.cc.Invoke(ctx, "/api.service.pb.API/AddThread", in, out, opts...)
+func (c *aPIClient) SetPeerPolicy(ctx context.Context, in *SetPeerPolicyRequest, opts ...grpc.CallOption) (*SetPeerPolicyReply, error) {
+	out := new(SetPeerPolicyReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/SetPeerPolicy", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
-	out := new(ThreadInfoReply)
-	err := c.cc.Invoke(ctx, "/api.service.pb.API/GetThread", in, out, opts...)
+func (c *aPIClient) AddFollower(ctx context.Context, in *AddFollowerRequest, opts ...grpc.CallOption) (*AddFollowerReply, error) {
+	out := new(AddFollowerReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/AddFollower", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error) {
-	out := new(PullThreadReply)
-	err := c.cc.Invoke(ctx, "/api.service.pb.API/PullThread", in, out, opts...)
+func (c *aPIClient) AddReplicator(ctx context.Context, in *AddReplicatorRequest, opts ...grpc.CallOption) (*AddReplicatorReply, error) {
+	out := new(AddReplicatorReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/AddReplicator", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error) {
-	out := new(DeleteThreadReply)
-	err := c.cc.Invoke(ctx, "/api.service.pb.API/DeleteThread", in, out, opts...)
+func (c *aPIClient) CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteReply, error) {
+	out := new(CreateInviteReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/CreateInvite", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) AddFollower(ctx context.Context, in *AddFollowerRequest, opts ...grpc.CallOption) (*AddFollowerReply, error) {
-	out := new(AddFollowerReply)
-	err := c.cc.Invoke(ctx, "/api.service.pb.API/AddFollower", in, out, opts...)
+func (c *aPIClient) AcceptInvite(ctx context.Context, in *AcceptInviteRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
+	out := new(ThreadInfoReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/AcceptInvite", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1221,6 +2648,24 @@ func (c *aPIClient) GetRecord(ctx context.Context, in *GetRecordRequest, opts ..
 	return out, nil
 }
 
+func (c *aPIClient) ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsReply, error) {
+	out := new(ListRecordsReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/ListRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListThreadRecords(ctx context.Context, in *ListThreadRecordsRequest, opts ...grpc.CallOption) (*ListThreadRecordsReply, error) {
+	out := new(ListThreadRecordsReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/ListThreadRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (API_SubscribeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[0], "/api.service.pb.API/Subscribe", opts...)
 	if err != nil {
@@ -1253,6 +2698,24 @@ func (x *aPISubscribeClient) Recv() (*NewRecordReply, error) {
 	return m, nil
 }
 
+func (c *aPIClient) GetOutbox(ctx context.Context, in *GetOutboxRequest, opts ...grpc.CallOption) (*GetOutboxReply, error) {
+	out := new(GetOutboxReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/GetOutbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (*GCReply, error) {
+	out := new(GCReply)
+	err := c.cc.Invoke(ctx, "/api.service.pb.API/GC", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServer is the server API for API service.
 type APIServer interface {
 	GetHostID(context.Context, *GetHostIDRequest) (*GetHostIDReply, error)
@@ -1261,11 +2724,24 @@ type APIServer interface {
 	GetThread(context.Context, *GetThreadRequest) (*ThreadInfoReply, error)
 	PullThread(context.Context, *PullThreadRequest) (*PullThreadReply, error)
 	DeleteThread(context.Context, *DeleteThreadRequest) (*DeleteThreadReply, error)
+	GetKeysAt(context.Context, *GetKeysAtRequest) (*ThreadKeys, error)
+	RotateKeys(context.Context, *RotateKeysRequest) (*ThreadInfoReply, error)
+	GetACL(context.Context, *GetACLRequest) (*ACLReply, error)
+	UpdateACL(context.Context, *UpdateACLRequest) (*NewRecordReply, error)
+	GetPeerPolicy(context.Context, *GetPeerPolicyRequest) (*PeerPolicyReply, error)
+	SetPeerPolicy(context.Context, *SetPeerPolicyRequest) (*SetPeerPolicyReply, error)
 	AddFollower(context.Context, *AddFollowerRequest) (*AddFollowerReply, error)
+	AddReplicator(context.Context, *AddReplicatorRequest) (*AddReplicatorReply, error)
+	CreateInvite(context.Context, *CreateInviteRequest) (*CreateInviteReply, error)
+	AcceptInvite(context.Context, *AcceptInviteRequest) (*ThreadInfoReply, error)
 	CreateRecord(context.Context, *CreateRecordRequest) (*NewRecordReply, error)
 	AddRecord(context.Context, *AddRecordRequest) (*AddRecordReply, error)
 	GetRecord(context.Context, *GetRecordRequest) (*GetRecordReply, error)
+	ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsReply, error)
+	ListThreadRecords(context.Context, *ListThreadRecordsRequest) (*ListThreadRecordsReply, error)
 	Subscribe(*SubscribeRequest, API_SubscribeServer) error
+	GetOutbox(context.Context, *GetOutboxRequest) (*GetOutboxReply, error)
+	GC(context.Context, *GCRequest) (*GCReply, error)
 }
 
 // UnimplementedAPIServer can be embedded to have forward compatible implementations.
@@ -1290,9 +2766,36 @@ func (*UnimplementedAPIServer) PullThread(ctx context.Context, req *PullThreadRe
 func (*UnimplementedAPIServer) DeleteThread(ctx context.Context, req *DeleteThreadRequest) (*DeleteThreadReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteThread not implemented")
 }
+func (*UnimplementedAPIServer) GetKeysAt(ctx context.Context, req *GetKeysAtRequest) (*ThreadKeys, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeysAt not implemented")
+}
+func (*UnimplementedAPIServer) RotateKeys(ctx context.Context, req *RotateKeysRequest) (*ThreadInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKeys not implemented")
+}
+func (*UnimplementedAPIServer) GetACL(ctx context.Context, req *GetACLRequest) (*ACLReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetACL not implemented")
+}
+func (*UnimplementedAPIServer) UpdateACL(ctx context.Context, req *UpdateACLRequest) (*NewRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateACL not implemented")
+}
+func (*UnimplementedAPIServer) GetPeerPolicy(ctx context.Context, req *GetPeerPolicyRequest) (*PeerPolicyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerPolicy not implemented")
+}
+func (*UnimplementedAPIServer) SetPeerPolicy(ctx context.Context, req *SetPeerPolicyRequest) (*SetPeerPolicyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPeerPolicy not implemented")
+}
 func (*UnimplementedAPIServer) AddFollower(ctx context.Context, req *AddFollowerRequest) (*AddFollowerReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddFollower not implemented")
 }
+func (*UnimplementedAPIServer) AddReplicator(ctx context.Context, req *AddReplicatorRequest) (*AddReplicatorReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReplicator not implemented")
+}
+func (*UnimplementedAPIServer) CreateInvite(ctx context.Context, req *CreateInviteRequest) (*CreateInviteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvite not implemented")
+}
+func (*UnimplementedAPIServer) AcceptInvite(ctx context.Context, req *AcceptInviteRequest) (*ThreadInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvite not implemented")
+}
 func (*UnimplementedAPIServer) CreateRecord(ctx context.Context, req *CreateRecordRequest) (*NewRecordReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
 }
@@ -1302,9 +2805,21 @@ func (*UnimplementedAPIServer) AddRecord(ctx context.Context, req *AddRecordRequ
 func (*UnimplementedAPIServer) GetRecord(ctx context.Context, req *GetRecordRequest) (*GetRecordReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRecord not implemented")
 }
+func (*UnimplementedAPIServer) ListRecords(ctx context.Context, req *ListRecordsRequest) (*ListRecordsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecords not implemented")
+}
+func (*UnimplementedAPIServer) ListThreadRecords(ctx context.Context, req *ListThreadRecordsRequest) (*ListThreadRecordsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListThreadRecords not implemented")
+}
 func (*UnimplementedAPIServer) Subscribe(req *SubscribeRequest, srv API_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (*UnimplementedAPIServer) GetOutbox(ctx context.Context, req *GetOutboxRequest) (*GetOutboxReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutbox not implemented")
+}
+func (*UnimplementedAPIServer) GC(ctx context.Context, req *GCRequest) (*GCReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GC not implemented")
+}
 
 func RegisterAPIServer(s *grpc.Server, srv APIServer) {
 	s.RegisterService(&_API_serviceDesc, srv)
@@ -1418,6 +2933,114 @@ func _API_DeleteThread_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetKeysAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeysAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetKeysAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/GetKeysAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetKeysAt(ctx, req.(*GetKeysAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RotateKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RotateKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/RotateKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RotateKeys(ctx, req.(*RotateKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/GetACL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetACL(ctx, req.(*GetACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/UpdateACL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateACL(ctx, req.(*UpdateACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetPeerPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetPeerPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/GetPeerPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetPeerPolicy(ctx, req.(*GetPeerPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SetPeerPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPeerPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SetPeerPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/SetPeerPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetPeerPolicy(ctx, req.(*SetPeerPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_AddFollower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddFollowerRequest)
 	if err := dec(in); err != nil {
@@ -1436,6 +3059,60 @@ func _API_AddFollower_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_AddReplicator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReplicatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AddReplicator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/AddReplicator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AddReplicator(ctx, req.(*AddReplicatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/CreateInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateInvite(ctx, req.(*CreateInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AcceptInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AcceptInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/AcceptInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AcceptInvite(ctx, req.(*AcceptInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRecordRequest)
 	if err := dec(in); err != nil {
@@ -1490,6 +3167,42 @@ func _API_GetRecord_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_ListRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/ListRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListRecords(ctx, req.(*ListRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListThreadRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThreadRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListThreadRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/ListThreadRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListThreadRecords(ctx, req.(*ListThreadRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1511,6 +3224,42 @@ func (x *aPISubscribeServer) Send(m *NewRecordReply) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _API_GetOutbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetOutbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/GetOutbox",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetOutbox(ctx, req.(*GetOutboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.service.pb.API/GC",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GC(ctx, req.(*GCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _API_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.service.pb.API",
 	HandlerType: (*APIServer)(nil),
@@ -1539,10 +3288,46 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteThread",
 			Handler:    _API_DeleteThread_Handler,
 		},
+		{
+			MethodName: "GetKeysAt",
+			Handler:    _API_GetKeysAt_Handler,
+		},
+		{
+			MethodName: "RotateKeys",
+			Handler:    _API_RotateKeys_Handler,
+		},
+		{
+			MethodName: "GetACL",
+			Handler:    _API_GetACL_Handler,
+		},
+		{
+			MethodName: "UpdateACL",
+			Handler:    _API_UpdateACL_Handler,
+		},
+		{
+			MethodName: "GetPeerPolicy",
+			Handler:    _API_GetPeerPolicy_Handler,
+		},
+		{
+			MethodName: "SetPeerPolicy",
+			Handler:    _API_SetPeerPolicy_Handler,
+		},
 		{
 			MethodName: "AddFollower",
 			Handler:    _API_AddFollower_Handler,
 		},
+		{
+			MethodName: "AddReplicator",
+			Handler:    _API_AddReplicator_Handler,
+		},
+		{
+			MethodName: "CreateInvite",
+			Handler:    _API_CreateInvite_Handler,
+		},
+		{
+			MethodName: "AcceptInvite",
+			Handler:    _API_AcceptInvite_Handler,
+		},
 		{
 			MethodName: "CreateRecord",
 			Handler:    _API_CreateRecord_Handler,
@@ -1555,6 +3340,22 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetRecord",
 			Handler:    _API_GetRecord_Handler,
 		},
+		{
+			MethodName: "ListRecords",
+			Handler:    _API_ListRecords_Handler,
+		},
+		{
+			MethodName: "ListThreadRecords",
+			Handler:    _API_ListThreadRecords_Handler,
+		},
+		{
+			MethodName: "GetOutbox",
+			Handler:    _API_GetOutbox_Handler,
+		},
+		{
+			MethodName: "GC",
+			Handler:    _API_GC_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
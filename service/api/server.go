@@ -10,6 +10,7 @@ import (
 	logging "github.com/ipfs/go-log"
 	ma "github.com/multiformats/go-multiaddr"
 	core "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/metrics"
 	pb "github.com/textileio/go-threads/service/api/pb"
 	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
@@ -49,6 +50,8 @@ func NewServer(ctx context.Context, ts core.Service, conf Config, opts ...grpc.S
 		}
 	}
 
+	metrics.RegisterAPI()
+
 	ctx, cancel := context.WithCancel(ctx)
 	s := &Server{
 		rpc:     grpc.NewServer(opts...),
@@ -2,7 +2,9 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
@@ -14,6 +16,7 @@ import (
 	core "github.com/textileio/go-threads/core/service"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/metrics"
 	pb "github.com/textileio/go-threads/service/api/pb"
 	"github.com/textileio/go-threads/service/util"
 	"google.golang.org/grpc/codes"
@@ -104,12 +107,155 @@ func (s *service) DeleteThread(ctx context.Context, req *pb.DeleteThreadRequest)
 	if err != nil {
 		return nil, err
 	}
-	if err := s.s.DeleteThread(ctx, threadID); err != nil {
+	if err := s.s.DeleteThread(ctx, threadID, core.DeleteBlocks(req.DeleteBlocks)); err != nil {
 		return nil, err
 	}
 	return &pb.DeleteThreadReply{}, nil
 }
 
+func (s *service) GetKeysAt(ctx context.Context, req *pb.GetKeysAtRequest) (*pb.ThreadKeys, error) {
+	log.Debugf("received get keys at request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	fk, rk, err := s.s.GetKeysAt(ctx, threadID, req.KeyEpoch)
+	if err != nil {
+		return nil, err
+	}
+	keys := &pb.ThreadKeys{KeyEpoch: req.KeyEpoch}
+	if fk != nil {
+		keys.FollowKey = fk.Bytes()
+	}
+	if rk != nil {
+		keys.ReadKey = rk.Bytes()
+	}
+	return keys, nil
+}
+
+func (s *service) RotateKeys(ctx context.Context, req *pb.RotateKeysRequest) (*pb.ThreadInfoReply, error) {
+	log.Debugf("received rotate keys request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]core.RotateOption, len(req.RemoveLogIDs))
+	for i, id := range req.RemoveLogIDs {
+		logID, err := peer.IDFromBytes(id)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts[i] = core.RemoveLog(logID)
+	}
+	info, err := s.s.RotateKeys(ctx, threadID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return threadInfoToProto(info)
+}
+
+func (s *service) GetACL(ctx context.Context, req *pb.GetACLRequest) (*pb.ACLReply, error) {
+	log.Debugf("received get acl request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.s.GetACL(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*pb.ACLEntry, 0, len(list.Roles))
+	for id, role := range list.Roles {
+		entries = append(entries, &pb.ACLEntry{
+			ID:   marshalPeerID(id),
+			Role: int32(role),
+		})
+	}
+	return &pb.ACLReply{
+		Version: list.Version,
+		Entries: entries,
+	}, nil
+}
+
+func (s *service) UpdateACL(ctx context.Context, req *pb.UpdateACLRequest) (*pb.NewRecordReply, error) {
+	log.Debugf("received update acl request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[peer.ID]thread.Role, len(req.Entries))
+	for _, e := range req.Entries {
+		id, err := peer.IDFromBytes(e.ID)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		roles[id] = thread.Role(e.Role)
+	}
+	rec, err := s.s.UpdateACL(ctx, threadID, roles)
+	if err != nil {
+		if errors.Is(err, core.ErrUnauthorized) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, err
+	}
+	prec, err := cbor.RecordToProto(ctx, s.s, rec.Value())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.NewRecordReply{
+		ThreadID: rec.ThreadID().Bytes(),
+		LogID:    marshalPeerID(rec.LogID()),
+		Record:   util.RecFromServiceRec(prec),
+	}, nil
+}
+
+func (s *service) GetPeerPolicy(ctx context.Context, req *pb.GetPeerPolicyRequest) (*pb.PeerPolicyReply, error) {
+	log.Debugf("received get peer policy request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.s.GetPeerPolicy(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PeerPolicyReply{
+		Allow:        marshalPeerIDs(policy.Allow),
+		Deny:         marshalPeerIDs(policy.Deny),
+		LogPeersOnly: policy.LogPeersOnly,
+	}, nil
+}
+
+func (s *service) SetPeerPolicy(ctx context.Context, req *pb.SetPeerPolicyRequest) (*pb.SetPeerPolicyReply, error) {
+	log.Debugf("received set peer policy request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	allow, err := unmarshalPeerIDs(req.Allow)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	deny, err := unmarshalPeerIDs(req.Deny)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.s.SetPeerPolicy(ctx, threadID, thread.PeerPolicy{
+		Allow:        allow,
+		Deny:         deny,
+		LogPeersOnly: req.LogPeersOnly,
+	}); err != nil {
+		return nil, err
+	}
+	return &pb.SetPeerPolicyReply{}, nil
+}
+
 func (s *service) AddFollower(ctx context.Context, req *pb.AddFollowerRequest) (*pb.AddFollowerReply, error) {
 	log.Debugf("received add follower request")
 
@@ -130,6 +276,56 @@ func (s *service) AddFollower(ctx context.Context, req *pb.AddFollowerRequest) (
 	}, nil
 }
 
+func (s *service) AddReplicator(ctx context.Context, req *pb.AddReplicatorRequest) (*pb.AddReplicatorReply, error) {
+	log.Debugf("received add replicator request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := ma.NewMultiaddrBytes(req.Addr)
+	if err != nil {
+		return nil, err
+	}
+	pid, err := s.s.AddReplicator(ctx, threadID, addr)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AddReplicatorReply{
+		PeerID: marshalPeerID(pid),
+	}, nil
+}
+
+func (s *service) CreateInvite(ctx context.Context, req *pb.CreateInviteRequest) (*pb.CreateInviteReply, error) {
+	log.Debugf("received create invite request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	invitee, err := peer.IDFromBytes(req.Invitee)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	invite, err := s.s.CreateInvite(ctx, threadID, invitee, time.Duration(req.Ttl))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateInviteReply{
+		Invite: invite,
+	}, nil
+}
+
+func (s *service) AcceptInvite(ctx context.Context, req *pb.AcceptInviteRequest) (*pb.ThreadInfoReply, error) {
+	log.Debugf("received accept invite request")
+
+	info, err := s.s.AcceptInvite(ctx, req.Invite)
+	if err != nil {
+		return nil, err
+	}
+	return threadInfoToProto(info)
+}
+
 func (s *service) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest) (*pb.NewRecordReply, error) {
 	log.Debugf("received create record request")
 
@@ -141,7 +337,20 @@ func (s *service) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest)
 	if err != nil {
 		return nil, err
 	}
-	rec, err := s.s.CreateRecord(ctx, threadID, body)
+	opts := []core.EventOption{
+		core.ContentType(req.ContentType),
+		core.Codec(req.Codec),
+		core.SchemaVersion(req.SchemaVersion),
+		core.ChunkSize(int(req.ChunkSize)),
+	}
+	if len(req.Author) > 0 {
+		author, err := peer.IDFromBytes(req.Author)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts = append(opts, core.Author(author))
+	}
+	rec, err := s.s.CreateRecord(ctx, threadID, body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -163,15 +372,11 @@ func (s *service) AddRecord(ctx context.Context, req *pb.AddRecordRequest) (*pb.
 	if err != nil {
 		return nil, err
 	}
-	info, err := s.s.GetThread(ctx, threadID)
-	if err != nil {
-		return nil, err
-	}
 	logID, err := peer.IDFromBytes(req.LogID)
 	if err != nil {
 		return nil, err
 	}
-	rec, err := cbor.RecordFromProto(util.RecToServiceRec(req.Record), info.FollowKey)
+	rec, err := cbor.RecordFromProto(util.RecToServiceRec(req.Record), core.FollowKeys(ctx, s.s, threadID))
 	if err != nil {
 		return nil, err
 	}
@@ -205,7 +410,79 @@ func (s *service) GetRecord(ctx context.Context, req *pb.GetRecordRequest) (*pb.
 	}, nil
 }
 
+func (s *service) ListRecords(ctx context.Context, req *pb.ListRecordsRequest) (*pb.ListRecordsReply, error) {
+	log.Debugf("received list records request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	logID, err := peer.IDFromBytes(req.LogID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	from, err := castOptionalCid(req.From)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	to, err := castOptionalCid(req.To)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	recs, next, err := s.s.ListRecords(ctx, threadID, logID, from, to, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	reply := &pb.ListRecordsReply{
+		Records: make([]*pb.Record, len(recs)),
+	}
+	for i, r := range recs {
+		prec, err := cbor.RecordToProto(ctx, s.s, r)
+		if err != nil {
+			return nil, err
+		}
+		reply.Records[i] = util.RecFromServiceRec(prec)
+	}
+	if next.Defined() {
+		reply.Next = next.Bytes()
+	}
+	return reply, nil
+}
+
+func (s *service) ListThreadRecords(
+	ctx context.Context,
+	req *pb.ListThreadRecordsRequest,
+) (*pb.ListThreadRecordsReply, error) {
+	log.Debugf("received list thread records request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	recs, next, err := s.s.ListThreadRecords(ctx, threadID, req.Token, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	reply := &pb.ListThreadRecordsReply{
+		Records: make([]*pb.NewRecordReply, len(recs)),
+		Next:    next,
+	}
+	for i, r := range recs {
+		prec, err := cbor.RecordToProto(ctx, s.s, r.Value())
+		if err != nil {
+			return nil, err
+		}
+		reply.Records[i] = &pb.NewRecordReply{
+			ThreadID: r.ThreadID().Bytes(),
+			LogID:    marshalPeerID(r.LogID()),
+			Record:   util.RecFromServiceRec(prec),
+		}
+	}
+	return reply, nil
+}
+
 func (s *service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeServer) error {
+	defer metrics.TrackStream("service", "Subscribe")()
 	log.Debugf("received subscribe request")
 
 	opts := make([]core.SubOption, len(req.ThreadIDs))
@@ -216,6 +493,21 @@ func (s *service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeSer
 		}
 		opts[i] = core.ThreadID(threadID)
 	}
+	if req.Replay {
+		cursor := make(map[peer.ID]cid.Cid, len(req.Cursor))
+		for _, c := range req.Cursor {
+			logID, err := peer.IDFromBytes(c.LogID)
+			if err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			recID, err := cid.Cast(c.RecordID)
+			if err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			cursor[logID] = recID
+		}
+		opts = append(opts, core.Cursor(cursor))
+	}
 
 	sub, err := s.s.Subscribe(server.Context(), opts...)
 	if err != nil {
@@ -237,11 +529,78 @@ func (s *service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeSer
 	return nil
 }
 
+func (s *service) GetOutbox(ctx context.Context, req *pb.GetOutboxRequest) (*pb.GetOutboxReply, error) {
+	log.Debugf("received get outbox request")
+
+	threadID, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.s.GetOutbox(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	pentries := make([]*pb.OutboxEntry, len(entries))
+	for i, e := range entries {
+		pentries[i] = &pb.OutboxEntry{
+			LogID:       marshalPeerID(e.Log),
+			RecordID:    e.Record.Bytes(),
+			PeerID:      marshalPeerID(e.Peer),
+			Attempts:    int32(e.Attempts),
+			NextAttempt: e.NextAttempt.UnixNano(),
+			LastError:   e.LastError,
+		}
+	}
+	return &pb.GetOutboxReply{
+		Entries: pentries,
+	}, nil
+}
+
+func (s *service) GC(ctx context.Context, _ *pb.GCRequest) (*pb.GCReply, error) {
+	log.Debugf("received gc request")
+
+	removed, err := s.s.GC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GCReply{
+		Removed: int64(removed),
+	}, nil
+}
+
+// castOptionalCid returns an undefined cid for empty bytes.
+func castOptionalCid(b []byte) (cid.Cid, error) {
+	if len(b) == 0 {
+		return cid.Undef, nil
+	}
+	return cid.Cast(b)
+}
+
 func marshalPeerID(id peer.ID) []byte {
 	b, _ := id.Marshal() // This will never return an error
 	return b
 }
 
+func marshalPeerIDs(ids []peer.ID) [][]byte {
+	bs := make([][]byte, len(ids))
+	for i, id := range ids {
+		bs[i] = marshalPeerID(id)
+	}
+	return bs
+}
+
+func unmarshalPeerIDs(bs [][]byte) ([]peer.ID, error) {
+	ids := make([]peer.ID, len(bs))
+	for i, b := range bs {
+		id, err := peer.IDFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
 func getKeyOptions(keys *pb.ThreadKeys) (opts []core.KeyOption, err error) {
 	if keys == nil {
 		return
@@ -271,6 +630,9 @@ func getKeyOptions(keys *pb.ThreadKeys) (opts []core.KeyOption, err error) {
 		}
 		opts = append(opts, core.LogKey(lk))
 	}
+	if keys.KeyEpoch != 0 {
+		opts = append(opts, core.KeyEpoch(keys.KeyEpoch))
+	}
 	return opts, nil
 }
 
@@ -313,5 +675,6 @@ func threadInfoToProto(info thread.Info) (*pb.ThreadInfoReply, error) {
 		Logs:      logs,
 		ReadKey:   rk,
 		FollowKey: info.FollowKey.Bytes(),
+		KeyEpoch:  info.KeyEpoch,
 	}, nil
 }
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/status"
+	ic "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	gostream "github.com/libp2p/go-libp2p-gostream"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+	pb "github.com/textileio/go-threads/service/pb"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+// requestHeader is the common form of service request headers.
+type requestHeader struct {
+	from *pb.ProtoPeerID
+	sig  []byte
+	key  *pb.ProtoPubKey
+}
+
+// getHeader returns the header of a request, or nil if it has none.
+func getHeader(req proto.Message) (*requestHeader, error) {
+	switch r := req.(type) {
+	case *pb.GetLogsRequest:
+		if r.Header != nil {
+			return &requestHeader{from: r.Header.From, sig: r.Header.Signature, key: r.Header.Key}, nil
+		}
+	case *pb.PushLogRequest:
+		if r.Header != nil {
+			return &requestHeader{from: r.Header.From, sig: r.Header.Signature, key: r.Header.Key}, nil
+		}
+	case *pb.GetRecordsRequest:
+		if r.Header != nil {
+			return &requestHeader{from: r.Header.From, sig: r.Header.Signature, key: r.Header.Key}, nil
+		}
+	case *pb.StreamRecordsRequest:
+		if r.Header != nil {
+			return &requestHeader{from: r.Header.From, sig: r.Header.Signature, key: r.Header.Key}, nil
+		}
+	case *pb.PushRecordRequest:
+		if r.Header != nil {
+			return &requestHeader{from: r.Header.From, sig: r.Header.Signature, key: r.Header.Key}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown request type %T", req)
+	}
+	return nil, nil
+}
+
+// setHeader sets the header of a request.
+func setHeader(req proto.Message, h *requestHeader) error {
+	switch r := req.(type) {
+	case *pb.GetLogsRequest:
+		r.Header = &pb.GetLogsRequest_Header{From: h.from, Signature: h.sig, Key: h.key}
+	case *pb.PushLogRequest:
+		r.Header = &pb.PushLogRequest_Header{From: h.from, Signature: h.sig, Key: h.key}
+	case *pb.GetRecordsRequest:
+		r.Header = &pb.GetRecordsRequest_Header{From: h.from, Signature: h.sig, Key: h.key}
+	case *pb.StreamRecordsRequest:
+		r.Header = &pb.StreamRecordsRequest_Header{From: h.from, Signature: h.sig, Key: h.key}
+	case *pb.PushRecordRequest:
+		r.Header = &pb.PushRecordRequest_Header{From: h.from, Signature: h.sig, Key: h.key}
+	default:
+		return fmt.Errorf("unknown request type %T", req)
+	}
+	return nil
+}
+
+// requestPayload returns the signed bytes of a request, which is the request
+// without its header. Push record requests sign only the record, so they can
+// be verified by peers receiving them over pubsub.
+func requestPayload(req proto.Message) ([]byte, error) {
+	switch r := req.(type) {
+	case *pb.GetLogsRequest:
+		c := *r
+		c.Header = nil
+		return proto.Marshal(&c)
+	case *pb.PushLogRequest:
+		c := *r
+		c.Header = nil
+		return proto.Marshal(&c)
+	case *pb.GetRecordsRequest:
+		c := *r
+		c.Header = nil
+		return proto.Marshal(&c)
+	case *pb.StreamRecordsRequest:
+		c := *r
+		c.Header = nil
+		return proto.Marshal(&c)
+	case *pb.PushRecordRequest:
+		if r.Record == nil {
+			return nil, fmt.Errorf("request record is required")
+		}
+		return r.Record.Marshal()
+	default:
+		return nil, fmt.Errorf("unknown request type %T", req)
+	}
+}
+
+// signRequest signs a request with the host key and sets its header.
+func (s *server) signRequest(req proto.Message) error {
+	sk := s.threads.getPrivKey()
+	if sk == nil {
+		return fmt.Errorf("private key for host not found")
+	}
+	payload, err := requestPayload(req)
+	if err != nil {
+		return err
+	}
+	sig, err := sk.Sign(payload)
+	if err != nil {
+		return err
+	}
+	return setHeader(req, &requestHeader{
+		from: &pb.ProtoPeerID{ID: s.threads.host.ID()},
+		sig:  sig,
+		key:  &pb.ProtoPubKey{PubKey: sk.GetPublic()},
+	})
+}
+
+// verifyRequest returns the sender of a request after checking its signature.
+// Requests received over a libp2p connection must be sent by the connection's
+// remote peer. Requests without a connection, e.g., from pubsub, skip this check.
+func verifyRequest(ctx context.Context, req proto.Message) (peer.ID, error) {
+	h, err := getHeader(req)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	if h == nil || h.from == nil {
+		return "", status.Error(codes.FailedPrecondition, "request header is required")
+	}
+	pk, err := requestPubKey(h.from.ID, h.key)
+	if err != nil {
+		return "", status.Error(codes.Unauthenticated, err.Error())
+	}
+	payload, err := requestPayload(req)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	if ok, err := pk.Verify(payload, h.sig); !ok || err != nil {
+		return "", status.Error(codes.Unauthenticated, "bad signature")
+	}
+	if remote, ok := remotePeer(ctx); ok && remote != h.from.ID {
+		return "", status.Error(
+			codes.PermissionDenied,
+			fmt.Sprintf("request from %s was sent by %s", h.from.ID, remote))
+	}
+	return h.from.ID, nil
+}
+
+// requestPubKey returns the signing key of a request sender.
+func requestPubKey(from peer.ID, key *pb.ProtoPubKey) (ic.PubKey, error) {
+	if key == nil || key.PubKey == nil {
+		// No attached key, it must be extractable from the source ID
+		pubk, err := from.ExtractPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("cannot extract signing key: %s", err)
+		}
+		if pubk == nil {
+			return nil, fmt.Errorf("cannot extract signing key")
+		}
+		return pubk, nil
+	}
+	// Verify that the source ID matches the attached key
+	if !from.MatchesPublicKey(key.PubKey) {
+		return nil, fmt.Errorf("bad signing key; source ID %s doesn't match key", from)
+	}
+	return key.PubKey, nil
+}
+
+// remotePeer returns the remote peer of the libp2p connection a request
+// was received on, if any. An undecodable peer is returned as empty so
+// that it never matches a sender.
+func remotePeer(ctx context.Context) (peer.ID, bool) {
+	p, ok := grpcpeer.FromContext(ctx)
+	if !ok || p.Addr == nil || p.Addr.Network() != gostream.Network {
+		return "", false
+	}
+	id, err := peer.Decode(p.Addr.String())
+	if err != nil {
+		return "", true
+	}
+	return id, true
+}
+
+// logPayload returns the signed bytes of a log in a thread, which are the
+// thread ID and the log without its heads and signature.
+func logPayload(id thread.ID, pblg *pb.Log) ([]byte, error) {
+	c := *pblg
+	c.Heads = nil
+	c.Signature = nil
+	b, err := proto.Marshal(&c)
+	if err != nil {
+		return nil, err
+	}
+	return append(id.Bytes(), b...), nil
+}
+
+// signLog signs a proto log with the log key, if it's known.
+func signLog(id thread.ID, lg thread.LogInfo, pblg *pb.Log) error {
+	if lg.PrivKey == nil {
+		return nil
+	}
+	payload, err := logPayload(id, pblg)
+	if err != nil {
+		return err
+	}
+	pblg.Signature, err = lg.PrivKey.Sign(payload)
+	return err
+}
+
+// verifyLog returns whether or not a proto log is signed with its own key.
+func verifyLog(id thread.ID, pblg *pb.Log) bool {
+	if pblg.Signature == nil || pblg.ID == nil || pblg.PubKey == nil || pblg.PubKey.PubKey == nil {
+		return false
+	}
+	if !pblg.ID.ID.MatchesPublicKey(pblg.PubKey.PubKey) {
+		return false
+	}
+	payload, err := logPayload(id, pblg)
+	if err != nil {
+		return false
+	}
+	ok, err := pblg.PubKey.PubKey.Verify(payload, pblg.Signature)
+	return ok && err == nil
+}
+
+// keyProof returns an HMAC of a key epoch of a thread and the peer that sent
+// its keys, keyed with the follow-key of the previous epoch. It shows the
+// sender knew the keys being replaced.
+func keyProof(fk *sym.Key, id thread.ID, epoch uint64, from peer.ID) []byte {
+	mac := hmac.New(sha256.New, fk.Bytes())
+	mac.Write(id.Bytes())
+	buf := make([]byte, binary.MaxVarintLen64)
+	mac.Write(buf[:binary.PutUvarint(buf, epoch)])
+	mac.Write([]byte(from))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,569 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	blocks "github.com/ipfs/go-block-format"
+	bserv "github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	bs "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	mdag "github.com/ipfs/go-merkledag"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/core/thread"
+	tcrypto "github.com/textileio/go-threads/crypto"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+func init() {
+	cbornode.RegisterCborType(carHeader{})
+	cbornode.RegisterCborType(carManifest{})
+	cbornode.RegisterCborType(carLog{})
+}
+
+// maxCarSectionSize is the maximum size of a block read from a CAR file.
+const maxCarSectionSize = 32 << 20
+
+// carHeader is the header of a CARv1 file.
+type carHeader struct {
+	Roots   []cid.Cid `refmt:"roots"`
+	Version uint64    `refmt:"version"`
+}
+
+// carManifest describes the thread in a CAR file. It is the root block.
+type carManifest struct {
+	Thread   []byte
+	KeyEpoch uint64
+	Logs     []carLog
+	Creator  []byte
+	Pins     []cid.Cid `refmt:",omitempty"`
+}
+
+// carLog describes a log in a CAR file.
+type carLog struct {
+	ID     []byte
+	PubKey []byte
+	Addrs  [][]byte
+	Heads  []cid.Cid
+}
+
+// ExportThread writes all local record, event, header, and body blocks of
+// a thread, and the local blocks of its pinned DAGs, to w as a CARv1 file.
+// The root of the file is a manifest holding the thread's logs with their
+// public keys, addresses, and heads, and the pins. Keys are not exported.
+func (t *service) ExportThread(ctx context.Context, id thread.ID, w io.Writer) error {
+	tsph := t.getThreadSemaphore(id)
+	tsph <- struct{}{}
+	defer func() { <-tsph }()
+
+	info, err := t.store.ThreadInfo(id)
+	if err != nil {
+		return err
+	}
+	if info.FollowKey == nil {
+		return fmt.Errorf("thread not found")
+	}
+	creator, err := t.getCreator(id)
+	if err != nil {
+		return err
+	}
+	pins, err := t.getPins(id)
+	if err != nil {
+		return err
+	}
+	manifest := &carManifest{
+		Thread:   id.Bytes(),
+		KeyEpoch: info.KeyEpoch,
+		Logs:     make([]carLog, len(info.Logs)),
+		Creator:  []byte(creator),
+		Pins:     pins,
+	}
+	for i, lg := range info.Logs {
+		pk, err := crypto.MarshalPublicKey(lg.PubKey)
+		if err != nil {
+			return err
+		}
+		addrs := make([][]byte, len(lg.Addrs))
+		for j, a := range lg.Addrs {
+			addrs[j] = a.Bytes()
+		}
+		manifest.Logs[i] = carLog{
+			ID:     []byte(lg.ID),
+			PubKey: pk,
+			Addrs:  addrs,
+			Heads:  lg.Heads,
+		}
+	}
+	root, err := cbornode.WrapObject(manifest, mh.SHA2_256, -1)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if err = writeCarHeader(bw, root.Cid()); err != nil {
+		return err
+	}
+	if err = writeCarBlock(bw, root); err != nil {
+		return err
+	}
+	for _, lg := range info.Logs {
+		recs, err := t.walkLog(ctx, id, lg.Heads, nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, r := range recs {
+			event, err := cbor.GetEvent(ctx, t, r.BlockID())
+			if err != nil {
+				return err
+			}
+			nodes := []format.Node{r, event}
+			enodes, err := eventNodes(ctx, t.Get, event)
+			if err != nil {
+				return err
+			}
+			nodes = append(nodes, enodes...)
+			for _, n := range nodes {
+				if err = writeCarBlock(bw, n); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	pinned := make(map[cid.Cid]struct{})
+	for _, p := range pins {
+		if err = t.markDAG(ctx, p, pinned); err != nil {
+			return err
+		}
+	}
+	for c := range pinned {
+		n, err := t.Get(ctx, c)
+		if err != nil {
+			return err
+		}
+		if err = writeCarBlock(bw, n); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ImportThread reads a thread exported with ExportThread from r.
+// A follow-key for the exported key epoch is required to verify the records.
+// Records from earlier key epochs also need the keys of their epoch, given
+// with PastKeys. The creator recorded in the file is not trusted: the creator
+// is taken from the Creator option, which access controlled threads require,
+// and must match the file's. Blocks are held in memory until all records verify, and only
+// the blocks linked from the logs are written to the blockstore.
+func (t *service) ImportThread(ctx context.Context, r io.Reader, opts ...core.KeyOption) (info thread.Info, err error) {
+	args := &core.KeyOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if args.FollowKey == nil {
+		return info, fmt.Errorf("a follow-key is required to import a thread")
+	}
+	creator := args.Creator
+	if err = t.checkReadKey(args.ReadKey); err != nil {
+		return
+	}
+	if err = checkAlgorithm(args.Algorithm); err != nil {
+		return
+	}
+
+	br := bufio.NewReader(r)
+	root, err := readCarHeader(br)
+	if err != nil {
+		return
+	}
+
+	// Records are read and verified from the file only
+	mem := bs.NewBlockstore(dssync.MutexWrap(ds.NewMapDatastore()))
+	dag := mdag.NewDAGService(bserv.New(mem, offline.Exchange(mem)))
+	var linked []format.Node
+	var rn format.Node
+	for {
+		n, err := readCarBlock(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return info, err
+		}
+		if n.Cid().Equals(root) {
+			rn = n
+			continue
+		}
+		if err = dag.Add(ctx, n); err != nil {
+			return info, err
+		}
+	}
+	if rn == nil {
+		return info, fmt.Errorf("manifest not found")
+	}
+	manifest := new(carManifest)
+	if err = cbornode.DecodeInto(rn.RawData(), manifest); err != nil {
+		return
+	}
+	id, err := thread.Cast(manifest.Thread)
+	if err != nil {
+		return
+	}
+	fk, err := t.store.FollowKey(id)
+	if err != nil {
+		return
+	}
+	if fk != nil {
+		return info, fmt.Errorf("thread %s already exists", id)
+	}
+	if id.Variant() == thread.AccessControlled && creator == "" {
+		return info, fmt.Errorf("a creator is required to import access controlled thread %s", id)
+	}
+	if creator != "" && len(manifest.Creator) > 0 {
+		mc, err := peer.IDFromBytes(manifest.Creator)
+		if err != nil {
+			return info, err
+		}
+		if mc != creator {
+			return info, fmt.Errorf("thread %s was created by %s, not %s", id, mc, creator)
+		}
+	}
+	fks := map[uint64]*sym.Key{manifest.KeyEpoch: args.FollowKey}
+	for epoch, keys := range args.PastKeys {
+		if epoch < manifest.KeyEpoch && keys.FollowKey != nil {
+			fks[epoch] = keys.FollowKey
+		}
+	}
+	keys := tcrypto.KeyRingFunc(func(epoch uint64) (tcrypto.DecryptionKey, error) {
+		fk, ok := fks[epoch]
+		if !ok {
+			return nil, fmt.Errorf("follow-key for epoch %d not found", epoch)
+		}
+		return fk, nil
+	})
+
+	// Verify all records before adding the thread
+	logs := make([]thread.LogInfo, len(manifest.Logs))
+	var lists []carACL
+	for i, l := range manifest.Logs {
+		lg := thread.LogInfo{Heads: l.Heads}
+		if lg.ID, err = peer.IDFromBytes(l.ID); err != nil {
+			return
+		}
+		if lg.PubKey, err = crypto.UnmarshalPublicKey(l.PubKey); err != nil {
+			return
+		}
+		if !lg.ID.MatchesPublicKey(lg.PubKey) {
+			return info, fmt.Errorf("public key does not match log %s", lg.ID)
+		}
+		for _, a := range l.Addrs {
+			addr, err := ma.NewMultiaddrBytes(a)
+			if err != nil {
+				return info, err
+			}
+			lg.Addrs = append(lg.Addrs, addr)
+		}
+		logs[i] = lg
+
+		recs, err := walkRecords(ctx, dag, lg.Heads, keys)
+		if err != nil {
+			return info, fmt.Errorf("reading log %s: %w", lg.ID, err)
+		}
+		for _, rec := range recs {
+			block, err := rec.GetBlock(ctx, dag)
+			if err != nil {
+				return info, err
+			}
+			if err = rec.Verify(lg.PubKey); err != nil {
+				return info, fmt.Errorf("record %s: %w", rec.Cid(), err)
+			}
+			event, err := cbor.EventFromNode(block)
+			if err != nil {
+				return info, err
+			}
+			enodes, err := eventNodes(ctx, dag.Get, event)
+			if err != nil {
+				return info, err
+			}
+			linked = append(append(linked, rec, event), enodes...)
+			if id.Variant() == thread.AccessControlled && event.IsACL() {
+				efk, err := keys.KeyAt(event.KeyEpoch())
+				if err != nil {
+					return info, err
+				}
+				list, err := cbor.ACLFromEvent(ctx, dag, event, efk)
+				if err != nil {
+					return info, err
+				}
+				lists = append(lists, carACL{lid: lg.ID, list: list})
+			}
+		}
+	}
+
+	// Pinned DAGs are imported as far as they're in the file
+	pinned := make(map[cid.Cid]struct{})
+	for _, p := range manifest.Pins {
+		nodes, err := carDAG(ctx, dag, p, pinned)
+		if err != nil {
+			return info, err
+		}
+		linked = append(linked, nodes...)
+	}
+
+	if err = t.store.AddThread(thread.Info{
+		ID:        id,
+		FollowKey: args.FollowKey,
+		ReadKey:   args.ReadKey,
+		KeyEpoch:  manifest.KeyEpoch,
+	}); err != nil {
+		return
+	}
+	for epoch, keys := range args.PastKeys {
+		if epoch >= manifest.KeyEpoch || keys.FollowKey == nil {
+			continue
+		}
+		if err = t.store.AddKeysAt(id, epoch, keys.FollowKey, keys.ReadKey); err != nil {
+			return
+		}
+	}
+
+	// Blocks can't be collected before the logs that link them are added
+	unprotect := t.protectBlocks()
+	if err = t.AddMany(ctx, linked); err == nil {
+		for _, lg := range logs {
+			if err = t.store.AddLog(id, lg); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil && len(manifest.Pins) > 0 {
+		err = t.putPins(id, manifest.Pins)
+	}
+	unprotect()
+	if err != nil {
+		return
+	}
+	if err = t.putCreator(id, creator); err != nil {
+		return
+	}
+	if err = t.setupAlgorithm(id, args.Algorithm); err != nil {
+		return
+	}
+	if current := trustedACL(creator, lists); current != nil {
+		if err = t.putACL(id, *current); err != nil {
+			return
+		}
+	}
+	if err = t.setupDiscovery(id, args.NoDiscovery); err != nil {
+		return
+	}
+	t.server.subscribe(id)
+	t.puller.add(id)
+	return t.store.ThreadInfo(id)
+}
+
+// carACL is an access control list found in a log of an imported thread.
+type carACL struct {
+	lid  peer.ID
+	list thread.ACL
+}
+
+// trustedACL returns the newest list that can be traced back to the
+// creator: the initial list must come from the creator's log, and each newer
+// list from an admin of the list before it.
+func trustedACL(creator peer.ID, lists []carACL) *thread.ACL {
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].list.Version < lists[j].list.Version
+	})
+	var current *thread.ACL
+	for i, l := range lists {
+		if current == nil {
+			if creator != "" && l.lid == creator {
+				current = &lists[i].list
+			}
+			continue
+		}
+		if l.list.Version > current.Version && current.Allows(thread.Admin, l.lid) {
+			current = &lists[i].list
+		}
+	}
+	return current
+}
+
+// carDAG returns the nodes of the DAG rooted at c that are in dag and not
+// in seen, and adds them to seen.
+func carDAG(ctx context.Context, dag format.DAGService, c cid.Cid, seen map[cid.Cid]struct{}) ([]format.Node, error) {
+	var nodes []format.Node
+	queue := []cid.Cid{c}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[c]; ok {
+			continue
+		}
+		seen[c] = struct{}{}
+		n, err := dag.Get(ctx, c)
+		if errors.Is(err, format.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+		for _, l := range n.Links() {
+			queue = append(queue, l.Cid)
+		}
+	}
+	return nodes, nil
+}
+
+// eventNodes returns the header and body nodes of an event. Chunked bodies
+// include the chunks their body node links to.
+func eventNodes(
+	ctx context.Context,
+	get func(context.Context, cid.Cid) (format.Node, error),
+	event core.Event,
+) ([]format.Node, error) {
+	var nodes []format.Node
+	for _, c := range []cid.Cid{event.HeaderID(), event.BodyID()} {
+		n, err := get(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	for _, l := range nodes[1].Links() {
+		n, err := get(ctx, l.Cid)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// walkRecords returns all records reachable from heads.
+func walkRecords(ctx context.Context, dag format.DAGService, heads []cid.Cid, keys tcrypto.KeyRing) ([]core.Record, error) {
+	var recs []core.Record
+	seen := make(map[cid.Cid]struct{})
+	queue := append([]cid.Cid{}, heads...)
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[c]; ok || !c.Defined() {
+			continue
+		}
+		seen[c] = struct{}{}
+		rec, err := cbor.GetRecord(ctx, dag, c, keys)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+		queue = append(queue, rec.PrevIDs()...)
+	}
+	return recs, nil
+}
+
+// writeCarHeader writes a CARv1 header with a single root.
+func writeCarHeader(w io.Writer, root cid.Cid) error {
+	data, err := cbornode.DumpObject(&carHeader{
+		Roots:   []cid.Cid{root},
+		Version: 1,
+	})
+	if err != nil {
+		return err
+	}
+	return writeCarSection(w, data)
+}
+
+// readCarHeader reads a CARv1 header and returns its single root.
+func readCarHeader(r *bufio.Reader) (cid.Cid, error) {
+	data, err := readCarSection(r)
+	if err != nil {
+		return cid.Undef, err
+	}
+	header := new(carHeader)
+	if err = cbornode.DecodeInto(data, header); err != nil {
+		return cid.Undef, err
+	}
+	if header.Version != 1 {
+		return cid.Undef, fmt.Errorf("unsupported car version %d", header.Version)
+	}
+	if len(header.Roots) != 1 {
+		return cid.Undef, fmt.Errorf("expected one root got %d", len(header.Roots))
+	}
+	return header.Roots[0], nil
+}
+
+// writeCarBlock writes a node's cid and data as a CAR section.
+func writeCarBlock(w io.Writer, n blocks.Block) error {
+	return writeCarSection(w, append(n.Cid().Bytes(), n.RawData()...))
+}
+
+// readCarBlock reads a CAR section and checks its data against its cid.
+func readCarBlock(r *bufio.Reader) (format.Node, error) {
+	data, err := readCarSection(r)
+	if err != nil {
+		return nil, err
+	}
+	n, c, err := cid.CidFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	chk, err := c.Prefix().Sum(data[n:])
+	if err != nil {
+		return nil, err
+	}
+	if !chk.Equals(c) {
+		return nil, fmt.Errorf("block data does not match cid %s", c)
+	}
+	block, err := blocks.NewBlockWithCid(data[n:], c)
+	if err != nil {
+		return nil, err
+	}
+	return cbornode.DecodeBlock(block)
+}
+
+// writeCarSection writes data prefixed with its varint length.
+func writeCarSection(w io.Writer, data []byte) error {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, uint64(len(data)))
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// readCarSection reads varint length prefixed data.
+// It returns io.EOF if there are no more sections.
+func readCarSection(r *bufio.Reader) ([]byte, error) {
+	l, err := binary.ReadUvarint(r)
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("reading section length: %w", err)
+	}
+	if l > maxCarSectionSize {
+		return nil, fmt.Errorf("section of %d bytes is too large", l)
+	}
+	data := make([]byte, l)
+	if _, err = io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("reading section: %w", err)
+	}
+	return data, nil
+}
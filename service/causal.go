@@ -0,0 +1,330 @@
+package service
+
+import (
+	"container/heap"
+	"context"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/core/thread"
+	tcrypto "github.com/textileio/go-threads/crypto"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+// CausalRecords returns an iterator over the local records of a thread in
+// causal order. Replicas with the same records iterate them in the same order.
+func (t *service) CausalRecords(ctx context.Context, id thread.ID) (*core.CausalIterator, error) {
+	info, err := t.store.ThreadInfo(id)
+	if err != nil {
+		return nil, err
+	}
+	var recs []core.ThreadRecord
+	for _, lg := range info.Logs {
+		lrecs, err := t.getRecordsSince(ctx, id, lg, cid.Undef)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range lrecs {
+			recs = append(recs, &Record{Record: r, threadID: id, logID: lg.ID})
+		}
+	}
+	return core.NewCausalIterator(t.sortCausal(ctx, recs)), nil
+}
+
+// seenHeads returns the heads of a thread's logs with their logical clocks.
+// Records don't change, so the clocks of heads are cached until they're
+// replaced by newer heads.
+func (t *service) seenHeads(ctx context.Context, id thread.ID) (map[cid.Cid]uint64, error) {
+	info, err := t.store.ThreadInfo(id)
+	if err != nil {
+		return nil, err
+	}
+	cached := make(map[cid.Cid]uint64)
+	if v, ok := t.headClocks.Load(id); ok {
+		cached = v.(map[cid.Cid]uint64)
+	}
+	seen := make(map[cid.Cid]uint64)
+	for _, lg := range info.Logs {
+		for _, h := range lg.Heads {
+			if !h.Defined() {
+				continue
+			}
+			if clock, ok := cached[h]; ok {
+				seen[h] = clock
+				continue
+			}
+			rec, err := t.GetRecord(ctx, id, h)
+			if err != nil {
+				return nil, err
+			}
+			clock, _, err := t.recordClock(ctx, id, rec)
+			if err != nil {
+				return nil, err
+			}
+			seen[h] = clock
+		}
+	}
+	clocks := make(map[cid.Cid]uint64, len(seen))
+	for h, clock := range seen {
+		if clock > 0 { // A zero clock may be from a key that's not known yet
+			clocks[h] = clock
+		}
+	}
+	t.headClocks.Store(id, clocks)
+	return seen, nil
+}
+
+// recordClock returns the logical clock and dependencies of a record.
+// Records whose header key is not known have a zero clock.
+func (t *service) recordClock(ctx context.Context, id thread.ID, rec core.Record) (uint64, []cid.Cid, error) {
+	event, err := cbor.EventFromRecord(ctx, t, rec)
+	if err != nil {
+		return 0, nil, err
+	}
+	key, err := t.headerKey(id, event)
+	if err != nil || key == nil {
+		return 0, nil, err
+	}
+	header, err := event.GetHeader(ctx, t, key)
+	if err != nil {
+		return 0, nil, err
+	}
+	clock, err := header.Clock()
+	if err != nil {
+		return 0, nil, err
+	}
+	deps, err := header.Deps()
+	if err != nil {
+		return 0, nil, err
+	}
+	return clock, deps, nil
+}
+
+// headerKey returns the key that decrypts an event header, which is the
+// follow-key for access control list events and the read-key otherwise.
+// It's nil if the key is not known.
+func (t *service) headerKey(id thread.ID, event *cbor.Event) (tcrypto.DecryptionKey, error) {
+	var key *sym.Key
+	var err error
+	if event.IsACL() {
+		key, err = t.store.FollowKeyAt(id, event.KeyEpoch())
+	} else {
+		key, err = t.store.ReadKeyAt(id, event.KeyEpoch())
+	}
+	if err != nil || key == nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// causalEntry is a record with what it takes to order it causally.
+type causalEntry struct {
+	rec   core.ThreadRecord
+	clock uint64
+	deps  []cid.Cid
+	held  time.Time
+}
+
+// newCausalEntry returns the entry of a record. Its dependencies are the
+// previous records in its log and the heads its author had seen.
+func (t *service) newCausalEntry(ctx context.Context, rec core.ThreadRecord) *causalEntry {
+	clock, deps, err := t.recordClock(ctx, rec.ThreadID(), rec.Value())
+	if err != nil {
+		log.Warnf("error getting clock of record %s: %s", rec.Value().Cid(), err)
+	}
+	e := &causalEntry{rec: rec, clock: clock}
+	self := rec.Value().Cid()
+	ids := append([]cid.Cid{}, rec.Value().PrevIDs()...)
+	unique := make(map[cid.Cid]struct{})
+	for _, d := range append(ids, deps...) {
+		if _, ok := unique[d]; ok || !d.Defined() || d.Equals(self) {
+			continue
+		}
+		unique[d] = struct{}{}
+		e.deps = append(e.deps, d)
+	}
+	return e
+}
+
+// causalLess orders records that don't depend on one another
+// by clock, then log ID, then record ID.
+func causalLess(a, b *causalEntry) bool {
+	if a.clock != b.clock {
+		return a.clock < b.clock
+	}
+	if a.rec.LogID() != b.rec.LogID() {
+		return a.rec.LogID() < b.rec.LogID()
+	}
+	return a.rec.Value().Cid().KeyString() < b.rec.Value().Cid().KeyString()
+}
+
+// causalQueue is a min-heap of entries ordered by causalLess.
+type causalQueue []*causalEntry
+
+func (q causalQueue) Len() int { return len(q) }
+
+func (q causalQueue) Less(i, j int) bool { return causalLess(q[i], q[j]) }
+
+func (q causalQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *causalQueue) Push(x interface{}) { *q = append(*q, x.(*causalEntry)) }
+
+func (q *causalQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	*q = old[:n-1]
+	return e
+}
+
+// sortCausal returns records ordered so that each comes after the records it
+// depends on. The order only depends on the given records, not on their
+// initial order. Dependencies outside of recs are ignored.
+func (t *service) sortCausal(ctx context.Context, recs []core.ThreadRecord) []core.ThreadRecord {
+	entries := make(map[cid.Cid]*causalEntry, len(recs))
+	for _, r := range recs {
+		entries[r.Value().Cid()] = t.newCausalEntry(ctx, r)
+	}
+	waiting := make(map[cid.Cid]int)
+	dependents := make(map[cid.Cid][]*causalEntry)
+	q := &causalQueue{}
+	for c, e := range entries {
+		for _, d := range e.deps {
+			if _, ok := entries[d]; ok {
+				waiting[c]++
+				dependents[d] = append(dependents[d], e)
+			}
+		}
+		if waiting[c] == 0 {
+			heap.Push(q, e)
+		}
+	}
+
+	sorted := make([]core.ThreadRecord, 0, len(recs))
+	for q.Len() > 0 {
+		e := heap.Pop(q).(*causalEntry)
+		sorted = append(sorted, e.rec)
+		for _, d := range dependents[e.rec.Value().Cid()] {
+			c := d.rec.Value().Cid()
+			if waiting[c]--; waiting[c] == 0 {
+				heap.Push(q, d)
+			}
+		}
+	}
+	return sorted
+}
+
+// deliverCausal returns a channel with the records from in, each held back
+// until the records it depends on are available locally and were delivered.
+// Only causal order is kept. Records that are ready are delivered in arrival
+// order, and held records that become ready at once are ordered as by
+// sortCausal.
+// Missing dependencies trigger a pull of the thread. Records held for longer
+// than MaxCausalWait, and the first record once more than MaxCausalPending
+// are held, are delivered anyway.
+func (t *service) deliverCausal(ctx context.Context, in <-chan core.ThreadRecord) <-chan core.ThreadRecord {
+	out := make(chan core.ThreadRecord)
+	wait := MaxCausalWait
+	go func() {
+		defer close(out)
+		defer func() {
+			// Don't block the subscription while it's closing
+			for range in {
+			}
+		}()
+
+		var held []*causalEntry
+		holding := make(map[cid.Cid]struct{})
+		ready := func(e *causalEntry) bool {
+			for _, d := range e.deps {
+				if _, ok := holding[d]; ok {
+					return false
+				}
+				if has, err := t.bstore.Has(d); err != nil || !has {
+					return false
+				}
+			}
+			return true
+		}
+		send := func(e *causalEntry) bool {
+			select {
+			case out <- e.rec:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		release := func(i int) bool {
+			e := held[i]
+			held = append(held[:i], held[i+1:]...)
+			delete(holding, e.rec.Value().Cid())
+			return send(e)
+		}
+		// flush delivers held records that became ready
+		flush := func() bool {
+			for {
+				next := -1
+				for i, e := range held {
+					if ready(e) && (next < 0 || causalLess(e, held[next])) {
+						next = i
+					}
+				}
+				if next < 0 {
+					return true
+				}
+				if !release(next) {
+					return false
+				}
+			}
+		}
+		// expire delivers records that were held for too long, oldest first
+		expire := func() bool {
+			for len(held) > 0 && time.Since(held[0].held) >= wait {
+				log.Warnf("delivering record %s with missing dependencies", held[0].rec.Value().Cid())
+				if !release(0) {
+					return false
+				}
+			}
+			return true
+		}
+
+		tick := time.NewTicker(wait / 4)
+		defer tick.Stop()
+		for {
+			select {
+			case rec, ok := <-in:
+				if !ok {
+					return
+				}
+				e := t.newCausalEntry(ctx, rec)
+				if !ready(e) {
+					log.Debugf("holding record %s until its dependencies arrive", rec.Value().Cid())
+					e.held = time.Now()
+					held = append(held, e)
+					holding[rec.Value().Cid()] = struct{}{}
+					t.puller.hint(rec.ThreadID())
+					if len(held) > MaxCausalPending {
+						log.Warnf("delivering record %s with missing dependencies", held[0].rec.Value().Cid())
+						if !release(0) {
+							return
+						}
+					}
+				} else if !send(e) {
+					return
+				}
+			case <-tick.C:
+				if !expire() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			if !flush() {
+				return
+			}
+		}
+	}()
+	return out
+}
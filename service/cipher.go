@@ -0,0 +1,45 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+// algorithmKey is the thread metadata key of the cipher records are
+// encrypted with.
+const algorithmKey = "algorithm"
+
+// checkAlgorithm returns an error if alg is set but unknown.
+func checkAlgorithm(alg sym.Algorithm) error {
+	if alg != 0 && !alg.Valid() {
+		return fmt.Errorf("unknown cipher %s", alg)
+	}
+	return nil
+}
+
+// setupAlgorithm stores the cipher of a new thread. Threads without one use
+// the service's cipher.
+func (t *service) setupAlgorithm(id thread.ID, alg sym.Algorithm) error {
+	if alg == 0 {
+		return nil
+	}
+	return t.store.PutInt64(id, algorithmKey, int64(alg))
+}
+
+// encryptionKey returns k set to encrypt with the cipher of a thread.
+func (t *service) encryptionKey(id thread.ID, k *sym.Key) (*sym.Key, error) {
+	alg := t.algorithm
+	stored, err := t.store.GetInt64(id, algorithmKey)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		alg = sym.Algorithm(*stored)
+	}
+	if alg == 0 {
+		return k, nil
+	}
+	return k.WithAlgorithm(alg), nil
+}
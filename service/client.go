@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"sync"
 	"time"
@@ -16,7 +17,9 @@ import (
 	core "github.com/textileio/go-threads/core/service"
 	"github.com/textileio/go-threads/core/thread"
 	sym "github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/metrics"
 	pb "github.com/textileio/go-threads/service/pb"
+	"github.com/textileio/go-threads/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 )
@@ -24,25 +27,33 @@ import (
 const (
 	// reqTimeout is the duration to wait for a request to complete.
 	reqTimeout = time.Second * 10
+
+	// maxStreamRetries is the number of times an interrupted stream is resumed.
+	maxStreamRetries = 3
 )
 
 // getLogs in a thread.
-func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) ([]thread.LogInfo, error) {
+// The ID of the creator's log is returned if the peer knows it. The
+// replicators the peer knows of are remembered.
+func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) ([]thread.LogInfo, peer.ID, error) {
 	fk, err := s.threads.store.FollowKey(id)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if fk == nil {
-		return nil, fmt.Errorf("a follow-key is required to request logs")
+		return nil, "", fmt.Errorf("a follow-key is required to request logs")
+	}
+	if err = s.threads.checkPeer(id, pid); err != nil {
+		return nil, "", err
 	}
 
 	req := &pb.GetLogsRequest{
-		Header: &pb.GetLogsRequest_Header{
-			From: &pb.ProtoPeerID{ID: s.threads.host.ID()},
-		},
 		ThreadID:  &pb.ProtoThreadID{ID: id},
 		FollowKey: &pb.ProtoKey{Key: fk},
 	}
+	if err = s.signRequest(req); err != nil {
+		return nil, "", err
+	}
 
 	log.Debugf("getting %s logs from %s...", id.String(), pid.String())
 
@@ -50,14 +61,14 @@ func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) ([]thre
 	defer cancel()
 	conn, err := s.dial(cctx, pid, grpc.WithInsecure())
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	// @todo: Retain connections.
 	client := pb.NewServiceClient(conn)
 	reply, err := client.GetLogs(cctx, req)
 	if err != nil {
 		log.Warnf("get logs from %s failed: %s", pid.String(), err)
-		return nil, err
+		return nil, "", err
 	}
 
 	log.Debugf("received %d logs from %s", len(reply.Logs), pid.String())
@@ -67,23 +78,94 @@ func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) ([]thre
 		lgs[i] = logFromProto(l)
 	}
 
-	return lgs, nil
+	if err = s.threads.putSharedReplicators(id, reply.Replicators); err != nil {
+		return nil, "", err
+	}
+
+	var creator peer.ID
+	if reply.Creator != nil {
+		creator = reply.Creator.ID
+	}
+	return lgs, creator, nil
 }
 
 // pushLog to a peer.
-func (s *server) pushLog(ctx context.Context, id thread.ID, lg thread.LogInfo, pid peer.ID, fk *sym.Key, rk *sym.Key) error {
+// Keys are sent along with the key epoch they belong to.
+func (s *server) pushLog(
+	ctx context.Context,
+	id thread.ID,
+	lg thread.LogInfo,
+	pid peer.ID,
+	fk *sym.Key,
+	rk *sym.Key,
+	epoch uint64,
+) (err error) {
+	defer func() {
+		metrics.Pushes.WithLabelValues("log", metrics.Result(err)).Inc()
+	}()
+	if err = s.threads.checkPeer(id, pid); err != nil {
+		return err
+	}
 	lreq := &pb.PushLogRequest{
-		Header: &pb.PushLogRequest_Header{
-			From: &pb.ProtoPeerID{ID: s.threads.host.ID()},
-		},
 		ThreadID: &pb.ProtoThreadID{ID: id},
 		Log:      logToProto(lg),
+		KeyEpoch: epoch,
+	}
+	if err = signLog(id, lg, lreq.Log); err != nil {
+		return err
+	}
+	if lreq.Replicators, err = s.threads.sharedReplicators(id); err != nil {
+		return err
 	}
 	if fk != nil {
-		lreq.FollowKey = &pb.ProtoKey{Key: fk}
+		creator, err := s.threads.getCreator(id)
+		if err != nil {
+			return err
+		}
+		if creator != "" {
+			lreq.Creator = &pb.ProtoPeerID{ID: creator}
+		}
+		removed, err := s.threads.getRemoved(id)
+		if err != nil {
+			return err
+		}
+		for lid := range removed {
+			lreq.Removed = append(lreq.Removed, pb.ProtoPeerID{ID: lid})
+		}
+		// Prove knowledge of each earlier follow-key, so that the peer can
+		// rotate from whichever epoch it holds
+		for e := uint64(0); e < epoch; e++ {
+			prev, err := s.threads.store.FollowKeyAt(id, e)
+			if err != nil {
+				return err
+			}
+			var proof []byte
+			if prev != nil {
+				proof = keyProof(prev, id, epoch, s.threads.host.ID())
+			}
+			lreq.KeyProofs = append(lreq.KeyProofs, proof)
+		}
+		if epoch > 0 {
+			lreq.KeyProof = lreq.KeyProofs[epoch-1]
+		}
+
+		// Seal keys to the recipient. Peers without an Ed25519 key can
+		// only receive them in plaintext, if that's allowed.
+		sealed, err := s.sealKeys(pid, fk, rk, epoch)
+		if err == nil {
+			lreq.SealedKeys = sealed
+		} else if s.threads.plaintextKeys {
+			log.Warnf("sending plaintext keys to %s: %s", pid.String(), err)
+			lreq.FollowKey = &pb.ProtoKey{Key: fk}
+			if rk != nil {
+				lreq.ReadKey = &pb.ProtoKey{Key: rk}
+			}
+		} else {
+			return fmt.Errorf("sealing keys to %s: %w", pid.String(), err)
+		}
 	}
-	if rk != nil {
-		lreq.ReadKey = &pb.ProtoKey{Key: rk}
+	if err := s.signRequest(lreq); err != nil {
+		return err
 	}
 
 	log.Debugf("pushing log %s to %s...", lg.ID.String(), pid.String())
@@ -102,165 +184,197 @@ func (s *server) pushLog(ctx context.Context, id thread.ID, lg thread.LogInfo, p
 	return err
 }
 
-// records maintains an ordered list of records from multiple sources.
-type records struct {
-	sync.RWMutex
-	m map[peer.ID]map[cid.Cid]core.Record
-	s map[peer.ID][]core.Record
-}
-
-// newRecords creates an instance of records.
-func newRecords() *records {
-	return &records{
-		m: make(map[peer.ID]map[cid.Cid]core.Record),
-		s: make(map[peer.ID][]core.Record),
-	}
-}
-
-// List all records.
-func (r *records) List() map[peer.ID][]core.Record {
-	r.RLock()
-	defer r.RUnlock()
-	return r.s
-}
-
-// Store a record.
-func (r *records) Store(p peer.ID, key cid.Cid, value core.Record) {
-	r.Lock()
-	defer r.Unlock()
-	if _, ok := r.m[p]; !ok {
-		r.m[p] = make(map[cid.Cid]core.Record)
-		r.s[p] = make([]core.Record, 0)
-	}
-	if _, ok := r.m[p][key]; ok {
-		return
-	}
-	r.m[p][key] = value
-
-	// Sanity check
-	if len(r.s[p]) > 0 && r.s[p][len(r.s[p])-1].Cid() != value.PrevID() {
-		panic("there is a gap in records list")
+// sealKeys encrypts thread keys to a peer's public key.
+func (s *server) sealKeys(pid peer.ID, fk, rk *sym.Key, epoch uint64) ([]byte, error) {
+	pk, err := s.threads.peerPubKey(pid)
+	if err != nil {
+		return nil, err
 	}
-
-	r.s[p] = append(r.s[p], value)
+	return cbor.SealKeys(pk, fk, rk, epoch)
 }
 
-// getRecords from log addresses.
-func (s *server) getRecords(
+// streamRecords from log addresses. Records are passed to handle in chunks,
+// oldest first per log, as they arrive. Handle is not called concurrently.
+// Offsets are advanced as chunks are handled, and an interrupted stream
+// resumes from them. It returns whether or not any address could be
+// streamed from.
+func (s *server) streamRecords(
 	ctx context.Context,
 	id thread.ID,
 	lid peer.ID,
 	offsets map[peer.ID]cid.Cid,
-	limit int,
-) (map[peer.ID][]core.Record, error) {
+	handle func(lid peer.ID, recs []core.Record) error,
+) (reached bool, err error) {
 	fk, err := s.threads.store.FollowKey(id)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 	if fk == nil {
-		return nil, fmt.Errorf("a follow-key is required to request records")
+		return false, fmt.Errorf("a follow-key is required to request records")
+	}
+	lg, err := s.threads.store.LogInfo(id, lid)
+	if err != nil {
+		return false, err
+	}
+	if lg.PubKey == nil {
+		return false, fmt.Errorf("log not found")
+	}
+
+	// Stream from each address
+	var lock sync.Mutex
+	wg := sync.WaitGroup{}
+	for _, pid := range addrPeers(lg.Addrs) {
+		if pid.String() == s.threads.host.ID().String() {
+			continue
+		}
+		if err := s.threads.checkPeer(id, pid); err != nil {
+			log.Debugf("not streaming records from %s: %s", pid, err)
+			continue
+		}
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			cursors := make(map[peer.ID]cid.Cid, len(offsets))
+			for l, offset := range offsets {
+				cursors[l] = offset
+			}
+			for i := 0; i <= maxStreamRetries; i++ {
+				progress, err := s.streamRecordsFrom(ctx, id, fk, pid, cursors, func(lid peer.ID, recs []core.Record) error {
+					lock.Lock()
+					defer lock.Unlock()
+					return handle(lid, recs)
+				})
+				if err == nil || progress {
+					lock.Lock()
+					reached = true
+					lock.Unlock()
+				}
+				if err == nil {
+					return
+				}
+				log.Warnf("stream records from %s failed: %s", pid, err)
+				if !progress {
+					return
+				}
+			}
+		}(pid)
 	}
+	wg.Wait()
+	return reached, nil
+}
 
-	pblgs := make([]*pb.GetRecordsRequest_LogEntry, 0, len(offsets))
-	for lid, offset := range offsets {
-		pblgs = append(pblgs, &pb.GetRecordsRequest_LogEntry{
+// streamRecordsFrom streams records from a peer, starting at cursors.
+// Cursors are advanced as chunks are handled. It returns whether or
+// not any chunk was handled.
+func (s *server) streamRecordsFrom(
+	ctx context.Context,
+	id thread.ID,
+	fk *sym.Key,
+	pid peer.ID,
+	cursors map[peer.ID]cid.Cid,
+	handle func(lid peer.ID, recs []core.Record) error,
+) (progress bool, err error) {
+	pblgs := make([]*pb.StreamRecordsRequest_LogEntry, 0, len(cursors))
+	for lid, offset := range cursors {
+		pblgs = append(pblgs, &pb.StreamRecordsRequest_LogEntry{
 			LogID:  &pb.ProtoPeerID{ID: lid},
 			Offset: &pb.ProtoCid{Cid: offset},
-			Limit:  int32(limit),
 		})
 	}
-
-	req := &pb.GetRecordsRequest{
-		Header: &pb.GetRecordsRequest_Header{
-			From: &pb.ProtoPeerID{ID: s.threads.host.ID()},
-		},
+	req := &pb.StreamRecordsRequest{
 		ThreadID:  &pb.ProtoThreadID{ID: id},
 		FollowKey: &pb.ProtoKey{Key: fk},
 		Logs:      pblgs,
+		ChunkSize: int32(MaxStreamChunkSize),
+	}
+	if err = s.signRequest(req); err != nil {
+		return false, err
 	}
 
-	lg, err := s.threads.store.LogInfo(id, lid)
+	log.Debugf("streaming records from %s...", pid)
+
+	// The stream is canceled if a chunk doesn't arrive in time
+	sctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	timer := time.AfterFunc(reqTimeout, cancel)
+	defer timer.Stop()
+
+	conn, err := s.dial(sctx, pid, grpc.WithInsecure())
 	if err != nil {
-		return nil, err
+		return false, fmt.Errorf("dial %s failed: %w", pid, err)
 	}
-	if lg.PubKey == nil {
-		return nil, fmt.Errorf("log not found")
+	client := pb.NewServiceClient(conn)
+	stream, err := client.StreamRecords(sctx, req)
+	if err != nil {
+		return false, err
 	}
+	received := make(map[cid.Cid]struct{})
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return progress, nil
+		}
+		if err != nil {
+			return progress, err
+		}
+		timer.Reset(reqTimeout)
 
-	// Pull from each address
-	recs := newRecords()
-	wg := sync.WaitGroup{}
-	for _, addr := range lg.Addrs {
-		wg.Add(1)
-		go func(addr ma.Multiaddr) {
-			defer wg.Done()
-			p, err := addr.ValueForProtocol(ma.P_P2P)
-			if err != nil {
-				log.Error(err)
-				return
-			}
-			pid, err := peer.Decode(p)
-			if err != nil {
-				log.Error(err)
-				return
+		lid := reply.LogID.ID
+		log.Debugf("received %d records in log %s from %s", len(reply.Records), lid.String(), pid)
+
+		lg, err := s.threads.store.LogInfo(id, lid)
+		if err != nil {
+			return progress, err
+		}
+		if lg.PubKey == nil {
+			if reply.Log == nil {
+				continue
 			}
-			if pid.String() == s.threads.host.ID().String() {
-				return
+			lg = logFromProto(reply.Log)
+			if lg.ID != lid || lg.PubKey == nil || !lg.ID.MatchesPublicKey(lg.PubKey) {
+				return progress, fmt.Errorf("invalid log %s", lid)
 			}
-
-			log.Debugf("getting records from %s...", p)
-
-			cctx, cancel := context.WithTimeout(ctx, reqTimeout)
-			defer cancel()
-			conn, err := s.dial(cctx, pid, grpc.WithInsecure())
-			if err != nil {
-				log.Errorf("dial %s failed: %s", p, err)
-				return
+			if !verifyLog(id, reply.Log) {
+				lg.Addrs = nil // Only the log's owner sets its addresses
 			}
-			client := pb.NewServiceClient(conn)
-			reply, err := client.GetRecords(cctx, req)
-			if err != nil {
-				log.Warnf("get records from %s failed: %s", p, err)
-				return
+			lg.Heads = []cid.Cid{}
+			if err = s.threads.store.AddLog(id, lg); err != nil {
+				return progress, err
 			}
-			for _, l := range reply.Logs {
-				log.Debugf("received %d records in log %s from %s", len(l.Records), l.LogID.ID.String(), p)
+		}
 
-				lg, err := s.threads.store.LogInfo(id, l.LogID.ID)
-				if err != nil {
-					log.Error(err)
-					return
-				}
-				if lg.PubKey == nil {
-					if l.Log != nil {
-						lg = logFromProto(l.Log)
-						lg.Heads = []cid.Cid{}
-						if err = s.threads.store.AddLog(id, lg); err != nil {
-							log.Error(err)
-							return
-						}
-					} else {
-						continue
-					}
+		recs := make([]core.Record, len(reply.Records))
+		for i, r := range reply.Records {
+			if recs[i], err = cbor.RecordFromProto(r, s.threads.followKeys(id)); err != nil {
+				return progress, err
+			}
+			// Sanity check: records arrive after the records they link to
+			for _, p := range recs[i].PrevIDs() {
+				if _, ok := received[p]; ok {
+					continue
 				}
-
-				for _, r := range l.Records {
-					rec, err := cbor.RecordFromProto(r, fk)
-					if err != nil {
-						log.Error(err)
-						return
-					}
-					recs.Store(lg.ID, rec.Cid(), rec)
+				if ok, err := s.threads.bstore.Has(p); err != nil {
+					return progress, err
+				} else if !ok {
+					return progress, fmt.Errorf("there is a gap in records of log %s", lid)
 				}
 			}
-		}(addr)
+			received[recs[i].Cid()] = struct{}{}
+		}
+		if len(recs) > 0 {
+			if err = handle(lid, recs); err != nil {
+				return progress, err
+			}
+		}
+		if reply.Cursor != nil && reply.Cursor.Cid.Defined() {
+			cursors[lid] = reply.Cursor.Cid
+		}
+		progress = true
 	}
-	wg.Wait()
-
-	return recs.List(), nil
 }
 
 // pushRecord to log addresses and thread topic.
+// Peers that can't be reached are added to the thread's outbox for a retry.
 func (s *server) pushRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core.Record) error {
 	// Collect known writers
 	addrs := make([]ma.Multiaddr, 0)
@@ -272,92 +386,31 @@ func (s *server) pushRecord(ctx context.Context, id thread.ID, lid peer.ID, rec
 		addrs = append(addrs, l.Addrs...)
 	}
 
-	// Serialize and sign the record for transport
-	pbrec, err := cbor.RecordToProto(ctx, s.threads, rec)
-	if err != nil {
-		return err
-	}
-	payload, err := pbrec.Marshal()
-	if err != nil {
-		return err
-	}
-	sk := s.threads.getPrivKey()
-	if sk == nil {
-		return fmt.Errorf("private key for host not found")
-	}
-	sig, err := sk.Sign(payload)
+	req, err := s.newPushRecordRequest(ctx, id, lid, rec)
 	if err != nil {
 		return err
 	}
 
-	req := &pb.PushRecordRequest{
-		Header: &pb.PushRecordRequest_Header{
-			From:      &pb.ProtoPeerID{ID: s.threads.host.ID()},
-			Signature: sig,
-			Key:       &pb.ProtoPubKey{PubKey: sk.GetPublic()},
-		},
-		ThreadID: &pb.ProtoThreadID{ID: id},
-		LogID:    &pb.ProtoPeerID{ID: lid},
-		Record:   pbrec,
-	}
-
-	// Push to each address
+	// Push to each peer
 	wg := sync.WaitGroup{}
-	for _, addr := range addrs {
+	for _, pid := range addrPeers(addrs) {
+		if pid.String() == s.threads.host.ID().String() {
+			continue
+		}
+		if err := s.threads.checkPeer(id, pid); err != nil {
+			log.Debugf("not pushing record to %s: %s", pid, err)
+			continue
+		}
 		wg.Add(1)
-		go func(addr ma.Multiaddr) {
+		go func(pid peer.ID) {
 			defer wg.Done()
-			p, err := addr.ValueForProtocol(ma.P_P2P)
-			if err != nil {
-				log.Error(err)
-				return
-			}
-			pid, err := peer.Decode(p)
-			if err != nil {
-				log.Error(err)
-				return
-			}
-			if pid.String() == s.threads.host.ID().String() {
-				return
-			}
-
-			log.Debugf("pushing record to %s...", p)
-
-			cctx, cancel := context.WithTimeout(ctx, reqTimeout)
-			defer cancel()
-			conn, err := s.dial(cctx, pid, grpc.WithInsecure())
-			if err != nil {
-				log.Errorf("dial %s failed: %s", p, err)
-				return
-			}
-			client := pb.NewServiceClient(conn)
-			if _, err = client.PushRecord(cctx, req); err != nil {
-				if status.Convert(err).Code() == codes.NotFound {
-					log.Debugf("pushing log %s to %s...", lid.String(), p)
-
-					// Send the missing log
-					l, err := s.threads.store.LogInfo(id, lid)
-					if err != nil {
-						log.Error(err)
-						return
-					}
-					lreq := &pb.PushLogRequest{
-						Header: &pb.PushLogRequest_Header{
-							From: &pb.ProtoPeerID{ID: s.threads.host.ID()},
-						},
-						ThreadID: &pb.ProtoThreadID{ID: id},
-						Log:      logToProto(l),
-					}
-					if _, err = client.PushLog(cctx, lreq); err != nil {
-						log.Warnf("push log to %s failed: %s", p, err)
-						return
-					}
-					return
+			if err := s.pushRecordToPeer(ctx, pid, req); err != nil {
+				log.Warnf("push record to %s failed: %s", pid, err)
+				if err := s.threads.enqueuePush(id, lid, rec.Cid(), pid, err); err != nil {
+					log.Error(err)
 				}
-				log.Warnf("push record to %s failed: %s", p, err)
-				return
 			}
-		}(addr)
+		}(pid)
 	}
 
 	// Finally, publish to the thread's topic
@@ -369,13 +422,87 @@ func (s *server) pushRecord(ctx context.Context, id thread.ID, lid peer.ID, rec
 	return nil
 }
 
+// newPushRecordRequest serializes and signs a record for transport.
+func (s *server) newPushRecordRequest(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	rec core.Record,
+) (*pb.PushRecordRequest, error) {
+	pbrec, err := cbor.RecordToProto(ctx, s.threads, rec)
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.PushRecordRequest{
+		ThreadID: &pb.ProtoThreadID{ID: id},
+		LogID:    &pb.ProtoPeerID{ID: lid},
+		Record:   pbrec,
+	}
+	if err = s.signRequest(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// pushRecordToPeer sends a push record request to a peer.
+// If the peer doesn't know the record's log, the log is pushed and the
+// record is sent again.
+func (s *server) pushRecordToPeer(ctx context.Context, pid peer.ID, req *pb.PushRecordRequest) (err error) {
+	defer func() {
+		metrics.Pushes.WithLabelValues("record", metrics.Result(err)).Inc()
+	}()
+	log.Debugf("pushing record to %s...", pid)
+
+	cctx, cancel := context.WithTimeout(ctx, reqTimeout)
+	defer cancel()
+	conn, err := s.dial(cctx, pid, grpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("dial %s failed: %w", pid, err)
+	}
+	client := pb.NewServiceClient(conn)
+	_, err = client.PushRecord(cctx, req)
+	if err == nil || status.Convert(err).Code() != codes.NotFound {
+		return err
+	}
+
+	id := req.ThreadID.ID
+	lid := req.LogID.ID
+	log.Debugf("pushing log %s to %s...", lid, pid)
+
+	// Send the missing log
+	l, err := s.threads.store.LogInfo(id, lid)
+	if err != nil {
+		return err
+	}
+	lreq := &pb.PushLogRequest{
+		ThreadID: &pb.ProtoThreadID{ID: id},
+		Log:      logToProto(l),
+	}
+	if err = signLog(id, l, lreq.Log); err != nil {
+		return err
+	}
+	if err = s.signRequest(lreq); err != nil {
+		return err
+	}
+	if _, err = client.PushLog(cctx, lreq); err != nil {
+		return fmt.Errorf("push log failed: %w", err)
+	}
+	_, err = client.PushRecord(cctx, req)
+	return err
+}
+
 // dial attempts to open a GRPC connection over libp2p to a peer.
+// Calls on the connection are traced and carry their span context.
 func (s *server) dial(
 	ctx context.Context,
 	peerID peer.ID,
 	dialOpts ...grpc.DialOption,
 ) (*grpc.ClientConn, error) {
-	opts := append([]grpc.DialOption{s.getDialOption()}, dialOpts...)
+	opts := append([]grpc.DialOption{
+		s.getDialOption(),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor(tracer)),
+		grpc.WithStreamInterceptor(tracing.StreamClientInterceptor(tracer)),
+	}, dialOpts...)
 	return grpc.DialContext(ctx, peerID.Pretty(), opts...)
 }
 
@@ -396,5 +523,11 @@ func (s *server) publish(id thread.ID, req *pb.PushRecordRequest) error {
 	if err != nil {
 		return err
 	}
-	return s.pubsub.Publish(id.String(), data)
+	s.Lock()
+	topic, err := s.joinTopic(id)
+	s.Unlock()
+	if err != nil {
+		return err
+	}
+	return topic.Publish(s.threads.ctx, data)
 }
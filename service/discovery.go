@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	swarm "github.com/libp2p/go-libp2p-swarm"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// noDiscoveryKey is the thread metadata key of the discovery opt-out.
+const noDiscoveryKey = "nodiscovery"
+
+var (
+	// ProvideInterval is the interval between announcements of the threads
+	// the host follows. Provider records expire, so they must be renewed.
+	ProvideInterval = time.Hour * 12
+
+	// MaxDiscoveredPeers is the maximum number of providers looked up for
+	// a thread whose known addresses all failed.
+	MaxDiscoveredPeers = 10
+
+	// discoveryTimeout is the duration to wait for providers of a thread.
+	discoveryTimeout = time.Minute
+)
+
+// SetDiscovery turns announcing that the host follows a thread on or off.
+// Announcements that were already made expire on their own.
+func (t *service) SetDiscovery(ctx context.Context, id thread.ID, enabled bool) error {
+	if _, err := t.store.ThreadInfo(id); err != nil {
+		return err
+	}
+	var off int64
+	if !enabled {
+		off = 1
+	}
+	if err := t.store.PutInt64(id, noDiscoveryKey, off); err != nil {
+		return err
+	}
+	if enabled {
+		go t.provide(t.ctx, id)
+	}
+	return nil
+}
+
+// setupDiscovery stores the discovery opt-out of a new thread, or announces it.
+func (t *service) setupDiscovery(id thread.ID, noDiscovery bool) error {
+	if noDiscovery {
+		return t.store.PutInt64(id, noDiscoveryKey, 1)
+	}
+	go t.provide(t.ctx, id)
+	return nil
+}
+
+// discoverable returns whether or not the host announces a thread.
+func (t *service) discoverable(id thread.ID) (bool, error) {
+	if t.routing == nil {
+		return false, nil
+	}
+	off, err := t.store.GetInt64(id, noDiscoveryKey)
+	if err != nil {
+		return false, err
+	}
+	return off == nil || *off == 0, nil
+}
+
+// provide announces that the host follows a thread, unless it opted out.
+func (t *service) provide(ctx context.Context, id thread.ID) {
+	ok, err := t.discoverable(id)
+	if err != nil {
+		log.Errorf("error checking discovery of %s: %s", id, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	if err = t.routing.Provide(ctx, threadKey(id), true); err != nil {
+		log.Warnf("error announcing thread %s: %s", id, err)
+		return
+	}
+	log.Debugf("announced thread %s", id)
+}
+
+// startProviding announces the threads the host follows every ProvideInterval.
+func (t *service) startProviding() {
+	tick := time.NewTicker(ProvideInterval)
+	defer tick.Stop()
+	for {
+		ts, err := t.store.Threads()
+		if err != nil {
+			log.Errorf("error listing threads to announce: %s", err)
+		}
+		for _, id := range ts {
+			t.provide(t.ctx, id)
+		}
+		select {
+		case <-tick.C:
+		case <-t.ctx.Done():
+			return
+		}
+	}
+}
+
+// discover looks up providers of a thread and adds their addresses to the
+// peerstore, so the thread's peers can be dialed again. Provider records are
+// not authenticated, so only providers that already appear in the addresses
+// of the thread's logs are used. Others are never sent the follow-key.
+// It returns the number of peers whose addresses were added.
+func (t *service) discover(ctx context.Context, id thread.ID) (int, error) {
+	if t.routing == nil {
+		return 0, nil
+	}
+	info, err := t.store.ThreadInfo(id)
+	if err != nil {
+		return 0, err
+	}
+	members := make(map[peer.ID]struct{})
+	for _, pid := range t.knownPeers(info.Logs) {
+		members[pid] = struct{}{}
+	}
+
+	log.Debugf("looking up providers of thread %s...", id)
+
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+	var count int
+	for p := range t.routing.FindProvidersAsync(ctx, threadKey(id), MaxDiscoveredPeers) {
+		if _, ok := members[p.ID]; !ok {
+			log.Debugf("ignoring provider %s of thread %s: not in log addresses", p.ID, id)
+			continue
+		}
+		if err = t.checkPeer(id, p.ID); err != nil {
+			log.Debugf("skipping provider %s of thread %s: %s", p.ID, id, err)
+			continue
+		}
+		t.host.Peerstore().AddAddrs(p.ID, p.Addrs, pstore.ProviderAddrTTL)
+		if sw, ok := t.host.Network().(*swarm.Swarm); ok {
+			sw.Backoff().Clear(p.ID) // The failed pull may have backed off the peer
+		}
+		count++
+	}
+
+	log.Debugf("discovered %d peers of thread %s", count, id)
+
+	return count, nil
+}
+
+// threadKey returns the content routing key of a thread, which is a hash of
+// its ID so that the ID itself isn't published.
+func threadKey(id thread.ID) cid.Cid {
+	sum, _ := mh.Sum(id.Bytes(), mh.SHA2_256, -1) // This will never return an error
+	return cid.NewCidV1(cid.Raw, sum)
+}
+
+// knownPeers returns the peers other than the host in the addresses of logs.
+func (t *service) knownPeers(lgs []thread.LogInfo) []peer.ID {
+	var pids []peer.ID
+	for _, lg := range lgs {
+		for _, pid := range addrPeers(lg.Addrs) {
+			if pid != t.host.ID() {
+				pids = append(pids, pid)
+			}
+		}
+	}
+	return pids
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// pinsKey is the thread metadata key of the blocks pinned to a thread.
+const pinsKey = "pins"
+
+// GC removes the blocks that are not reachable from the heads of any log,
+// or from a pinned block. Writers are only blocked while the heads, pins and
+// blocks to collect from are listed. Blocks written while the collection runs
+// are kept. Blocks fetched for records that are still being validated may be
+// removed, and are written again once their record is saved.
+// It returns the number of removed blocks.
+func (t *service) GC(ctx context.Context) (int, error) {
+	t.gcRunLock.Lock()
+	defer t.gcRunLock.Unlock()
+
+	start := time.Now()
+	roots, candidates, err := t.snapshot(ctx)
+	defer t.stopTracking()
+	if err != nil {
+		return 0, fmt.Errorf("listing blocks: %w", err)
+	}
+	marked, err := t.mark(ctx, roots)
+	if err != nil {
+		return 0, fmt.Errorf("marking blocks: %w", err)
+	}
+	removed, err := t.sweep(ctx, candidates, marked)
+	if err != nil {
+		return removed, fmt.Errorf("sweeping blocks: %w", err)
+	}
+	log.Infof("gc removed %d blocks and kept %d in %s", removed, len(candidates)-removed, time.Since(start))
+	return removed, nil
+}
+
+// Add adds a node to the DAG. A running garbage collection won't remove it.
+func (t *service) Add(ctx context.Context, n format.Node) error {
+	t.trackWrites(n.Cid())
+	return t.DAGService.Add(ctx, n)
+}
+
+// AddMany adds nodes to the DAG. A running garbage collection won't remove them.
+func (t *service) AddMany(ctx context.Context, ns []format.Node) error {
+	cids := make([]cid.Cid, len(ns))
+	for i, n := range ns {
+		cids[i] = n.Cid()
+	}
+	t.trackWrites(cids...)
+	return t.DAGService.AddMany(ctx, ns)
+}
+
+// Pin keeps the DAG rooted at c from garbage collection while the thread exists.
+func (t *service) Pin(ctx context.Context, id thread.ID, c cid.Cid) error {
+	pins, err := t.getPins(id)
+	if err != nil {
+		return err
+	}
+	for _, p := range pins {
+		if p.Equals(c) {
+			return nil
+		}
+	}
+	if err = t.putPins(id, append(pins, c)); err != nil {
+		return err
+	}
+	// A running collection listed the pins before this one
+	return t.trackDAG(ctx, c)
+}
+
+// Unpin allows the DAG rooted at c to be garbage collected,
+// unless it's reachable from elsewhere.
+func (t *service) Unpin(_ context.Context, id thread.ID, c cid.Cid) error {
+	pins, err := t.getPins(id)
+	if err != nil {
+		return err
+	}
+	for i, p := range pins {
+		if p.Equals(c) {
+			return t.putPins(id, append(pins[:i], pins[i+1:]...))
+		}
+	}
+	return nil
+}
+
+// protectBlocks keeps the garbage collector from listing blocks while blocks
+// are written and linked from logs. The returned func ends the protection.
+// Calls must not be nested.
+func (t *service) protectBlocks() func() {
+	t.gcLock.RLock()
+	return t.gcLock.RUnlock
+}
+
+// gcRoots are the heads and pins of a thread that a collection marks from.
+type gcRoots struct {
+	id    thread.ID
+	heads [][]cid.Cid
+	pins  []cid.Cid
+}
+
+// snapshot lists the roots to mark from and the blocks that may be removed,
+// and starts tracking the blocks that are written until stopTracking.
+func (t *service) snapshot(ctx context.Context) ([]gcRoots, []cid.Cid, error) {
+	t.gcLock.Lock()
+	defer t.gcLock.Unlock()
+
+	t.gcWritesLock.Lock()
+	t.gcWrites = make(map[cid.Cid]struct{})
+	t.gcWritesLock.Unlock()
+
+	ids, err := t.store.Threads()
+	if err != nil {
+		return nil, nil, err
+	}
+	roots := make([]gcRoots, len(ids))
+	for i, id := range ids {
+		info, err := t.store.ThreadInfo(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		roots[i].id = id
+		for _, lg := range info.Logs {
+			roots[i].heads = append(roots[i].heads, lg.Heads)
+		}
+		if roots[i].pins, err = t.getPins(id); err != nil {
+			return nil, nil, err
+		}
+	}
+	keys, err := t.bstore.AllKeysChan(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var candidates []cid.Cid
+	for c := range keys {
+		candidates = append(candidates, c)
+	}
+	return roots, candidates, ctx.Err()
+}
+
+// trackWrites keeps blocks written during a collection from being removed.
+func (t *service) trackWrites(cids ...cid.Cid) {
+	t.gcWritesLock.Lock()
+	defer t.gcWritesLock.Unlock()
+	if t.gcWrites == nil {
+		return
+	}
+	for _, c := range cids {
+		t.gcWrites[c] = struct{}{}
+	}
+}
+
+// trackDAG keeps the locally available blocks of the DAG rooted at c from
+// being removed by a running collection.
+func (t *service) trackDAG(ctx context.Context, c cid.Cid) error {
+	t.gcWritesLock.Lock()
+	running := t.gcWrites != nil
+	t.gcWritesLock.Unlock()
+	if !running {
+		return nil
+	}
+	dag := make(map[cid.Cid]struct{})
+	if err := t.markDAG(ctx, c, dag); err != nil {
+		return err
+	}
+	cids := make([]cid.Cid, 0, len(dag))
+	for c := range dag {
+		cids = append(cids, c)
+	}
+	t.trackWrites(cids...)
+	return nil
+}
+
+// stopTracking stops tracking written blocks.
+func (t *service) stopTracking() {
+	t.gcWritesLock.Lock()
+	defer t.gcWritesLock.Unlock()
+	t.gcWrites = nil
+}
+
+// startGC runs the garbage collector every interval until the service closes.
+func (t *service) startGC(interval time.Duration) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			if _, err := t.GC(t.ctx); err != nil {
+				log.Errorf("gc error: %s", err)
+			}
+		case <-t.ctx.Done():
+			return
+		}
+	}
+}
+
+// mark returns the IDs of all blocks reachable from the heads and pins of
+// every thread. Only locally available blocks are traversed.
+func (t *service) mark(ctx context.Context, roots []gcRoots) (map[cid.Cid]struct{}, error) {
+	marked := make(map[cid.Cid]struct{})
+	for _, root := range roots {
+		id := root.id
+		for _, heads := range root.heads {
+			recs, err := t.walkLog(ctx, id, heads, t.missing, 0)
+			if err != nil {
+				return nil, fmt.Errorf("walking logs of thread %s: %w", id, err)
+			}
+			for _, r := range recs {
+				cids, err := t.localRecordBlocks(ctx, r)
+				if err != nil {
+					return nil, err
+				}
+				for _, c := range cids {
+					marked[c] = struct{}{}
+				}
+			}
+		}
+		for _, c := range root.pins {
+			if err := t.markDAG(ctx, c, marked); err != nil {
+				return nil, fmt.Errorf("marking pin %s of thread %s: %w", c, id, err)
+			}
+		}
+	}
+	return marked, nil
+}
+
+// markDAG marks the locally available blocks of the DAG rooted at c.
+func (t *service) markDAG(ctx context.Context, c cid.Cid, marked map[cid.Cid]struct{}) error {
+	queue := []cid.Cid{c}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if _, ok := marked[c]; ok {
+			continue
+		}
+		if missing, err := t.missing(c); err != nil {
+			return err
+		} else if missing {
+			continue
+		}
+		marked[c] = struct{}{}
+		n, err := t.Get(ctx, c)
+		if err != nil {
+			return err
+		}
+		for _, l := range n.Links() {
+			queue = append(queue, l.Cid)
+		}
+	}
+	return nil
+}
+
+// sweep removes the candidate blocks that are neither marked nor written
+// since the collection started.
+func (t *service) sweep(ctx context.Context, candidates []cid.Cid, marked map[cid.Cid]struct{}) (int, error) {
+	var removed int
+	for _, c := range candidates {
+		if _, ok := marked[c]; ok {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return removed, err
+		}
+		ok, err := t.removeUnwritten(c)
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// removeUnwritten removes a block unless it was written during the collection.
+// Writes are tracked before blocks are written, so a block can't be written
+// between the check and the removal.
+func (t *service) removeUnwritten(c cid.Cid) (bool, error) {
+	t.gcWritesLock.Lock()
+	defer t.gcWritesLock.Unlock()
+	if _, ok := t.gcWrites[c]; ok {
+		return false, nil
+	}
+	return true, t.bstore.DeleteBlock(c)
+}
+
+// localRecordBlocks returns the IDs of a record's locally available blocks:
+// the record, its event, header, body, and the chunks of a chunked body.
+func (t *service) localRecordBlocks(ctx context.Context, r core.Record) ([]cid.Cid, error) {
+	cids := []cid.Cid{r.Cid()}
+	if missing, err := t.missing(r.BlockID()); err != nil || missing {
+		return cids, err
+	}
+	event, err := cbor.GetEvent(ctx, t, r.BlockID())
+	if err != nil {
+		return nil, err
+	}
+	cids = append(cids, r.BlockID())
+	if missing, err := t.missing(event.HeaderID()); err != nil {
+		return nil, err
+	} else if !missing {
+		cids = append(cids, event.HeaderID())
+	}
+	if missing, err := t.missing(event.BodyID()); err != nil || missing {
+		return cids, err
+	}
+	body, err := t.Get(ctx, event.BodyID())
+	if err != nil {
+		return nil, err
+	}
+	cids = append(cids, body.Cid())
+	for _, l := range body.Links() {
+		if missing, err := t.missing(l.Cid); err != nil {
+			return nil, err
+		} else if !missing {
+			cids = append(cids, l.Cid)
+		}
+	}
+	return cids, nil
+}
+
+// missing returns whether a block is not available locally.
+func (t *service) missing(c cid.Cid) (bool, error) {
+	if !c.Defined() {
+		return true, nil
+	}
+	has, err := t.bstore.Has(c)
+	return !has, err
+}
+
+// getPins returns the blocks pinned to a thread.
+func (t *service) getPins(id thread.ID) ([]cid.Cid, error) {
+	data, err := t.store.GetBytes(id, pinsKey)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var pins []cid.Cid
+	if err = cbornode.DecodeInto(*data, &pins); err != nil {
+		return nil, err
+	}
+	return pins, nil
+}
+
+// putPins replaces the blocks pinned to a thread.
+func (t *service) putPins(id thread.ID, pins []cid.Cid) error {
+	data, err := cbornode.DumpObject(pins)
+	if err != nil {
+		return err
+	}
+	return t.store.PutBytes(id, pinsKey, data)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// CreateInvite returns a signed invite to a thread for the given peer.
+// The invite addresses the thread on this host's addresses, and holds the
+// keys of the current and earlier key epochs. Invites to known replicators
+// don't include read-keys.
+func (t *service) CreateInvite(
+	_ context.Context,
+	id thread.ID,
+	invitee peer.ID,
+	ttl time.Duration,
+) ([]byte, error) {
+	info, err := t.store.ThreadInfo(id)
+	if err != nil {
+		return nil, err
+	}
+	if info.FollowKey == nil {
+		return nil, fmt.Errorf("thread not found")
+	}
+	pk, err := t.peerPubKey(invitee)
+	if err != nil {
+		return nil, err
+	}
+	if ttl == 0 {
+		ttl = DefaultInviteTTL
+	}
+	replicators, err := t.getReplicators(id)
+	if err != nil {
+		return nil, err
+	}
+	_, replicator := replicators[invitee]
+	rk := info.ReadKey
+	if replicator {
+		rk = nil
+	}
+	past := make(map[uint64]thread.EpochKeys)
+	for epoch := uint64(0); epoch < info.KeyEpoch; epoch++ {
+		var keys thread.EpochKeys
+		if keys.FollowKey, err = t.store.FollowKeyAt(id, epoch); err != nil {
+			return nil, err
+		}
+		if keys.FollowKey == nil {
+			continue
+		}
+		if !replicator {
+			if keys.ReadKey, err = t.store.ReadKeyAt(id, epoch); err != nil {
+				return nil, err
+			}
+		}
+		past[epoch] = keys
+	}
+
+	pa, err := ma.NewComponent(ma.ProtocolWithCode(ma.P_P2P).Name, t.host.ID().String())
+	if err != nil {
+		return nil, err
+	}
+	ta, err := ma.NewComponent(thread.Name, id.String())
+	if err != nil {
+		return nil, err
+	}
+	var addrs []ma.Multiaddr
+	for _, la := range t.host.Addrs() {
+		addrs = append(addrs, la.Encapsulate(pa).Encapsulate(ta))
+	}
+	return cbor.CreateInvite(thread.Invite{
+		ID:        id,
+		Addrs:     addrs,
+		FollowKey: info.FollowKey,
+		ReadKey:   rk,
+		KeyEpoch:  info.KeyEpoch,
+		PastKeys:  past,
+		Expiry:    time.Now().Add(ttl),
+	}, pk, t.getPrivKey())
+}
+
+// AcceptInvite opens an invite with the host key and adds the thread
+// from the first reachable address. The addresses must point to the invited
+// thread on the inviter. Replicators don't keep read-keys.
+func (t *service) AcceptInvite(ctx context.Context, invite []byte) (info thread.Info, err error) {
+	inv, err := cbor.OpenInvite(invite, t.getPrivKey())
+	if err != nil {
+		return
+	}
+	if len(inv.Addrs) == 0 {
+		return info, fmt.Errorf("invite has no addresses")
+	}
+	for _, addr := range inv.Addrs {
+		if err = checkInviteAddr(inv, addr); err != nil {
+			return
+		}
+	}
+	// Replicators drop the read-keys
+	opts := []core.KeyOption{core.FollowKey(inv.FollowKey), core.KeyEpoch(inv.KeyEpoch)}
+	if !t.replicator {
+		opts = append(opts, core.ReadKey(inv.ReadKey))
+	}
+	for epoch, keys := range inv.PastKeys {
+		rk := keys.ReadKey
+		if t.replicator {
+			rk = nil
+		}
+		opts = append(opts, core.PastKeys(epoch, keys.FollowKey, rk))
+	}
+	for _, addr := range inv.Addrs {
+		info, err = t.AddThread(ctx, addr, opts...)
+		if err == nil {
+			return info, nil
+		}
+		log.Warnf("error adding thread %s from %s: %s", inv.ID, addr, err)
+	}
+	return
+}
+
+// checkInviteAddr returns an error if addr doesn't address the invite's
+// thread on the inviter.
+func checkInviteAddr(inv thread.Invite, addr ma.Multiaddr) error {
+	idstr, err := addr.ValueForProtocol(thread.Code)
+	if err != nil {
+		return fmt.Errorf("invite address %s has no thread: %w", addr, err)
+	}
+	id, err := thread.Decode(idstr)
+	if err != nil {
+		return err
+	}
+	if !id.Equals(inv.ID) {
+		return fmt.Errorf("invite address %s is not for thread %s", addr, inv.ID)
+	}
+	p, err := addr.ValueForProtocol(ma.P_P2P)
+	if err != nil {
+		return fmt.Errorf("invite address %s has no peer: %w", addr, err)
+	}
+	pid, err := peer.Decode(p)
+	if err != nil {
+		return err
+	}
+	if pid != inv.Inviter {
+		return fmt.Errorf("invite address %s is not the inviter's", addr)
+	}
+	return nil
+}
+
+// peerPubKey returns the public key of a peer, from its ID if it's
+// embedded or from the peerstore otherwise.
+func (t *service) peerPubKey(pid peer.ID) (crypto.PubKey, error) {
+	pk, err := pid.ExtractPublicKey()
+	if err == nil && pk != nil {
+		return pk, nil
+	}
+	if pk = t.host.Peerstore().PubKey(pid); pk == nil {
+		return nil, fmt.Errorf("public key for %s not found", pid)
+	}
+	return pk, nil
+}
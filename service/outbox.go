@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// GetOutbox returns the records of a thread that haven't been delivered to peers.
+func (t *service) GetOutbox(_ context.Context, id thread.ID) ([]lstore.OutboxEntry, error) {
+	return t.store.Outbox(id)
+}
+
+// enqueuePush adds a record that failed to reach a peer to the thread's outbox.
+// An undefined record queues a push of the thread's current keys with log lid.
+func (t *service) enqueuePush(id thread.ID, lid peer.ID, rid cid.Cid, pid peer.ID, err error) error {
+	now := time.Now()
+	return t.store.PutOutbox(id, lstore.OutboxEntry{
+		Log:         lid,
+		Record:      rid,
+		Peer:        pid,
+		Attempts:    1,
+		NextAttempt: now.Add(pushBackoff(1)),
+		LastError:   err.Error(),
+		Created:     now,
+	})
+}
+
+// pushBackoff returns the delay before the next delivery after attempts failures.
+func pushBackoff(attempts int) time.Duration {
+	d := MinPushBackoff
+	for i := 1; i < attempts && d < MaxPushBackoff; i++ {
+		d *= 2
+	}
+	if d > MaxPushBackoff {
+		d = MaxPushBackoff
+	}
+	return d
+}
+
+// notifyConnected returns a notifiee that signals new peer connections
+// so their outbox entries are retried right away.
+func (t *service) notifyConnected() network.Notifiee {
+	return &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, c network.Conn) {
+			select {
+			case t.connected <- c.RemotePeer():
+			default:
+			}
+		},
+	}
+}
+
+// startPushing retries outbox entries when they're due and when peers connect.
+func (t *service) startPushing() {
+	tick := time.NewTicker(PushRetryInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+			t.retryPushes("")
+		case pid := <-t.connected:
+			t.retryPushes(pid)
+		case <-t.ctx.Done():
+			return
+		}
+	}
+}
+
+// outboxItem is an outbox entry with its thread.
+type outboxItem struct {
+	id thread.ID
+	e  lstore.OutboxEntry
+}
+
+// retryPushes pushes outbox entries that are due, and all entries
+// of the connected peer, if any. Entries over the outbox limits are
+// dropped first.
+func (t *service) retryPushes(connected peer.ID) {
+	ts, err := t.store.ThreadsFromOutbox()
+	if err != nil {
+		log.Errorf("error listing outbox threads: %s", err)
+		return
+	}
+	var items []outboxItem
+	for _, id := range ts {
+		entries, err := t.store.Outbox(id)
+		if err != nil {
+			log.Errorf("error getting outbox of thread %s: %s", id, err)
+			continue
+		}
+		for _, e := range entries {
+			items = append(items, outboxItem{id: id, e: e})
+		}
+	}
+	items = t.prunePushes(items)
+
+	now := time.Now()
+	wg := sync.WaitGroup{}
+	sem := make(chan struct{}, MaxConcurrentPushes)
+	for _, item := range items {
+		if item.e.Peer != connected && item.e.NextAttempt.After(now) {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-t.ctx.Done():
+			wg.Wait()
+			return
+		}
+		wg.Add(1)
+		go func(id thread.ID, e lstore.OutboxEntry) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := t.retryPush(id, e); err != nil {
+				log.Errorf("error retrying push of %s to %s: %s", e.Record, e.Peer, err)
+			}
+		}(item.id, item.e)
+	}
+	wg.Wait()
+}
+
+// prunePushes removes the outbox entries that expired, and the oldest entries
+// over MaxOutboxPeerEntries for a peer or over MaxOutboxEntries in total.
+// It returns the remaining entries.
+func (t *service) prunePushes(items []outboxItem) []outboxItem {
+	// Newest first, so that the oldest entries are over the limits
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].e.Created.After(items[j].e.Created)
+	})
+	now := time.Now()
+	peers := make(map[peer.ID]int)
+	kept := items[:0]
+	for _, item := range items {
+		e := item.e
+		var reason string
+		switch {
+		case !e.Created.IsZero() && now.Sub(e.Created) > OutboxTTL:
+			reason = "expired"
+		case peers[e.Peer] >= MaxOutboxPeerEntries:
+			reason = "too many entries for peer"
+		case len(kept) >= MaxOutboxEntries:
+			reason = "too many entries"
+		}
+		if reason == "" {
+			peers[e.Peer]++
+			kept = append(kept, item)
+			continue
+		}
+		log.Warnf("dropping push of %s to %s: %s", e.Record, e.Peer, reason)
+		if err := t.store.RemoveOutbox(item.id, e.Peer, e.Record); err != nil {
+			log.Errorf("error removing outbox entry: %s", err)
+		}
+	}
+	return kept
+}
+
+// retryPush pushes an outbox entry to its peer. The entry is removed once
+// delivered or after MaxPushAttempts failures, or rescheduled with a longer
+// backoff.
+func (t *service) retryPush(id thread.ID, e lstore.OutboxEntry) error {
+	if err := t.checkPeer(id, e.Peer); err != nil {
+		log.Debugf("dropping push of %s to %s: %s", e.Record, e.Peer, err)
+		return t.store.RemoveOutbox(id, e.Peer, e.Record)
+	}
+	var err error
+	if e.Record.Defined() {
+		err = t.retryPushRecord(id, e)
+	} else {
+		err = t.retryPushKeys(id, e)
+	}
+	if errors.Is(err, errDropPush) {
+		return t.store.RemoveOutbox(id, e.Peer, e.Record)
+	}
+	if err != nil {
+		log.Debugf("retry push of %s to %s failed: %s", e.Record, e.Peer, err)
+		if e.Attempts+1 >= MaxPushAttempts {
+			log.Warnf("dropping push of %s to %s after %d attempts: %s", e.Record, e.Peer, e.Attempts+1, err)
+			return t.store.RemoveOutbox(id, e.Peer, e.Record)
+		}
+		e.Attempts++
+		e.NextAttempt = time.Now().Add(pushBackoff(e.Attempts))
+		e.LastError = err.Error()
+		return t.store.PutOutbox(id, e)
+	}
+	return t.store.RemoveOutbox(id, e.Peer, e.Record)
+}
+
+// errDropPush indicates an outbox entry that can no longer be delivered.
+var errDropPush = errors.New("push can't be delivered")
+
+// retryPushRecord pushes the record of an outbox entry to its peer.
+func (t *service) retryPushRecord(id thread.ID, e lstore.OutboxEntry) error {
+	rec, err := t.GetRecord(t.ctx, id, e.Record)
+	if err != nil {
+		log.Warnf("dropping push of %s to %s: %s", e.Record, e.Peer, err)
+		return errDropPush
+	}
+	req, err := t.server.newPushRecordRequest(t.ctx, id, e.Log, rec)
+	if err != nil {
+		return err
+	}
+	return t.server.pushRecordToPeer(t.ctx, e.Peer, req)
+}
+
+// retryPushKeys pushes the thread's current keys with the log of an outbox
+// entry to its peer, without the read-key for replicators.
+func (t *service) retryPushKeys(id thread.ID, e lstore.OutboxEntry) error {
+	info, err := t.store.ThreadInfo(id)
+	if err != nil {
+		return err
+	}
+	lg, err := t.store.LogInfo(id, e.Log)
+	if err != nil {
+		return err
+	}
+	if lg.PubKey == nil {
+		log.Warnf("dropping push of keys to %s: log %s not found", e.Peer, e.Log)
+		return errDropPush
+	}
+	replicators, err := t.getReplicators(id)
+	if err != nil {
+		return err
+	}
+	rk := info.ReadKey
+	if _, ok := replicators[e.Peer]; ok {
+		rk = nil
+	}
+	return t.server.pushLog(t.ctx, id, lg, e.Peer, info.FollowKey, rk, info.KeyEpoch)
+}
+
+// dropPushes removes the outbox entries of a thread for the given peers.
+func (t *service) dropPushes(id thread.ID, pids map[peer.ID]struct{}) error {
+	if len(pids) == 0 {
+		return nil
+	}
+	entries, err := t.store.Outbox(id)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if _, ok := pids[e.Peer]; !ok {
+			continue
+		}
+		if err = t.store.RemoveOutbox(id, e.Peer, e.Record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
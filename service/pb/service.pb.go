@@ -9,8 +9,11 @@ import (
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
 	io "io"
 	math "math"
+	math_bits "math/bits"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -22,7 +25,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // Log represents a thread log.
 type Log struct {
@@ -34,6 +37,9 @@ type Log struct {
 	Addrs []ProtoAddr `protobuf:"bytes,3,rep,name=addrs,proto3,customtype=ProtoAddr" json:"addrs,omitempty"`
 	// heads of the log.
 	Heads []ProtoCid `protobuf:"bytes,4,rep,name=heads,proto3,customtype=ProtoCid" json:"heads,omitempty"`
+	// signature of the thread ID and the log's ID, key and addresses, made
+	// with the log key. It shows the addresses were set by the log's owner.
+	Signature []byte `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
 func (m *Log) Reset()         { *m = Log{} }
@@ -50,7 +56,7 @@ func (m *Log) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return xxx_messageInfo_Log.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -69,6 +75,13 @@ func (m *Log) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Log proto.InternalMessageInfo
 
+func (m *Log) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 // Record is a thread record containing link data.
 type Log_Record struct {
 	// recordNode is the top-level node's raw data.
@@ -95,7 +108,7 @@ func (m *Log_Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return xxx_messageInfo_Log_Record.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -166,7 +179,7 @@ func (m *GetLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return xxx_messageInfo_GetLogsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -192,9 +205,14 @@ func (m *GetLogsRequest) GetHeader() *GetLogsRequest_Header {
 	return nil
 }
 
-// Header holds sender information.
+// Header holds sender and key information.
 type GetLogsRequest_Header struct {
+	// from is the sender's peerID.
 	From *ProtoPeerID `protobuf:"bytes,1,opt,name=from,proto3,customtype=ProtoPeerID" json:"from,omitempty"`
+	// signature is the signature of the request without its header.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// key is the sender's public key used to sign the request.
+	Key *ProtoPubKey `protobuf:"bytes,3,opt,name=key,proto3,customtype=ProtoPubKey" json:"key,omitempty"`
 }
 
 func (m *GetLogsRequest_Header) Reset()         { *m = GetLogsRequest_Header{} }
@@ -211,7 +229,7 @@ func (m *GetLogsRequest_Header) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return xxx_messageInfo_GetLogsRequest_Header.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -230,10 +248,22 @@ func (m *GetLogsRequest_Header) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetLogsRequest_Header proto.InternalMessageInfo
 
+func (m *GetLogsRequest_Header) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 // GetLogsReply is the response from a GetLogsRequest.
 type GetLogsReply struct {
 	// logs are the result of the request.
 	Logs []*Log `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	// creator is the ID of the thread creator's log, if it's known.
+	Creator *ProtoPeerID `protobuf:"bytes,2,opt,name=creator,proto3,customtype=ProtoPeerID" json:"creator,omitempty"`
+	// replicators are the peers known to replicate the thread without its
+	// read-key.
+	Replicators []ProtoPeerID `protobuf:"bytes,3,rep,name=replicators,proto3,customtype=ProtoPeerID" json:"replicators,omitempty"`
 }
 
 func (m *GetLogsReply) Reset()         { *m = GetLogsReply{} }
@@ -250,7 +280,7 @@ func (m *GetLogsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return xxx_messageInfo_GetLogsReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -288,6 +318,26 @@ type PushLogRequest struct {
 	ReadKey *ProtoKey `protobuf:"bytes,4,opt,name=readKey,proto3,customtype=ProtoKey" json:"readKey,omitempty"`
 	// log is the actual log payload.
 	Log *Log `protobuf:"bytes,5,opt,name=log,proto3" json:"log,omitempty"`
+	// keyEpoch is the key epoch of followKey and readKey.
+	KeyEpoch uint64 `protobuf:"varint,6,opt,name=keyEpoch,proto3" json:"keyEpoch,omitempty"`
+	// sealedKeys holds the follow-key, read-key, and key epoch encrypted to the
+	// recipient's peer key. It replaces the plaintext key fields when set.
+	SealedKeys []byte `protobuf:"bytes,7,opt,name=sealedKeys,proto3" json:"sealedKeys,omitempty"`
+	// creator is the ID of the thread creator's log, if it's known. It's only
+	// used by recipients that don't have the thread yet.
+	Creator *ProtoPeerID `protobuf:"bytes,8,opt,name=creator,proto3,customtype=ProtoPeerID" json:"creator,omitempty"`
+	// keyProof is an HMAC of the thread ID, key epoch and sender, keyed with the
+	// follow-key of the previous epoch. It's required to rotate keys.
+	KeyProof []byte `protobuf:"bytes,9,opt,name=keyProof,proto3" json:"keyProof,omitempty"`
+	// removed are the IDs of logs removed from the thread by key rotations.
+	Removed []ProtoPeerID `protobuf:"bytes,10,rep,name=removed,proto3,customtype=ProtoPeerID" json:"removed,omitempty"`
+	// replicators are the peers known to replicate the thread without its
+	// read-key. They're never sent read-keys.
+	Replicators []ProtoPeerID `protobuf:"bytes,11,rep,name=replicators,proto3,customtype=ProtoPeerID" json:"replicators,omitempty"`
+	// keyProofs are key proofs keyed with the follow-key of each earlier
+	// epoch, indexed by epoch, so that peers that missed rotations can
+	// still rotate to keyEpoch.
+	KeyProofs [][]byte `protobuf:"bytes,12,rep,name=keyProofs,proto3" json:"keyProofs,omitempty"`
 }
 
 func (m *PushLogRequest) Reset()         { *m = PushLogRequest{} }
@@ -304,7 +354,7 @@ func (m *PushLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return xxx_messageInfo_PushLogRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -337,9 +387,42 @@ func (m *PushLogRequest) GetLog() *Log {
 	return nil
 }
 
-// Header holds sender information.
+func (m *PushLogRequest) GetKeyEpoch() uint64 {
+	if m != nil {
+		return m.KeyEpoch
+	}
+	return 0
+}
+
+func (m *PushLogRequest) GetSealedKeys() []byte {
+	if m != nil {
+		return m.SealedKeys
+	}
+	return nil
+}
+
+func (m *PushLogRequest) GetKeyProof() []byte {
+	if m != nil {
+		return m.KeyProof
+	}
+	return nil
+}
+
+func (m *PushLogRequest) GetKeyProofs() [][]byte {
+	if m != nil {
+		return m.KeyProofs
+	}
+	return nil
+}
+
+// Header holds sender and key information.
 type PushLogRequest_Header struct {
+	// from is the sender's peerID.
 	From *ProtoPeerID `protobuf:"bytes,1,opt,name=from,proto3,customtype=ProtoPeerID" json:"from,omitempty"`
+	// signature is the signature of the request without its header.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// key is the sender's public key used to sign the request.
+	Key *ProtoPubKey `protobuf:"bytes,3,opt,name=key,proto3,customtype=ProtoPubKey" json:"key,omitempty"`
 }
 
 func (m *PushLogRequest_Header) Reset()         { *m = PushLogRequest_Header{} }
@@ -356,7 +439,7 @@ func (m *PushLogRequest_Header) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return xxx_messageInfo_PushLogRequest_Header.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -375,6 +458,13 @@ func (m *PushLogRequest_Header) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PushLogRequest_Header proto.InternalMessageInfo
 
+func (m *PushLogRequest_Header) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 // PushLogReply is the response from a PushLogRequest.
 type PushLogReply struct {
 }
@@ -393,7 +483,7 @@ func (m *PushLogReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return xxx_messageInfo_PushLogReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -438,7 +528,7 @@ func (m *GetRecordsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return xxx_messageInfo_GetRecordsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -495,7 +585,7 @@ func (m *GetRecordsRequest_LogEntry) XXX_Marshal(b []byte, deterministic bool) (
 		return xxx_messageInfo_GetRecordsRequest_LogEntry.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -521,9 +611,14 @@ func (m *GetRecordsRequest_LogEntry) GetLimit() int32 {
 	return 0
 }
 
-// Header holds sender information.
+// Header holds sender and key information.
 type GetRecordsRequest_Header struct {
+	// from is the sender's peerID.
 	From *ProtoPeerID `protobuf:"bytes,1,opt,name=from,proto3,customtype=ProtoPeerID" json:"from,omitempty"`
+	// signature is the signature of the request without its header.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// key is the sender's public key used to sign the request.
+	Key *ProtoPubKey `protobuf:"bytes,3,opt,name=key,proto3,customtype=ProtoPubKey" json:"key,omitempty"`
 }
 
 func (m *GetRecordsRequest_Header) Reset()         { *m = GetRecordsRequest_Header{} }
@@ -540,7 +635,7 @@ func (m *GetRecordsRequest_Header) XXX_Marshal(b []byte, deterministic bool) ([]
 		return xxx_messageInfo_GetRecordsRequest_Header.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -559,6 +654,13 @@ func (m *GetRecordsRequest_Header) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetRecordsRequest_Header proto.InternalMessageInfo
 
+func (m *GetRecordsRequest_Header) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 // GetRecordsReply contains records requested with a GetRecordsRequest.
 type GetRecordsReply struct {
 	// records are the result of the request.
@@ -579,7 +681,7 @@ func (m *GetRecordsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return xxx_messageInfo_GetRecordsReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -613,6 +715,9 @@ type GetRecordsReply_LogEntry struct {
 	Records []*Log_Record `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
 	// log contains new log info that was missing from the request.
 	Log *Log `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	// more is set if the log has more records than the limit. The last
+	// record can be used as the offset of the next request.
+	More bool `protobuf:"varint,4,opt,name=more,proto3" json:"more,omitempty"`
 }
 
 func (m *GetRecordsReply_LogEntry) Reset()         { *m = GetRecordsReply_LogEntry{} }
@@ -629,7 +734,7 @@ func (m *GetRecordsReply_LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]
 		return xxx_messageInfo_GetRecordsReply_LogEntry.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -662,6 +767,233 @@ func (m *GetRecordsReply_LogEntry) GetLog() *Log {
 	return nil
 }
 
+func (m *GetRecordsReply_LogEntry) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+// StreamRecordsRequest is used to stream records from a log address.
+type StreamRecordsRequest struct {
+	// header is the message header.
+	Header *StreamRecordsRequest_Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// threadID is the target thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,2,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// followKey for the thread.
+	FollowKey *ProtoKey `protobuf:"bytes,3,opt,name=followKey,proto3,customtype=ProtoKey" json:"followKey,omitempty"`
+	// List of requested logs.
+	Logs []*StreamRecordsRequest_LogEntry `protobuf:"bytes,4,rep,name=logs,proto3" json:"logs,omitempty"`
+	// chunkSize is the max number of records in a reply.
+	ChunkSize int32 `protobuf:"varint,5,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
+}
+
+func (m *StreamRecordsRequest) Reset()         { *m = StreamRecordsRequest{} }
+func (m *StreamRecordsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamRecordsRequest) ProtoMessage()    {}
+func (*StreamRecordsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{7}
+}
+func (m *StreamRecordsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamRecordsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamRecordsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamRecordsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamRecordsRequest.Merge(m, src)
+}
+func (m *StreamRecordsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamRecordsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamRecordsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamRecordsRequest proto.InternalMessageInfo
+
+func (m *StreamRecordsRequest) GetHeader() *StreamRecordsRequest_Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *StreamRecordsRequest) GetLogs() []*StreamRecordsRequest_LogEntry {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *StreamRecordsRequest) GetChunkSize() int32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+// LogEntry represents a single log.
+type StreamRecordsRequest_LogEntry struct {
+	// logID of this entry.
+	LogID *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// offset tells the recipient at which point to consider records new for the reply.
+	Offset *ProtoCid `protobuf:"bytes,2,opt,name=offset,proto3,customtype=ProtoCid" json:"offset,omitempty"`
+}
+
+func (m *StreamRecordsRequest_LogEntry) Reset()         { *m = StreamRecordsRequest_LogEntry{} }
+func (m *StreamRecordsRequest_LogEntry) String() string { return proto.CompactTextString(m) }
+func (*StreamRecordsRequest_LogEntry) ProtoMessage()    {}
+func (*StreamRecordsRequest_LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{7, 0}
+}
+func (m *StreamRecordsRequest_LogEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamRecordsRequest_LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamRecordsRequest_LogEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamRecordsRequest_LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamRecordsRequest_LogEntry.Merge(m, src)
+}
+func (m *StreamRecordsRequest_LogEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamRecordsRequest_LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamRecordsRequest_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamRecordsRequest_LogEntry proto.InternalMessageInfo
+
+// Header holds sender and key information.
+type StreamRecordsRequest_Header struct {
+	// from is the sender's peerID.
+	From *ProtoPeerID `protobuf:"bytes,1,opt,name=from,proto3,customtype=ProtoPeerID" json:"from,omitempty"`
+	// signature is the signature of the request without its header.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// key is the sender's public key used to sign the request.
+	Key *ProtoPubKey `protobuf:"bytes,3,opt,name=key,proto3,customtype=ProtoPubKey" json:"key,omitempty"`
+}
+
+func (m *StreamRecordsRequest_Header) Reset()         { *m = StreamRecordsRequest_Header{} }
+func (m *StreamRecordsRequest_Header) String() string { return proto.CompactTextString(m) }
+func (*StreamRecordsRequest_Header) ProtoMessage()    {}
+func (*StreamRecordsRequest_Header) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{7, 1}
+}
+func (m *StreamRecordsRequest_Header) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamRecordsRequest_Header) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamRecordsRequest_Header.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamRecordsRequest_Header) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamRecordsRequest_Header.Merge(m, src)
+}
+func (m *StreamRecordsRequest_Header) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamRecordsRequest_Header) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamRecordsRequest_Header.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamRecordsRequest_Header proto.InternalMessageInfo
+
+func (m *StreamRecordsRequest_Header) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// StreamRecordsReply is a chunk of records from a single log.
+// Records of a log are sent oldest first over one or more replies.
+type StreamRecordsReply struct {
+	// logID of the records.
+	LogID *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// records in this chunk.
+	Records []*Log_Record `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	// log contains new log info that was missing from the request.
+	// It's only set in the first chunk of a log.
+	Log *Log `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	// cursor is the offset to resume the log from after this chunk.
+	Cursor *ProtoCid `protobuf:"bytes,4,opt,name=cursor,proto3,customtype=ProtoCid" json:"cursor,omitempty"`
+}
+
+func (m *StreamRecordsReply) Reset()         { *m = StreamRecordsReply{} }
+func (m *StreamRecordsReply) String() string { return proto.CompactTextString(m) }
+func (*StreamRecordsReply) ProtoMessage()    {}
+func (*StreamRecordsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{8}
+}
+func (m *StreamRecordsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamRecordsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamRecordsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamRecordsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamRecordsReply.Merge(m, src)
+}
+func (m *StreamRecordsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamRecordsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamRecordsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamRecordsReply proto.InternalMessageInfo
+
+func (m *StreamRecordsReply) GetRecords() []*Log_Record {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *StreamRecordsReply) GetLog() *Log {
+	if m != nil {
+		return m.Log
+	}
+	return nil
+}
+
 // PushRecordRequest is used to push a log record to a peer.
 type PushRecordRequest struct {
 	// header is the header message.
@@ -678,7 +1010,7 @@ func (m *PushRecordRequest) Reset()         { *m = PushRecordRequest{} }
 func (m *PushRecordRequest) String() string { return proto.CompactTextString(m) }
 func (*PushRecordRequest) ProtoMessage()    {}
 func (*PushRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{7}
+	return fileDescriptor_a0b84a42fa06f626, []int{9}
 }
 func (m *PushRecordRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -688,7 +1020,7 @@ func (m *PushRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return xxx_messageInfo_PushRecordRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -735,7 +1067,7 @@ func (m *PushRecordRequest_Header) Reset()         { *m = PushRecordRequest_Head
 func (m *PushRecordRequest_Header) String() string { return proto.CompactTextString(m) }
 func (*PushRecordRequest_Header) ProtoMessage()    {}
 func (*PushRecordRequest_Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{7, 0}
+	return fileDescriptor_a0b84a42fa06f626, []int{9, 0}
 }
 func (m *PushRecordRequest_Header) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -745,7 +1077,7 @@ func (m *PushRecordRequest_Header) XXX_Marshal(b []byte, deterministic bool) ([]
 		return xxx_messageInfo_PushRecordRequest_Header.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -779,7 +1111,7 @@ func (m *PushRecordReply) Reset()         { *m = PushRecordReply{} }
 func (m *PushRecordReply) String() string { return proto.CompactTextString(m) }
 func (*PushRecordReply) ProtoMessage()    {}
 func (*PushRecordReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a0b84a42fa06f626, []int{8}
+	return fileDescriptor_a0b84a42fa06f626, []int{10}
 }
 func (m *PushRecordReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -789,7 +1121,7 @@ func (m *PushRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return xxx_messageInfo_PushRecordReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -822,6 +1154,10 @@ func init() {
 	proto.RegisterType((*GetRecordsRequest_Header)(nil), "service.pb.GetRecordsRequest.Header")
 	proto.RegisterType((*GetRecordsReply)(nil), "service.pb.GetRecordsReply")
 	proto.RegisterType((*GetRecordsReply_LogEntry)(nil), "service.pb.GetRecordsReply.LogEntry")
+	proto.RegisterType((*StreamRecordsRequest)(nil), "service.pb.StreamRecordsRequest")
+	proto.RegisterType((*StreamRecordsRequest_LogEntry)(nil), "service.pb.StreamRecordsRequest.LogEntry")
+	proto.RegisterType((*StreamRecordsRequest_Header)(nil), "service.pb.StreamRecordsRequest.Header")
+	proto.RegisterType((*StreamRecordsReply)(nil), "service.pb.StreamRecordsReply")
 	proto.RegisterType((*PushRecordRequest)(nil), "service.pb.PushRecordRequest")
 	proto.RegisterType((*PushRecordRequest_Header)(nil), "service.pb.PushRecordRequest.Header")
 	proto.RegisterType((*PushRecordReply)(nil), "service.pb.PushRecordReply")
@@ -830,55 +1166,69 @@ func init() {
 func init() { proto.RegisterFile("service.proto", fileDescriptor_a0b84a42fa06f626) }
 
 var fileDescriptor_a0b84a42fa06f626 = []byte{
-	// 755 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x51, 0x6f, 0xd2, 0x50,
-	0x14, 0xa6, 0x2d, 0x30, 0x76, 0xc6, 0x46, 0xb8, 0x31, 0xa6, 0xa9, 0x5a, 0x58, 0x37, 0xe7, 0x62,
-	0x32, 0x66, 0xb6, 0x17, 0x35, 0xbe, 0x6c, 0x62, 0x26, 0x4a, 0xcc, 0x52, 0xfd, 0x03, 0xb0, 0x5e,
-	0x0a, 0xb1, 0x70, 0xb1, 0x2d, 0x33, 0xbc, 0xfa, 0xb0, 0x67, 0xff, 0x80, 0x89, 0x6f, 0xfe, 0x0d,
-	0xdf, 0xf4, 0x71, 0x0f, 0x9a, 0x18, 0x1e, 0x88, 0xb2, 0x3f, 0xe1, 0xa3, 0xb9, 0xe7, 0x96, 0x42,
-	0x61, 0x90, 0x11, 0x93, 0xbd, 0x71, 0xcf, 0xf7, 0x9d, 0xd3, 0x73, 0xbe, 0xfb, 0xf5, 0x14, 0x58,
-	0xf5, 0xa8, 0x7b, 0xda, 0x38, 0xa1, 0x85, 0xb6, 0xcb, 0x7c, 0x46, 0x20, 0x3c, 0x56, 0xb5, 0x1d,
-	0xbb, 0xe1, 0xd7, 0x3b, 0xd5, 0xc2, 0x09, 0x6b, 0xee, 0xda, 0xcc, 0x66, 0xbb, 0x48, 0xa9, 0x76,
-	0x6a, 0x78, 0xc2, 0x03, 0xfe, 0x12, 0xa9, 0xc6, 0x27, 0x19, 0x94, 0x32, 0xb3, 0x49, 0x0e, 0xe4,
-	0x52, 0x51, 0x95, 0xf2, 0xd2, 0x76, 0xfa, 0x30, 0xd3, 0xeb, 0xe7, 0x56, 0x8e, 0x39, 0x7c, 0x4c,
-	0xa9, 0x5b, 0x2a, 0x9a, 0x72, 0xa9, 0x48, 0xee, 0x41, 0xb2, 0xdd, 0xa9, 0xbe, 0xa4, 0x5d, 0x55,
-	0x9e, 0x24, 0x61, 0xd8, 0x0c, 0x60, 0xb2, 0x01, 0x89, 0x8a, 0x65, 0xb9, 0x9e, 0xaa, 0xe4, 0x95,
-	0xed, 0xf4, 0xe1, 0x6a, 0xaf, 0x9f, 0x5b, 0x46, 0xde, 0x81, 0x65, 0xb9, 0xa6, 0xc0, 0x88, 0x01,
-	0x89, 0x3a, 0xad, 0x58, 0x9e, 0x1a, 0x47, 0x52, 0xba, 0xd7, 0xcf, 0xa5, 0x90, 0xf4, 0xb4, 0x61,
-	0x99, 0x02, 0xd2, 0x3e, 0x48, 0x90, 0x34, 0xe9, 0x09, 0x73, 0x2d, 0xa2, 0x03, 0xb8, 0xf8, 0xeb,
-	0x15, 0xb3, 0xa8, 0xe8, 0xd2, 0x1c, 0x8b, 0x90, 0xdb, 0xb0, 0x4c, 0x4f, 0x69, 0xcb, 0x47, 0x18,
-	0xfb, 0x33, 0x47, 0x01, 0x9e, 0xcd, 0x2b, 0x52, 0x17, 0x61, 0x45, 0x64, 0x8f, 0x22, 0x44, 0x83,
-	0x54, 0x95, 0x59, 0x5d, 0x44, 0xe3, 0x88, 0x86, 0x67, 0xe3, 0xa7, 0x04, 0x6b, 0x47, 0xd4, 0x2f,
-	0x33, 0xdb, 0x33, 0xe9, 0xbb, 0x0e, 0xf5, 0x7c, 0xf2, 0x08, 0x92, 0x22, 0x19, 0x1b, 0x59, 0xd9,
-	0x5b, 0x2f, 0x8c, 0xe4, 0x2f, 0x44, 0xb9, 0x85, 0xe7, 0x48, 0x34, 0x83, 0x04, 0xb2, 0x03, 0x29,
-	0xbf, 0xee, 0xd2, 0x8a, 0x55, 0x2a, 0x06, 0x32, 0x66, 0x7b, 0xfd, 0xdc, 0x2a, 0x4e, 0xfe, 0x26,
-	0x00, 0xcc, 0x90, 0x42, 0xee, 0xc3, 0x72, 0x8d, 0x39, 0x0e, 0x7b, 0xcf, 0x65, 0xc7, 0xbe, 0xc7,
-	0x94, 0xe2, 0x9a, 0x8f, 0x60, 0x6d, 0x07, 0x92, 0xe2, 0x61, 0x64, 0x03, 0xe2, 0x35, 0x97, 0x35,
-	0x67, 0x5d, 0x26, 0x82, 0xc6, 0x3e, 0xa4, 0xc3, 0x56, 0xdb, 0x0e, 0xbf, 0xb5, 0xb8, 0xc3, 0x6c,
-	0x4f, 0x95, 0xf2, 0xca, 0xf6, 0xca, 0x5e, 0x66, 0x7c, 0xa4, 0x32, 0xb3, 0x4d, 0x04, 0x8d, 0xcf,
-	0x32, 0xac, 0x1d, 0x77, 0xbc, 0x3a, 0x8f, 0x5c, 0x45, 0x8c, 0x28, 0xf7, 0xfa, 0xc4, 0x20, 0x5b,
-	0xb0, 0xc4, 0xb3, 0x38, 0x33, 0x7e, 0x09, 0x73, 0x08, 0x92, 0x75, 0x50, 0x1c, 0x66, 0xab, 0x09,
-	0x6c, 0x7d, 0x6a, 0x68, 0x8e, 0x2d, 0xaa, 0xeb, 0x1a, 0xa4, 0xc3, 0xa9, 0xdb, 0x4e, 0xd7, 0x38,
-	0x53, 0x20, 0x7b, 0x44, 0x7d, 0xe1, 0xe3, 0xd0, 0x42, 0x4f, 0x26, 0x54, 0xdb, 0x9c, 0xb0, 0x50,
-	0x94, 0x7e, 0x8d, 0xc2, 0x3d, 0x0e, 0x6c, 0x10, 0x47, 0x1b, 0x6c, 0xcd, 0x6f, 0xab, 0xcc, 0xec,
-	0x67, 0x2d, 0xdf, 0xed, 0x0a, 0x77, 0x68, 0x4d, 0x48, 0x0d, 0x23, 0xe4, 0x2e, 0x24, 0x1c, 0x66,
-	0xcf, 0xde, 0x28, 0x02, 0x25, 0x9b, 0x90, 0x64, 0xb5, 0x9a, 0x47, 0xfd, 0x60, 0x8e, 0xe8, 0x1e,
-	0x08, 0x30, 0x72, 0x03, 0x12, 0x4e, 0xa3, 0xd9, 0xf0, 0xb1, 0xf9, 0x84, 0x29, 0x0e, 0x8b, 0x5e,
-	0xcc, 0x0f, 0x09, 0x32, 0xe3, 0x23, 0x70, 0xd3, 0x3f, 0x8c, 0x98, 0x7e, 0xe6, 0x25, 0xb4, 0x9d,
-	0xee, 0xe4, 0xac, 0x67, 0xd2, 0xe2, 0xc3, 0x3e, 0xe0, 0xa6, 0xc4, 0x92, 0xaa, 0x8c, 0x0f, 0xbc,
-	0x39, 0x61, 0xb8, 0x82, 0x78, 0xa2, 0x39, 0xa4, 0x0d, 0xed, 0xa9, 0xcc, 0xb6, 0xa7, 0xf1, 0x4d,
-	0x86, 0x2c, 0x37, 0x5c, 0x90, 0x7a, 0x15, 0x7f, 0x4d, 0xd1, 0xff, 0xd3, 0x5f, 0xe1, 0xf8, 0xca,
-	0xdc, 0xf1, 0x0b, 0x90, 0x14, 0x73, 0xe1, 0x2b, 0x39, 0x7b, 0xfa, 0x80, 0xa5, 0xb5, 0x16, 0xba,
-	0x5f, 0xfe, 0x09, 0xf0, 0x1a, 0x76, 0xab, 0xe2, 0x77, 0xdc, 0xf0, 0x13, 0x10, 0x06, 0xb8, 0x92,
-	0x6f, 0x43, 0xf7, 0x4f, 0x7d, 0xba, 0x38, 0x66, 0x64, 0x21, 0x33, 0xae, 0x4c, 0xdb, 0xe9, 0xee,
-	0x7d, 0x91, 0x61, 0xe9, 0xb5, 0x68, 0x92, 0x1c, 0xc0, 0x52, 0xb0, 0x30, 0x89, 0x36, 0x7b, 0xe1,
-	0x6b, 0xea, 0xa5, 0x18, 0xdf, 0x04, 0x31, 0x5e, 0x22, 0xd8, 0x0d, 0xd1, 0x12, 0xd1, 0x35, 0x19,
-	0x2d, 0x11, 0x59, 0x26, 0x31, 0xf2, 0x02, 0x60, 0xe4, 0x4c, 0x72, 0x67, 0xee, 0xfb, 0xa9, 0xdd,
-	0x9a, 0x63, 0x68, 0x51, 0x6b, 0x34, 0x70, 0xb4, 0xd6, 0x94, 0x45, 0xa2, 0xb5, 0x26, 0x74, 0x32,
-	0x62, 0x87, 0xf9, 0xbf, 0x7f, 0x74, 0xe9, 0xeb, 0x40, 0x97, 0xbe, 0x0f, 0x74, 0xe9, 0x7c, 0xa0,
-	0x4b, 0xbf, 0x07, 0xba, 0xf4, 0xf1, 0x42, 0x8f, 0x9d, 0x5f, 0xe8, 0xb1, 0x5f, 0x17, 0x7a, 0xac,
-	0x9a, 0xc4, 0xff, 0x1b, 0xfb, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x27, 0x2d, 0x7d, 0x61, 0xbb,
-	0x08, 0x00, 0x00,
+	// 985 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xcf, 0xfe, 0xf1, 0xda, 0x79, 0x76, 0x12, 0x65, 0x54, 0xa1, 0xd1, 0x16, 0xd6, 0xae, 0x1b,
+	0xda, 0x14, 0xa9, 0x6e, 0x09, 0x17, 0x40, 0x20, 0xd4, 0x90, 0xaa, 0x04, 0x22, 0x14, 0x4d, 0x40,
+	0x9c, 0x6d, 0xef, 0x78, 0x6d, 0x65, 0xed, 0x59, 0x66, 0xd7, 0x41, 0xe6, 0xc8, 0x27, 0x80, 0x0b,
+	0x07, 0x3e, 0x08, 0x17, 0x2e, 0xdc, 0xca, 0xb1, 0xc7, 0x2a, 0x87, 0xa8, 0x24, 0x17, 0x3e, 0x02,
+	0x27, 0x84, 0x66, 0x66, 0xff, 0x78, 0x37, 0xfe, 0x13, 0x84, 0xea, 0x9b, 0xe7, 0xfd, 0x7e, 0xf3,
+	0xde, 0xcc, 0x7b, 0xbf, 0xf7, 0x66, 0x0d, 0x1b, 0x21, 0xe5, 0x67, 0x83, 0x2e, 0x6d, 0x05, 0x9c,
+	0x45, 0x0c, 0x41, 0xba, 0xec, 0xd8, 0x0f, 0xbd, 0x41, 0xd4, 0x1f, 0x77, 0x5a, 0x5d, 0x36, 0x7c,
+	0xe4, 0x31, 0x8f, 0x3d, 0x92, 0x94, 0xce, 0xb8, 0x27, 0x57, 0x72, 0x21, 0x7f, 0xa9, 0xad, 0xcd,
+	0xdf, 0x74, 0x30, 0x8e, 0x98, 0x87, 0xea, 0xa0, 0x1f, 0x1e, 0x60, 0xad, 0xa1, 0xed, 0xd6, 0xf6,
+	0xb7, 0xce, 0x2f, 0xea, 0xd5, 0x63, 0x01, 0x1f, 0x53, 0xca, 0x0f, 0x0f, 0x88, 0x7e, 0x78, 0x80,
+	0xee, 0x83, 0x15, 0x8c, 0x3b, 0x5f, 0xd0, 0x09, 0xd6, 0x8b, 0x24, 0x69, 0x26, 0x31, 0x8c, 0xee,
+	0x42, 0xa9, 0xed, 0xba, 0x3c, 0xc4, 0x46, 0xc3, 0xd8, 0xad, 0xed, 0x6f, 0x9c, 0x5f, 0xd4, 0xd7,
+	0x25, 0xef, 0x89, 0xeb, 0x72, 0xa2, 0x30, 0xd4, 0x84, 0x52, 0x9f, 0xb6, 0xdd, 0x10, 0x9b, 0x92,
+	0x54, 0x3b, 0xbf, 0xa8, 0x57, 0x24, 0xe9, 0xd3, 0x81, 0x4b, 0x14, 0x84, 0xde, 0x84, 0xf5, 0x70,
+	0xe0, 0x8d, 0xda, 0xd1, 0x98, 0x53, 0x5c, 0x12, 0x41, 0x49, 0x66, 0xb0, 0x7f, 0xd0, 0xc0, 0x22,
+	0xb4, 0xcb, 0xb8, 0x8b, 0x1c, 0x00, 0x2e, 0x7f, 0x7d, 0xc9, 0x5c, 0xaa, 0xee, 0x40, 0xa6, 0x2c,
+	0xc2, 0x11, 0x3d, 0xa3, 0xa3, 0x48, 0xc2, 0xba, 0x72, 0x94, 0x1a, 0xc4, 0x6e, 0x11, 0x8f, 0x72,
+	0x09, 0x1b, 0x6a, 0x77, 0x66, 0x41, 0x36, 0x54, 0x3a, 0xcc, 0x9d, 0x48, 0xd4, 0x94, 0x68, 0xba,
+	0x6e, 0xfe, 0xac, 0xc3, 0xe6, 0x33, 0x1a, 0x1d, 0x31, 0x2f, 0x24, 0xf4, 0xdb, 0x31, 0x0d, 0x23,
+	0xf4, 0x01, 0x58, 0x6a, 0xb3, 0x3c, 0x48, 0x75, 0xef, 0x4e, 0x2b, 0x2b, 0x4e, 0x2b, 0xcf, 0x6d,
+	0x7d, 0x26, 0x89, 0x24, 0xde, 0x80, 0x1e, 0x42, 0x25, 0xea, 0x73, 0xda, 0x76, 0x0f, 0x0f, 0xe2,
+	0x24, 0x6f, 0x9f, 0x5f, 0xd4, 0x37, 0x64, 0x5e, 0xbe, 0x8a, 0x01, 0x92, 0x52, 0xd0, 0x3b, 0xb0,
+	0xde, 0x63, 0xbe, 0xcf, 0xbe, 0x13, 0x45, 0x91, 0xe7, 0x9e, 0xca, 0xa3, 0xa8, 0x48, 0x06, 0xdb,
+	0x23, 0xb0, 0x54, 0x30, 0x74, 0x17, 0xcc, 0x1e, 0x67, 0xc3, 0x79, 0xa5, 0x96, 0x60, 0x3e, 0xf5,
+	0x7a, 0x21, 0xf5, 0xe8, 0x0e, 0x18, 0xa7, 0x69, 0xc8, 0x6b, 0x3a, 0x10, 0x58, 0xf3, 0x27, 0x0d,
+	0x6a, 0xe9, 0x65, 0x03, 0x5f, 0xa8, 0xc2, 0xf4, 0x99, 0x17, 0x62, 0xad, 0x61, 0xec, 0x56, 0xf7,
+	0xb6, 0xa6, 0x93, 0x72, 0xc4, 0x3c, 0x22, 0x41, 0xf4, 0x00, 0xca, 0x5d, 0x4e, 0xdb, 0x11, 0xe3,
+	0x58, 0x9f, 0x7d, 0xbc, 0x04, 0x47, 0xef, 0x42, 0x95, 0xd3, 0xc0, 0x1f, 0x74, 0xc5, 0x2a, 0xd1,
+	0xda, 0x35, 0xfa, 0x34, 0xa7, 0xf9, 0xca, 0x84, 0xcd, 0xe3, 0x71, 0xd8, 0x17, 0xf1, 0x6e, 0x52,
+	0xac, 0x3c, 0x77, 0x75, 0xc5, 0x42, 0xf7, 0xa0, 0x2c, 0x76, 0x09, 0xa6, 0x39, 0x83, 0x99, 0x80,
+	0xa2, 0x0e, 0x3e, 0xf3, 0x64, 0x6b, 0xcc, 0x48, 0xa9, 0xc0, 0x84, 0x78, 0x4f, 0xe9, 0xe4, 0x69,
+	0xc0, 0xba, 0x7d, 0x6c, 0x35, 0xb4, 0x5d, 0x93, 0xa4, 0x6b, 0x21, 0xfc, 0x90, 0xb6, 0x7d, 0x2a,
+	0x7c, 0x85, 0xb8, 0xac, 0x84, 0x9f, 0x59, 0xa6, 0xab, 0x51, 0x59, 0x52, 0x0d, 0x15, 0xe6, 0x98,
+	0x33, 0xd6, 0xc3, 0xeb, 0xaa, 0x47, 0x92, 0xb5, 0x70, 0xc3, 0xe9, 0x90, 0x9d, 0x51, 0x17, 0xc3,
+	0xec, 0x2a, 0x25, 0x78, 0xb1, 0xa8, 0xd5, 0xe5, 0x45, 0x15, 0x4a, 0x4d, 0x22, 0x85, 0xb8, 0x26,
+	0x36, 0x90, 0xcc, 0xb0, 0x72, 0xd9, 0x6f, 0x42, 0x2d, 0x55, 0x4d, 0xe0, 0x4f, 0x9a, 0xcf, 0x0d,
+	0xd8, 0x7e, 0x46, 0x23, 0x35, 0xa7, 0xd2, 0x11, 0xf1, 0x51, 0x41, 0x75, 0x3b, 0x85, 0x11, 0x91,
+	0xa7, 0xaf, 0x50, 0x78, 0x1f, 0xc6, 0x4d, 0x6a, 0xca, 0x26, 0xbd, 0xb7, 0xf8, 0x58, 0x47, 0xcc,
+	0x7b, 0x3a, 0x8a, 0xf8, 0x44, 0xf5, 0xae, 0x3d, 0x84, 0x4a, 0x62, 0x41, 0x6f, 0x43, 0xc9, 0x67,
+	0xde, 0xfc, 0xf7, 0x44, 0xa1, 0x68, 0x07, 0x2c, 0xd6, 0xeb, 0x85, 0x34, 0xc2, 0x7a, 0xe1, 0x5c,
+	0xe2, 0x15, 0x88, 0x31, 0x74, 0x0b, 0x4a, 0xfe, 0x60, 0x38, 0x88, 0xe4, 0xe1, 0x4b, 0x44, 0x2d,
+	0x56, 0x5e, 0xd9, 0xbf, 0x34, 0xd8, 0x9a, 0xce, 0x81, 0x98, 0x69, 0xef, 0xe7, 0x66, 0xda, 0xdc,
+	0x2a, 0x06, 0xfe, 0xa4, 0x98, 0xac, 0x5f, 0xb4, 0xff, 0x9e, 0xad, 0xc7, 0xa2, 0x8f, 0xa4, 0x4b,
+	0xac, 0xcb, 0x80, 0x6f, 0x14, 0x3a, 0xbe, 0xa5, 0x22, 0x92, 0x84, 0x96, 0xcc, 0x07, 0x63, 0xc1,
+	0x7c, 0x40, 0x60, 0x0e, 0x19, 0x57, 0x0f, 0x5b, 0x85, 0xc8, 0xdf, 0xcd, 0x97, 0x06, 0xdc, 0x3a,
+	0x89, 0x38, 0x6d, 0x0f, 0x0b, 0xba, 0xfd, 0xa4, 0xa0, 0xdb, 0xfb, 0xd3, 0x2e, 0x67, 0xed, 0x58,
+	0xa1, 0x74, 0x3f, 0xce, 0x49, 0xf7, 0xc1, 0xd2, 0x93, 0xe5, 0x0b, 0x22, 0xf4, 0xd1, 0xed, 0x8f,
+	0x47, 0xa7, 0x27, 0x83, 0xef, 0xd5, 0xb7, 0x46, 0x89, 0x64, 0x06, 0xfb, 0x9b, 0xd7, 0xa4, 0xed,
+	0x95, 0xab, 0xf8, 0x57, 0x0d, 0x50, 0x21, 0x1d, 0x81, 0x7f, 0xe3, 0x3b, 0xbd, 0x16, 0x05, 0xee,
+	0x80, 0xd5, 0x1d, 0xf3, 0x90, 0x71, 0x6c, 0xce, 0x4a, 0x94, 0xc2, 0x9a, 0xcf, 0x75, 0xd8, 0x16,
+	0x93, 0x35, 0x0e, 0x70, 0x93, 0x41, 0x7a, 0x8d, 0xfe, 0x3f, 0xd5, 0x98, 0x26, 0xc9, 0x58, 0x98,
+	0xa4, 0x16, 0x58, 0xea, 0xf6, 0xf2, 0x3e, 0xf3, 0x73, 0x14, 0xb3, 0x56, 0x2e, 0x81, 0x6d, 0xd8,
+	0x9a, 0xce, 0x4c, 0xe0, 0x4f, 0xf6, 0xfe, 0xd1, 0xa1, 0x7c, 0xa2, 0x0e, 0x89, 0x9e, 0x40, 0x39,
+	0xfe, 0x6e, 0x43, 0xf6, 0xfc, 0x2f, 0x57, 0x1b, 0xcf, 0xc4, 0xc4, 0x93, 0xb7, 0x26, 0x5c, 0xc4,
+	0x8f, 0x60, 0xde, 0x45, 0xfe, 0x7b, 0xca, 0xc6, 0x33, 0x31, 0xe5, 0xe2, 0x73, 0x80, 0x6c, 0x82,
+	0xa2, 0xb7, 0x16, 0x3e, 0x44, 0xf6, 0xed, 0x05, 0x83, 0x57, 0xf9, 0xca, 0x2e, 0x9c, 0xf7, 0x75,
+	0x4d, 0x22, 0xf6, 0xed, 0x79, 0xb0, 0xf2, 0xf5, 0x35, 0x6c, 0xe4, 0xda, 0x07, 0x35, 0x96, 0x0d,
+	0x1a, 0xdb, 0x59, 0xc0, 0x90, 0x4e, 0x1f, 0x6b, 0xfb, 0x8d, 0xbf, 0xff, 0x74, 0xb4, 0xdf, 0x2f,
+	0x1d, 0xed, 0x8f, 0x4b, 0x47, 0x7b, 0x71, 0xe9, 0x68, 0xaf, 0x2e, 0x1d, 0xed, 0xc7, 0x2b, 0x67,
+	0xed, 0xc5, 0x95, 0xb3, 0xf6, 0xf2, 0xca, 0x59, 0xeb, 0x58, 0xf2, 0xdf, 0xda, 0x7b, 0xff, 0x0e,
+	0x00, 0xe4, 0xb4, 0x0b, 0xea, 0xf9, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -901,6 +1251,8 @@ type ServiceClient interface {
 	GetRecords(ctx context.Context, in *GetRecordsRequest, opts ...grpc.CallOption) (*GetRecordsReply, error)
 	// PushRecord to a peer.
 	PushRecord(ctx context.Context, in *PushRecordRequest, opts ...grpc.CallOption) (*PushRecordReply, error)
+	// StreamRecords from a peer in chunks.
+	StreamRecords(ctx context.Context, in *StreamRecordsRequest, opts ...grpc.CallOption) (Service_StreamRecordsClient, error)
 }
 
 type serviceClient struct {
@@ -947,6 +1299,38 @@ func (c *serviceClient) PushRecord(ctx context.Context, in *PushRecordRequest, o
 	return out, nil
 }
 
+func (c *serviceClient) StreamRecords(ctx context.Context, in *StreamRecordsRequest, opts ...grpc.CallOption) (Service_StreamRecordsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Service_serviceDesc.Streams[0], "/service.pb.Service/StreamRecords", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceStreamRecordsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_StreamRecordsClient interface {
+	Recv() (*StreamRecordsReply, error)
+	grpc.ClientStream
+}
+
+type serviceStreamRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceStreamRecordsClient) Recv() (*StreamRecordsReply, error) {
+	m := new(StreamRecordsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServiceServer is the server API for Service service.
 type ServiceServer interface {
 	// GetLogs from a peer.
@@ -957,6 +1341,28 @@ type ServiceServer interface {
 	GetRecords(context.Context, *GetRecordsRequest) (*GetRecordsReply, error)
 	// PushRecord to a peer.
 	PushRecord(context.Context, *PushRecordRequest) (*PushRecordReply, error)
+	// StreamRecords from a peer in chunks.
+	StreamRecords(*StreamRecordsRequest, Service_StreamRecordsServer) error
+}
+
+// UnimplementedServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedServiceServer struct {
+}
+
+func (*UnimplementedServiceServer) GetLogs(ctx context.Context, req *GetLogsRequest) (*GetLogsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (*UnimplementedServiceServer) PushLog(ctx context.Context, req *PushLogRequest) (*PushLogReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushLog not implemented")
+}
+func (*UnimplementedServiceServer) GetRecords(ctx context.Context, req *GetRecordsRequest) (*GetRecordsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecords not implemented")
+}
+func (*UnimplementedServiceServer) PushRecord(ctx context.Context, req *PushRecordRequest) (*PushRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushRecord not implemented")
+}
+func (*UnimplementedServiceServer) StreamRecords(req *StreamRecordsRequest, srv Service_StreamRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRecords not implemented")
 }
 
 func RegisterServiceServer(s *grpc.Server, srv ServiceServer) {
@@ -1035,6 +1441,27 @@ func _Service_PushRecord_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_StreamRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).StreamRecords(m, &serviceStreamRecordsServer{stream})
+}
+
+type Service_StreamRecordsServer interface {
+	Send(*StreamRecordsReply) error
+	grpc.ServerStream
+}
+
+type serviceStreamRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceStreamRecordsServer) Send(m *StreamRecordsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Service_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "service.pb.Service",
 	HandlerType: (*ServiceServer)(nil),
@@ -1056,14 +1483,20 @@ var _Service_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Service_PushRecord_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRecords",
+			Handler:       _Service_StreamRecords_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "service.proto",
 }
 
 func (m *Log) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1071,61 +1504,81 @@ func (m *Log) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *Log) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Log) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.ID != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.ID.Size()))
-		n1, err := m.ID.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.PubKey != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.PubKey.Size()))
-		n2, err := m.PubKey.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Heads) > 0 {
+		for iNdEx := len(m.Heads) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Heads[iNdEx].Size()
+				i -= size
+				if _, err := m.Heads[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
 		}
-		i += n2
 	}
 	if len(m.Addrs) > 0 {
-		for _, msg := range m.Addrs {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Addrs[iNdEx].Size()
+				i -= size
+				if _, err := m.Addrs[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
 			dAtA[i] = 0x1a
-			i++
-			i = encodeVarintService(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
+		}
+	}
+	if m.PubKey != nil {
+		{
+			size := m.PubKey.Size()
+			i -= size
+			if _, err := m.PubKey.MarshalTo(dAtA[i:]); err != nil {
 				return 0, err
 			}
-			i += n
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Heads) > 0 {
-		for _, msg := range m.Heads {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintService(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
+	if m.ID != nil {
+		{
+			size := m.ID.Size()
+			i -= size
+			if _, err := m.ID.MarshalTo(dAtA[i:]); err != nil {
 				return 0, err
 			}
-			i += n
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *Log_Record) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1133,41 +1586,50 @@ func (m *Log_Record) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *Log_Record) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Log_Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.RecordNode) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(len(m.RecordNode)))
-		i += copy(dAtA[i:], m.RecordNode)
-	}
-	if len(m.EventNode) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintService(dAtA, i, uint64(len(m.EventNode)))
-		i += copy(dAtA[i:], m.EventNode)
+	if len(m.BodyNode) > 0 {
+		i -= len(m.BodyNode)
+		copy(dAtA[i:], m.BodyNode)
+		i = encodeVarintService(dAtA, i, uint64(len(m.BodyNode)))
+		i--
+		dAtA[i] = 0x22
 	}
 	if len(m.HeaderNode) > 0 {
-		dAtA[i] = 0x1a
-		i++
+		i -= len(m.HeaderNode)
+		copy(dAtA[i:], m.HeaderNode)
 		i = encodeVarintService(dAtA, i, uint64(len(m.HeaderNode)))
-		i += copy(dAtA[i:], m.HeaderNode)
+		i--
+		dAtA[i] = 0x1a
 	}
-	if len(m.BodyNode) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintService(dAtA, i, uint64(len(m.BodyNode)))
-		i += copy(dAtA[i:], m.BodyNode)
+	if len(m.EventNode) > 0 {
+		i -= len(m.EventNode)
+		copy(dAtA[i:], m.EventNode)
+		i = encodeVarintService(dAtA, i, uint64(len(m.EventNode)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RecordNode) > 0 {
+		i -= len(m.RecordNode)
+		copy(dAtA[i:], m.RecordNode)
+		i = encodeVarintService(dAtA, i, uint64(len(m.RecordNode)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *GetLogsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1175,47 +1637,58 @@ func (m *GetLogsRequest) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetLogsRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Header.Size()))
-		n3, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if m.FollowKey != nil {
+		{
+			size := m.FollowKey.Size()
+			i -= size
+			if _, err := m.FollowKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n3
+		i--
+		dAtA[i] = 0x1a
 	}
 	if m.ThreadID != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.ThreadID.Size()))
-		n4, err := m.ThreadID.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n4
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.FollowKey != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.FollowKey.Size()))
-		n5, err := m.FollowKey.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n5
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *GetLogsRequest_Header) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1223,27 +1696,53 @@ func (m *GetLogsRequest_Header) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetLogsRequest_Header) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogsRequest_Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
+	if m.Key != nil {
+		{
+			size := m.Key.Size()
+			i -= size
+			if _, err := m.Key.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if m.From != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.From.Size()))
-		n6, err := m.From.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		{
+			size := m.From.Size()
+			i -= size
+			if _, err := m.From.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n6
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *GetLogsReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1251,29 +1750,62 @@ func (m *GetLogsReply) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetLogsReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Logs) > 0 {
-		for _, msg := range m.Logs {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintService(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
+	if len(m.Replicators) > 0 {
+		for iNdEx := len(m.Replicators) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Replicators[iNdEx].Size()
+				i -= size
+				if _, err := m.Replicators[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Creator != nil {
+		{
+			size := m.Creator.Size()
+			i -= size
+			if _, err := m.Creator.MarshalTo(dAtA[i:]); err != nil {
 				return 0, err
 			}
-			i += n
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *PushLogRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1281,67 +1813,150 @@ func (m *PushLogRequest) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *PushLogRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushLogRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Header.Size()))
-		n7, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.KeyProofs) > 0 {
+		for iNdEx := len(m.KeyProofs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.KeyProofs[iNdEx])
+			copy(dAtA[i:], m.KeyProofs[iNdEx])
+			i = encodeVarintService(dAtA, i, uint64(len(m.KeyProofs[iNdEx])))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if len(m.Replicators) > 0 {
+		for iNdEx := len(m.Replicators) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Replicators[iNdEx].Size()
+				i -= size
+				if _, err := m.Replicators[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
 		}
-		i += n7
 	}
-	if m.ThreadID != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.ThreadID.Size()))
-		n8, err := m.ThreadID.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Removed) > 0 {
+		for iNdEx := len(m.Removed) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Removed[iNdEx].Size()
+				i -= size
+				if _, err := m.Removed[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
 		}
-		i += n8
 	}
-	if m.FollowKey != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.FollowKey.Size()))
-		n9, err := m.FollowKey.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.KeyProof) > 0 {
+		i -= len(m.KeyProof)
+		copy(dAtA[i:], m.KeyProof)
+		i = encodeVarintService(dAtA, i, uint64(len(m.KeyProof)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Creator != nil {
+		{
+			size := m.Creator.Size()
+			i -= size
+			if _, err := m.Creator.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.SealedKeys) > 0 {
+		i -= len(m.SealedKeys)
+		copy(dAtA[i:], m.SealedKeys)
+		i = encodeVarintService(dAtA, i, uint64(len(m.SealedKeys)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.KeyEpoch != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.KeyEpoch))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Log != nil {
+		{
+			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n9
+		i--
+		dAtA[i] = 0x2a
 	}
 	if m.ReadKey != nil {
+		{
+			size := m.ReadKey.Size()
+			i -= size
+			if _, err := m.ReadKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
 		dAtA[i] = 0x22
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.ReadKey.Size()))
-		n10, err := m.ReadKey.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	}
+	if m.FollowKey != nil {
+		{
+			size := m.FollowKey.Size()
+			i -= size
+			if _, err := m.FollowKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n10
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.Log != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Log.Size()))
-		n11, err := m.Log.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n11
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *PushLogRequest_Header) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1349,27 +1964,53 @@ func (m *PushLogRequest_Header) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *PushLogRequest_Header) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushLogRequest_Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
+	if m.Key != nil {
+		{
+			size := m.Key.Size()
+			i -= size
+			if _, err := m.Key.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if m.From != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.From.Size()))
-		n12, err := m.From.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		{
+			size := m.From.Size()
+			i -= size
+			if _, err := m.From.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n12
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *PushLogReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1377,17 +2018,22 @@ func (m *PushLogReply) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *PushLogReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushLogReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *GetRecordsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1395,59 +2041,72 @@ func (m *GetRecordsRequest) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetRecordsRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Header.Size()))
-		n13, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n13
-	}
-	if m.ThreadID != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.ThreadID.Size()))
-		n14, err := m.ThreadID.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
 		}
-		i += n14
 	}
 	if m.FollowKey != nil {
+		{
+			size := m.FollowKey.Size()
+			i -= size
+			if _, err := m.FollowKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
 		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.FollowKey.Size()))
-		n15, err := m.FollowKey.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n15
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Logs) > 0 {
-		for _, msg := range m.Logs {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintService(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
-			i += n
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *GetRecordsRequest_LogEntry) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1455,42 +2114,51 @@ func (m *GetRecordsRequest_LogEntry) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetRecordsRequest_LogEntry) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsRequest_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.LogID != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.LogID.Size()))
-		n16, err := m.LogID.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n16
+	if m.Limit != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x18
 	}
 	if m.Offset != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Offset.Size()))
-		n17, err := m.Offset.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		{
+			size := m.Offset.Size()
+			i -= size
+			if _, err := m.Offset.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n17
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.Limit != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Limit))
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *GetRecordsRequest_Header) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1498,27 +2166,53 @@ func (m *GetRecordsRequest_Header) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetRecordsRequest_Header) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsRequest_Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.From != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.From.Size()))
-		n18, err := m.From.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n18
-	}
-	return i, nil
+	if m.Key != nil {
+		{
+			size := m.Key.Size()
+			i -= size
+			if _, err := m.Key.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.From != nil {
+		{
+			size := m.From.Size()
+			i -= size
+			if _, err := m.From.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
 func (m *GetRecordsReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1526,29 +2220,36 @@ func (m *GetRecordsReply) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetRecordsReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
 	if len(m.Logs) > 0 {
-		for _, msg := range m.Logs {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintService(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *GetRecordsReply_LogEntry) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1556,275 +2257,635 @@ func (m *GetRecordsReply_LogEntry) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetRecordsReply_LogEntry) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsReply_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.LogID != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.LogID.Size()))
-		n19, err := m.LogID.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if m.More {
+		i--
+		if m.More {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-		i += n19
+		i--
+		dAtA[i] = 0x20
 	}
-	if len(m.Records) > 0 {
-		for _, msg := range m.Records {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintService(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
+	if m.Log != nil {
+		{
+			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
-			i += n
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-	}
-	if m.Log != nil {
+		i--
 		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Log.Size()))
-		n20, err := m.Log.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	}
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
 		}
-		i += n20
 	}
-	return i, nil
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *PushRecordRequest) Marshal() (dAtA []byte, err error) {
+func (m *StreamRecordsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *PushRecordRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+func (m *StreamRecordsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamRecordsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Header.Size()))
-		n21, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n21
+	if m.ChunkSize != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.ChunkSize))
+		i--
+		dAtA[i] = 0x28
 	}
-	if m.ThreadID != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.ThreadID.Size()))
-		n22, err := m.ThreadID.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
 		}
-		i += n22
 	}
-	if m.LogID != nil {
+	if m.FollowKey != nil {
+		{
+			size := m.FollowKey.Size()
+			i -= size
+			if _, err := m.FollowKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
 		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.LogID.Size()))
-		n23, err := m.LogID.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n23
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.Record != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Record.Size()))
-		n24, err := m.Record.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n24
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
-func (m *PushRecordRequest_Header) Marshal() (dAtA []byte, err error) {
+func (m *StreamRecordsRequest_LogEntry) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *PushRecordRequest_Header) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+func (m *StreamRecordsRequest_LogEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamRecordsRequest_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.From != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.From.Size()))
-		n25, err := m.From.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if m.Offset != nil {
+		{
+			size := m.Offset.Size()
+			i -= size
+			if _, err := m.Offset.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n25
-	}
-	if len(m.Signature) > 0 {
+		i--
 		dAtA[i] = 0x12
-		i++
-		i = encodeVarintService(dAtA, i, uint64(len(m.Signature)))
-		i += copy(dAtA[i:], m.Signature)
 	}
-	if m.Key != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintService(dAtA, i, uint64(m.Key.Size()))
-		n26, err := m.Key.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
-		i += n26
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
-func (m *PushRecordReply) Marshal() (dAtA []byte, err error) {
+func (m *StreamRecordsRequest_Header) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *PushRecordReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+func (m *StreamRecordsRequest_Header) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamRecordsRequest_Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	return i, nil
-}
-
-func encodeVarintService(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func NewPopulatedLog(r randyService, easy bool) *Log {
-	this := &Log{}
-	this.ID = NewPopulatedProtoPeerID(r)
-	this.PubKey = NewPopulatedProtoPubKey(r)
-	v1 := r.Intn(10)
-	this.Addrs = make([]ProtoAddr, v1)
-	for i := 0; i < v1; i++ {
-		v2 := NewPopulatedProtoAddr(r)
-		this.Addrs[i] = *v2
+	if m.Key != nil {
+		{
+			size := m.Key.Size()
+			i -= size
+			if _, err := m.Key.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	v3 := r.Intn(10)
-	this.Heads = make([]ProtoCid, v3)
-	for i := 0; i < v3; i++ {
-		v4 := NewPopulatedProtoCid(r)
-		this.Heads[i] = *v4
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if !easy && r.Intn(10) != 0 {
+	if m.From != nil {
+		{
+			size := m.From.Size()
+			i -= size
+			if _, err := m.From.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return this
+	return len(dAtA) - i, nil
 }
 
-func NewPopulatedLog_Record(r randyService, easy bool) *Log_Record {
-	this := &Log_Record{}
-	v5 := r.Intn(100)
-	this.RecordNode = make([]byte, v5)
-	for i := 0; i < v5; i++ {
-		this.RecordNode[i] = byte(r.Intn(256))
-	}
-	v6 := r.Intn(100)
-	this.EventNode = make([]byte, v6)
-	for i := 0; i < v6; i++ {
-		this.EventNode[i] = byte(r.Intn(256))
-	}
-	v7 := r.Intn(100)
-	this.HeaderNode = make([]byte, v7)
-	for i := 0; i < v7; i++ {
-		this.HeaderNode[i] = byte(r.Intn(256))
-	}
-	v8 := r.Intn(100)
-	this.BodyNode = make([]byte, v8)
-	for i := 0; i < v8; i++ {
-		this.BodyNode[i] = byte(r.Intn(256))
-	}
-	if !easy && r.Intn(10) != 0 {
+func (m *StreamRecordsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedGetLogsRequest(r randyService, easy bool) *GetLogsRequest {
-	this := &GetLogsRequest{}
-	if r.Intn(10) != 0 {
-		this.Header = NewPopulatedGetLogsRequest_Header(r, easy)
-	}
-	this.ThreadID = NewPopulatedProtoThreadID(r)
-	this.FollowKey = NewPopulatedProtoKey(r)
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+func (m *StreamRecordsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func NewPopulatedGetLogsRequest_Header(r randyService, easy bool) *GetLogsRequest_Header {
-	this := &GetLogsRequest_Header{}
-	this.From = NewPopulatedProtoPeerID(r)
-	if !easy && r.Intn(10) != 0 {
+func (m *StreamRecordsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Cursor != nil {
+		{
+			size := m.Cursor.Size()
+			i -= size
+			if _, err := m.Cursor.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
 	}
-	return this
-}
-
-func NewPopulatedGetLogsReply(r randyService, easy bool) *GetLogsReply {
-	this := &GetLogsReply{}
-	if r.Intn(10) != 0 {
-		v9 := r.Intn(5)
-		this.Logs = make([]*Log, v9)
-		for i := 0; i < v9; i++ {
-			this.Logs[i] = NewPopulatedLog(r, easy)
+	if m.Log != nil {
+		{
+			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	if !easy && r.Intn(10) != 0 {
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	return this
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func NewPopulatedPushLogRequest(r randyService, easy bool) *PushLogRequest {
-	this := &PushLogRequest{}
-	if r.Intn(10) != 0 {
-		this.Header = NewPopulatedPushLogRequest_Header(r, easy)
-	}
-	this.ThreadID = NewPopulatedProtoThreadID(r)
-	this.FollowKey = NewPopulatedProtoKey(r)
-	this.ReadKey = NewPopulatedProtoKey(r)
-	if r.Intn(10) != 0 {
-		this.Log = NewPopulatedLog(r, easy)
-	}
-	if !easy && r.Intn(10) != 0 {
+func (m *PushRecordRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
+}
+
+func (m *PushRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushRecordRequest_Header) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushRecordRequest_Header) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushRecordRequest_Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Key != nil {
+		{
+			size := m.Key.Size()
+			i -= size
+			if _, err := m.Key.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.From != nil {
+		{
+			size := m.From.Size()
+			i -= size
+			if _, err := m.From.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushRecordReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushRecordReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintService(dAtA []byte, offset int, v uint64) int {
+	offset -= sovService(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func NewPopulatedLog(r randyService, easy bool) *Log {
+	this := &Log{}
+	this.ID = NewPopulatedProtoPeerID(r)
+	this.PubKey = NewPopulatedProtoPubKey(r)
+	v1 := r.Intn(10)
+	this.Addrs = make([]ProtoAddr, v1)
+	for i := 0; i < v1; i++ {
+		v2 := NewPopulatedProtoAddr(r)
+		this.Addrs[i] = *v2
+	}
+	v3 := r.Intn(10)
+	this.Heads = make([]ProtoCid, v3)
+	for i := 0; i < v3; i++ {
+		v4 := NewPopulatedProtoCid(r)
+		this.Heads[i] = *v4
+	}
+	v5 := r.Intn(100)
+	this.Signature = make([]byte, v5)
+	for i := 0; i < v5; i++ {
+		this.Signature[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedLog_Record(r randyService, easy bool) *Log_Record {
+	this := &Log_Record{}
+	v6 := r.Intn(100)
+	this.RecordNode = make([]byte, v6)
+	for i := 0; i < v6; i++ {
+		this.RecordNode[i] = byte(r.Intn(256))
+	}
+	v7 := r.Intn(100)
+	this.EventNode = make([]byte, v7)
+	for i := 0; i < v7; i++ {
+		this.EventNode[i] = byte(r.Intn(256))
+	}
+	v8 := r.Intn(100)
+	this.HeaderNode = make([]byte, v8)
+	for i := 0; i < v8; i++ {
+		this.HeaderNode[i] = byte(r.Intn(256))
+	}
+	v9 := r.Intn(100)
+	this.BodyNode = make([]byte, v9)
+	for i := 0; i < v9; i++ {
+		this.BodyNode[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogsRequest(r randyService, easy bool) *GetLogsRequest {
+	this := &GetLogsRequest{}
+	if r.Intn(5) != 0 {
+		this.Header = NewPopulatedGetLogsRequest_Header(r, easy)
+	}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.FollowKey = NewPopulatedProtoKey(r)
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogsRequest_Header(r randyService, easy bool) *GetLogsRequest_Header {
+	this := &GetLogsRequest_Header{}
+	this.From = NewPopulatedProtoPeerID(r)
+	v10 := r.Intn(100)
+	this.Signature = make([]byte, v10)
+	for i := 0; i < v10; i++ {
+		this.Signature[i] = byte(r.Intn(256))
+	}
+	this.Key = NewPopulatedProtoPubKey(r)
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogsReply(r randyService, easy bool) *GetLogsReply {
+	this := &GetLogsReply{}
+	if r.Intn(5) != 0 {
+		v11 := r.Intn(5)
+		this.Logs = make([]*Log, v11)
+		for i := 0; i < v11; i++ {
+			this.Logs[i] = NewPopulatedLog(r, easy)
+		}
+	}
+	this.Creator = NewPopulatedProtoPeerID(r)
+	v12 := r.Intn(10)
+	this.Replicators = make([]ProtoPeerID, v12)
+	for i := 0; i < v12; i++ {
+		v13 := NewPopulatedProtoPeerID(r)
+		this.Replicators[i] = *v13
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushLogRequest(r randyService, easy bool) *PushLogRequest {
+	this := &PushLogRequest{}
+	if r.Intn(5) != 0 {
+		this.Header = NewPopulatedPushLogRequest_Header(r, easy)
+	}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.FollowKey = NewPopulatedProtoKey(r)
+	this.ReadKey = NewPopulatedProtoKey(r)
+	if r.Intn(5) != 0 {
+		this.Log = NewPopulatedLog(r, easy)
+	}
+	this.KeyEpoch = uint64(uint64(r.Uint32()))
+	v14 := r.Intn(100)
+	this.SealedKeys = make([]byte, v14)
+	for i := 0; i < v14; i++ {
+		this.SealedKeys[i] = byte(r.Intn(256))
+	}
+	this.Creator = NewPopulatedProtoPeerID(r)
+	v15 := r.Intn(100)
+	this.KeyProof = make([]byte, v15)
+	for i := 0; i < v15; i++ {
+		this.KeyProof[i] = byte(r.Intn(256))
+	}
+	v16 := r.Intn(10)
+	this.Removed = make([]ProtoPeerID, v16)
+	for i := 0; i < v16; i++ {
+		v17 := NewPopulatedProtoPeerID(r)
+		this.Removed[i] = *v17
+	}
+	v18 := r.Intn(10)
+	this.Replicators = make([]ProtoPeerID, v18)
+	for i := 0; i < v18; i++ {
+		v19 := NewPopulatedProtoPeerID(r)
+		this.Replicators[i] = *v19
+	}
+	v20 := r.Intn(10)
+	this.KeyProofs = make([][]byte, v20)
+	for i := 0; i < v20; i++ {
+		v21 := r.Intn(100)
+		this.KeyProofs[i] = make([]byte, v21)
+		for j := 0; j < v21; j++ {
+			this.KeyProofs[i][j] = byte(r.Intn(256))
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
 }
 
 func NewPopulatedPushLogRequest_Header(r randyService, easy bool) *PushLogRequest_Header {
 	this := &PushLogRequest_Header{}
 	this.From = NewPopulatedProtoPeerID(r)
+	v22 := r.Intn(100)
+	this.Signature = make([]byte, v22)
+	for i := 0; i < v22; i++ {
+		this.Signature[i] = byte(r.Intn(256))
+	}
+	this.Key = NewPopulatedProtoPubKey(r)
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -1839,15 +2900,15 @@ func NewPopulatedPushLogReply(r randyService, easy bool) *PushLogReply {
 
 func NewPopulatedGetRecordsRequest(r randyService, easy bool) *GetRecordsRequest {
 	this := &GetRecordsRequest{}
-	if r.Intn(10) != 0 {
+	if r.Intn(5) != 0 {
 		this.Header = NewPopulatedGetRecordsRequest_Header(r, easy)
 	}
 	this.ThreadID = NewPopulatedProtoThreadID(r)
 	this.FollowKey = NewPopulatedProtoKey(r)
-	if r.Intn(10) != 0 {
-		v10 := r.Intn(5)
-		this.Logs = make([]*GetRecordsRequest_LogEntry, v10)
-		for i := 0; i < v10; i++ {
+	if r.Intn(5) != 0 {
+		v23 := r.Intn(5)
+		this.Logs = make([]*GetRecordsRequest_LogEntry, v23)
+		for i := 0; i < v23; i++ {
 			this.Logs[i] = NewPopulatedGetRecordsRequest_LogEntry(r, easy)
 		}
 	}
@@ -1872,6 +2933,12 @@ func NewPopulatedGetRecordsRequest_LogEntry(r randyService, easy bool) *GetRecor
 func NewPopulatedGetRecordsRequest_Header(r randyService, easy bool) *GetRecordsRequest_Header {
 	this := &GetRecordsRequest_Header{}
 	this.From = NewPopulatedProtoPeerID(r)
+	v24 := r.Intn(100)
+	this.Signature = make([]byte, v24)
+	for i := 0; i < v24; i++ {
+		this.Signature[i] = byte(r.Intn(256))
+	}
+	this.Key = NewPopulatedProtoPubKey(r)
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -1879,10 +2946,10 @@ func NewPopulatedGetRecordsRequest_Header(r randyService, easy bool) *GetRecords
 
 func NewPopulatedGetRecordsReply(r randyService, easy bool) *GetRecordsReply {
 	this := &GetRecordsReply{}
-	if r.Intn(10) != 0 {
-		v11 := r.Intn(5)
-		this.Logs = make([]*GetRecordsReply_LogEntry, v11)
-		for i := 0; i < v11; i++ {
+	if r.Intn(5) != 0 {
+		v25 := r.Intn(5)
+		this.Logs = make([]*GetRecordsReply_LogEntry, v25)
+		for i := 0; i < v25; i++ {
 			this.Logs[i] = NewPopulatedGetRecordsReply_LogEntry(r, easy)
 		}
 	}
@@ -1894,16 +2961,82 @@ func NewPopulatedGetRecordsReply(r randyService, easy bool) *GetRecordsReply {
 func NewPopulatedGetRecordsReply_LogEntry(r randyService, easy bool) *GetRecordsReply_LogEntry {
 	this := &GetRecordsReply_LogEntry{}
 	this.LogID = NewPopulatedProtoPeerID(r)
-	if r.Intn(10) != 0 {
-		v12 := r.Intn(5)
-		this.Records = make([]*Log_Record, v12)
-		for i := 0; i < v12; i++ {
+	if r.Intn(5) != 0 {
+		v26 := r.Intn(5)
+		this.Records = make([]*Log_Record, v26)
+		for i := 0; i < v26; i++ {
+			this.Records[i] = NewPopulatedLog_Record(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		this.Log = NewPopulatedLog(r, easy)
+	}
+	this.More = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedStreamRecordsRequest(r randyService, easy bool) *StreamRecordsRequest {
+	this := &StreamRecordsRequest{}
+	if r.Intn(5) != 0 {
+		this.Header = NewPopulatedStreamRecordsRequest_Header(r, easy)
+	}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.FollowKey = NewPopulatedProtoKey(r)
+	if r.Intn(5) != 0 {
+		v27 := r.Intn(5)
+		this.Logs = make([]*StreamRecordsRequest_LogEntry, v27)
+		for i := 0; i < v27; i++ {
+			this.Logs[i] = NewPopulatedStreamRecordsRequest_LogEntry(r, easy)
+		}
+	}
+	this.ChunkSize = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.ChunkSize *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedStreamRecordsRequest_LogEntry(r randyService, easy bool) *StreamRecordsRequest_LogEntry {
+	this := &StreamRecordsRequest_LogEntry{}
+	this.LogID = NewPopulatedProtoPeerID(r)
+	this.Offset = NewPopulatedProtoCid(r)
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedStreamRecordsRequest_Header(r randyService, easy bool) *StreamRecordsRequest_Header {
+	this := &StreamRecordsRequest_Header{}
+	this.From = NewPopulatedProtoPeerID(r)
+	v28 := r.Intn(100)
+	this.Signature = make([]byte, v28)
+	for i := 0; i < v28; i++ {
+		this.Signature[i] = byte(r.Intn(256))
+	}
+	this.Key = NewPopulatedProtoPubKey(r)
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedStreamRecordsReply(r randyService, easy bool) *StreamRecordsReply {
+	this := &StreamRecordsReply{}
+	this.LogID = NewPopulatedProtoPeerID(r)
+	if r.Intn(5) != 0 {
+		v29 := r.Intn(5)
+		this.Records = make([]*Log_Record, v29)
+		for i := 0; i < v29; i++ {
 			this.Records[i] = NewPopulatedLog_Record(r, easy)
 		}
 	}
-	if r.Intn(10) != 0 {
+	if r.Intn(5) != 0 {
 		this.Log = NewPopulatedLog(r, easy)
 	}
+	this.Cursor = NewPopulatedProtoCid(r)
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -1911,12 +3044,12 @@ func NewPopulatedGetRecordsReply_LogEntry(r randyService, easy bool) *GetRecords
 
 func NewPopulatedPushRecordRequest(r randyService, easy bool) *PushRecordRequest {
 	this := &PushRecordRequest{}
-	if r.Intn(10) != 0 {
+	if r.Intn(5) != 0 {
 		this.Header = NewPopulatedPushRecordRequest_Header(r, easy)
 	}
 	this.ThreadID = NewPopulatedProtoThreadID(r)
 	this.LogID = NewPopulatedProtoPeerID(r)
-	if r.Intn(10) != 0 {
+	if r.Intn(5) != 0 {
 		this.Record = NewPopulatedLog_Record(r, easy)
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -1927,9 +3060,9 @@ func NewPopulatedPushRecordRequest(r randyService, easy bool) *PushRecordRequest
 func NewPopulatedPushRecordRequest_Header(r randyService, easy bool) *PushRecordRequest_Header {
 	this := &PushRecordRequest_Header{}
 	this.From = NewPopulatedProtoPeerID(r)
-	v13 := r.Intn(100)
-	this.Signature = make([]byte, v13)
-	for i := 0; i < v13; i++ {
+	v30 := r.Intn(100)
+	this.Signature = make([]byte, v30)
+	for i := 0; i < v30; i++ {
 		this.Signature[i] = byte(r.Intn(256))
 	}
 	this.Key = NewPopulatedProtoPubKey(r)
@@ -1964,9 +3097,9 @@ func randUTF8RuneService(r randyService) rune {
 	return rune(ru + 61)
 }
 func randStringService(r randyService) string {
-	v14 := r.Intn(100)
-	tmps := make([]rune, v14)
-	for i := 0; i < v14; i++ {
+	v31 := r.Intn(100)
+	tmps := make([]rune, v31)
+	for i := 0; i < v31; i++ {
 		tmps[i] = randUTF8RuneService(r)
 	}
 	return string(tmps)
@@ -1988,11 +3121,11 @@ func randFieldService(dAtA []byte, r randyService, fieldNumber int, wire int) []
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateService(dAtA, uint64(key))
-		v15 := r.Int63()
+		v32 := r.Int63()
 		if r.Intn(2) == 0 {
-			v15 *= -1
+			v32 *= -1
 		}
-		dAtA = encodeVarintPopulateService(dAtA, uint64(v15))
+		dAtA = encodeVarintPopulateService(dAtA, uint64(v32))
 	case 1:
 		dAtA = encodeVarintPopulateService(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -2043,6 +3176,10 @@ func (m *Log) Size() (n int) {
 			n += 1 + l + sovService(uint64(l))
 		}
 	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
 	return n
 }
 
@@ -2102,9 +3239,17 @@ func (m *GetLogsRequest_Header) Size() (n int) {
 		l = m.From.Size()
 		n += 1 + l + sovService(uint64(l))
 	}
-	return n
-}
-
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Key != nil {
+		l = m.Key.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	return n
+}
+
 func (m *GetLogsReply) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2117,6 +3262,16 @@ func (m *GetLogsReply) Size() (n int) {
 			n += 1 + l + sovService(uint64(l))
 		}
 	}
+	if m.Creator != nil {
+		l = m.Creator.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if len(m.Replicators) > 0 {
+		for _, e := range m.Replicators {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -2146,6 +3301,39 @@ func (m *PushLogRequest) Size() (n int) {
 		l = m.Log.Size()
 		n += 1 + l + sovService(uint64(l))
 	}
+	if m.KeyEpoch != 0 {
+		n += 1 + sovService(uint64(m.KeyEpoch))
+	}
+	l = len(m.SealedKeys)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Creator != nil {
+		l = m.Creator.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.KeyProof)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if len(m.Removed) > 0 {
+		for _, e := range m.Removed {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if len(m.Replicators) > 0 {
+		for _, e := range m.Replicators {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if len(m.KeyProofs) > 0 {
+		for _, b := range m.KeyProofs {
+			l = len(b)
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -2159,6 +3347,14 @@ func (m *PushLogRequest_Header) Size() (n int) {
 		l = m.From.Size()
 		n += 1 + l + sovService(uint64(l))
 	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Key != nil {
+		l = m.Key.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
 	return n
 }
 
@@ -2228,6 +3424,14 @@ func (m *GetRecordsRequest_Header) Size() (n int) {
 		l = m.From.Size()
 		n += 1 + l + sovService(uint64(l))
 	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Key != nil {
+		l = m.Key.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
 	return n
 }
 
@@ -2266,6 +3470,104 @@ func (m *GetRecordsReply_LogEntry) Size() (n int) {
 		l = m.Log.Size()
 		n += 1 + l + sovService(uint64(l))
 	}
+	if m.More {
+		n += 2
+	}
+	return n
+}
+
+func (m *StreamRecordsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.FollowKey != nil {
+		l = m.FollowKey.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if m.ChunkSize != 0 {
+		n += 1 + sovService(uint64(m.ChunkSize))
+	}
+	return n
+}
+
+func (m *StreamRecordsRequest_LogEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Offset != nil {
+		l = m.Offset.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	return n
+}
+
+func (m *StreamRecordsRequest_Header) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.From != nil {
+		l = m.From.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Key != nil {
+		l = m.Key.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	return n
+}
+
+func (m *StreamRecordsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if m.Log != nil {
+		l = m.Log.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Cursor != nil {
+		l = m.Cursor.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
 	return n
 }
 
@@ -2319,25 +3621,1361 @@ func (m *PushRecordReply) Size() (n int) {
 	if m == nil {
 		return 0
 	}
-	var l int
-	_ = l
-	return n
-}
+	var l int
+	_ = l
+	return n
+}
+
+func sovService(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozService(x uint64) (n int) {
+	return sovService(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Log) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Log: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Log: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.ID = &v
+			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPubKey
+			m.PubKey = &v
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoAddr
+			m.Addrs = append(m.Addrs, v)
+			if err := m.Addrs[len(m.Addrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heads", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.Heads = append(m.Heads, v)
+			if err := m.Heads[len(m.Heads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Log_Record) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Record: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Record: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordNode = append(m.RecordNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.RecordNode == nil {
+				m.RecordNode = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventNode = append(m.EventNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.EventNode == nil {
+				m.EventNode = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HeaderNode = append(m.HeaderNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.HeaderNode == nil {
+				m.HeaderNode = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BodyNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BodyNode = append(m.BodyNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.BodyNode == nil {
+				m.BodyNode = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetLogsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetLogsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &GetLogsRequest_Header{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FollowKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.FollowKey = &v
+			if err := m.FollowKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogsRequest_Header) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Header: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Header: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.From = &v
+			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPubKey
+			m.Key = &v
+			if err := m.Key.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetLogsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetLogsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &Log{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.Creator = &v
+			if err := m.Creator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicators", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.Replicators = append(m.Replicators, v)
+			if err := m.Replicators[len(m.Replicators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PushLogRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PushLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &PushLogRequest_Header{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FollowKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.FollowKey = &v
+			if err := m.FollowKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.ReadKey = &v
+			if err := m.ReadKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Log == nil {
+				m.Log = &Log{}
+			}
+			if err := m.Log.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyEpoch", wireType)
+			}
+			m.KeyEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeyEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SealedKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SealedKeys = append(m.SealedKeys[:0], dAtA[iNdEx:postIndex]...)
+			if m.SealedKeys == nil {
+				m.SealedKeys = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.Creator = &v
+			if err := m.Creator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyProof", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyProof = append(m.KeyProof[:0], dAtA[iNdEx:postIndex]...)
+			if m.KeyProof == nil {
+				m.KeyProof = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.Removed = append(m.Removed, v)
+			if err := m.Removed[len(m.Removed)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicators", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.Replicators = append(m.Replicators, v)
+			if err := m.Replicators[len(m.Replicators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyProofs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyProofs = append(m.KeyProofs, make([]byte, postIndex-iNdEx))
+			copy(m.KeyProofs[len(m.KeyProofs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovService(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozService(x uint64) (n int) {
-	return sovService(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Log) Unmarshal(dAtA []byte) error {
+func (m *PushLogRequest_Header) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2360,15 +4998,15 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Log: wiretype end group for non-group")
+			return fmt.Errorf("proto: Header: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Log: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Header: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2396,14 +5034,14 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			var v ProtoPeerID
-			m.ID = &v
-			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.From = &v
+			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2430,15 +5068,14 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoPubKey
-			m.PubKey = &v
-			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2465,47 +5102,65 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoAddr
-			m.Addrs = append(m.Addrs, v)
-			if err := m.Addrs[len(m.Addrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoPubKey
+			m.Key = &v
+			if err := m.Key.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Heads", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthService
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoCid
-			m.Heads = append(m.Heads, v)
-			if err := m.Heads[len(m.Heads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushLogReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
 			}
-			iNdEx = postIndex
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PushLogReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PushLogReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -2530,7 +5185,7 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Log_Record) Unmarshal(dAtA []byte) error {
+func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2553,17 +5208,17 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Record: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRecordsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Record: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRecordsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RecordNode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -2573,29 +5228,31 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RecordNode = append(m.RecordNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.RecordNode == nil {
-				m.RecordNode = []byte{}
+			if m.Header == nil {
+				m.Header = &GetRecordsRequest_Header{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventNode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2622,14 +5279,15 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EventNode = append(m.EventNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.EventNode == nil {
-				m.EventNode = []byte{}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HeaderNode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FollowKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2656,16 +5314,17 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.HeaderNode = append(m.HeaderNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.HeaderNode == nil {
-				m.HeaderNode = []byte{}
+			var v ProtoKey
+			m.FollowKey = &v
+			if err := m.FollowKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BodyNode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -2675,24 +5334,24 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BodyNode = append(m.BodyNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.BodyNode == nil {
-				m.BodyNode = []byte{}
+			m.Logs = append(m.Logs, &GetRecordsRequest_LogEntry{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -2719,7 +5378,7 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
+func (m *GetRecordsRequest_LogEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2742,17 +5401,17 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetLogsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetLogsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -2762,31 +5421,30 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &GetLogsRequest_Header{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2813,17 +5471,17 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoThreadID
-			m.ThreadID = &v
-			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoCid
+			m.Offset = &v
+			if err := m.Offset.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FollowKey", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var byteLen int
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -2833,27 +5491,11 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthService
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthService
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v ProtoKey
-			m.FollowKey = &v
-			if err := m.FollowKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -2878,7 +5520,7 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetLogsRequest_Header) Unmarshal(dAtA []byte) error {
+func (m *GetRecordsRequest_Header) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2942,6 +5584,75 @@ func (m *GetLogsRequest_Header) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPubKey
+			m.Key = &v
+			if err := m.Key.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -2966,7 +5677,7 @@ func (m *GetLogsRequest_Header) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
+func (m *GetRecordsReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2989,10 +5700,10 @@ func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetLogsReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRecordsReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetLogsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRecordsReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3024,7 +5735,7 @@ func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, &Log{})
+			m.Logs = append(m.Logs, &GetRecordsReply_LogEntry{})
 			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
@@ -3053,7 +5764,7 @@ func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
+func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3076,86 +5787,15 @@ func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PushLogRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PushLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthService
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthService
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Header == nil {
-				m.Header = &PushLogRequest_Header{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthService
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthService
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v ProtoThreadID
-			m.ThreadID = &v
-			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FollowKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3182,17 +5822,17 @@ func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoKey
-			m.FollowKey = &v
-			if err := m.FollowKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -3202,28 +5842,27 @@ func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoKey
-			m.ReadKey = &v
-			if err := m.ReadKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Records = append(m.Records, &Log_Record{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
 			}
@@ -3259,64 +5898,11 @@ func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipService(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PushLogRequest_Header) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowService
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Header: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Header: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field More", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -3326,80 +5912,12 @@ func (m *PushLogRequest_Header) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthService
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthService
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v ProtoPeerID
-			m.From = &v
-			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipService(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PushLogReply) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowService
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PushLogReply: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PushLogReply: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.More = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -3424,7 +5942,7 @@ func (m *PushLogReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
+func (m *StreamRecordsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3447,10 +5965,10 @@ func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRecordsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: StreamRecordsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRecordsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StreamRecordsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3483,7 +6001,7 @@ func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &GetRecordsRequest_Header{}
+				m.Header = &StreamRecordsRequest_Header{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -3588,11 +6106,30 @@ func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, &GetRecordsRequest_LogEntry{})
+			m.Logs = append(m.Logs, &StreamRecordsRequest_LogEntry{})
 			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkSize", wireType)
+			}
+			m.ChunkSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -3617,7 +6154,7 @@ func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordsRequest_LogEntry) Unmarshal(dAtA []byte) error {
+func (m *StreamRecordsRequest_LogEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3716,25 +6253,6 @@ func (m *GetRecordsRequest_LogEntry) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
-			}
-			m.Limit = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Limit |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -3759,7 +6277,7 @@ func (m *GetRecordsRequest_LogEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordsRequest_Header) Unmarshal(dAtA []byte) error {
+func (m *StreamRecordsRequest_Header) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3823,64 +6341,45 @@ func (m *GetRecordsRequest_Header) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipService(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthService
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthService
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetRecordsReply) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowService
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetRecordsReply: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRecordsReply: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -3890,23 +6389,24 @@ func (m *GetRecordsReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, &GetRecordsReply_LogEntry{})
-			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoPubKey
+			m.Key = &v
+			if err := m.Key.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3934,7 +6434,7 @@ func (m *GetRecordsReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
+func (m *StreamRecordsReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3957,10 +6457,10 @@ func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: StreamRecordsReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StreamRecordsReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4068,6 +6568,41 @@ func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cursor", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.Cursor = &v
+			if err := m.Cursor.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -4500,6 +7035,7 @@ func (m *PushRecordReply) Unmarshal(dAtA []byte) error {
 func skipService(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -4531,10 +7067,8 @@ func skipService(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -4555,55 +7089,30 @@ func skipService(dAtA []byte) (n int, err error) {
 				return 0, ErrInvalidLengthService
 			}
 			iNdEx += length
-			if iNdEx < 0 {
-				return 0, ErrInvalidLengthService
-			}
-			return iNdEx, nil
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowService
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipService(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-				if iNdEx < 0 {
-					return 0, ErrInvalidLengthService
-				}
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupService
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthService
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthService = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowService   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthService        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowService          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupService = fmt.Errorf("proto: unexpected end of group")
 )
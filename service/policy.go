@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// policyKey is the thread metadata key of the peer policy.
+const policyKey = "policy"
+
+// GetPeerPolicy returns the peer policy of a thread.
+func (t *service) GetPeerPolicy(_ context.Context, id thread.ID) (thread.PeerPolicy, error) {
+	return t.getPolicy(id)
+}
+
+// SetPeerPolicy replaces the peer policy of a thread.
+func (t *service) SetPeerPolicy(_ context.Context, id thread.ID, policy thread.PeerPolicy) error {
+	if _, err := t.store.ThreadInfo(id); err != nil {
+		return err
+	}
+	data, err := cbor.MarshalPeerPolicy(policy)
+	if err != nil {
+		return err
+	}
+	return t.store.PutBytes(id, policyKey, data)
+}
+
+// getPolicy returns the peer policy of a thread.
+// Threads without a stored policy allow all peers.
+func (t *service) getPolicy(id thread.ID) (policy thread.PeerPolicy, err error) {
+	data, err := t.store.GetBytes(id, policyKey)
+	if err != nil || data == nil {
+		return
+	}
+	return cbor.UnmarshalPeerPolicy(*data)
+}
+
+// checkPeer returns core.ErrPeerNotAllowed if the peer policy of a thread
+// doesn't allow pid. The host itself is always allowed.
+func (t *service) checkPeer(id thread.ID, pid peer.ID) error {
+	if pid == t.host.ID() {
+		return nil
+	}
+	policy, err := t.getPolicy(id)
+	if err != nil {
+		return err
+	}
+	var logPeers []peer.ID
+	if policy.LogPeersOnly {
+		info, err := t.store.ThreadInfo(id)
+		if err != nil {
+			return err
+		}
+		for _, lg := range info.Logs {
+			logPeers = append(logPeers, addrPeers(lg.Addrs)...)
+		}
+	}
+	if !policy.Allows(pid, logPeers) {
+		return fmt.Errorf("%w: peer %s in thread %s", core.ErrPeerNotAllowed, pid, id)
+	}
+	return nil
+}
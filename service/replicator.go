@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+	pb "github.com/textileio/go-threads/service/pb"
+)
+
+// replicatorsKey is the thread metadata key of the peers known to be
+// replicators. Replicators are shared with the thread's peers, so that none of
+// them sends a replicator the read-key.
+const replicatorsKey = "replicators"
+
+// AddReplicator to a thread. Replicators are only sent the follow-key, so they
+// can store and serve the thread's logs but can't read record bodies.
+func (t *service) AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multiaddr) (peer.ID, error) {
+	return t.addFollower(ctx, id, paddr, true)
+}
+
+// checkReadKey returns an error if a read-key is given to a replicator.
+func (t *service) checkReadKey(rk *sym.Key) error {
+	if t.replicator && rk != nil {
+		return core.ErrReadKeyNotAllowed
+	}
+	return nil
+}
+
+// getReplicators returns the peers added as replicators to a thread.
+func (t *service) getReplicators(id thread.ID) (map[peer.ID]struct{}, error) {
+	replicators := make(map[peer.ID]struct{})
+	data, err := t.store.GetBytes(id, replicatorsKey)
+	if err != nil || data == nil {
+		return replicators, err
+	}
+	var pids []string
+	if err = cbornode.DecodeInto(*data, &pids); err != nil {
+		return nil, err
+	}
+	for _, p := range pids {
+		pid, err := peer.Decode(p)
+		if err != nil {
+			return nil, err
+		}
+		replicators[pid] = struct{}{}
+	}
+	return replicators, nil
+}
+
+// addReplicator remembers peers as replicators of a thread.
+func (t *service) addReplicator(id thread.ID, pids ...peer.ID) error {
+	replicators, err := t.getReplicators(id)
+	if err != nil {
+		return err
+	}
+	var changed bool
+	for _, pid := range pids {
+		if _, ok := replicators[pid]; !ok {
+			replicators[pid] = struct{}{}
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	strs := make([]string, 0, len(replicators))
+	for p := range replicators {
+		strs = append(strs, p.String())
+	}
+	data, err := cbornode.DumpObject(strs)
+	if err != nil {
+		return err
+	}
+	return t.store.PutBytes(id, replicatorsKey, data)
+}
+
+// sharedReplicators returns the replicators of a thread to share with its
+// peers, including the host if it's a replicator.
+func (t *service) sharedReplicators(id thread.ID) ([]pb.ProtoPeerID, error) {
+	replicators, err := t.getReplicators(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.replicator {
+		replicators[t.host.ID()] = struct{}{}
+	}
+	pids := make([]pb.ProtoPeerID, 0, len(replicators))
+	for pid := range replicators {
+		pids = append(pids, pb.ProtoPeerID{ID: pid})
+	}
+	return pids, nil
+}
+
+// putSharedReplicators remembers the replicators shared by a peer.
+// Marking a peer as a replicator only keeps read-keys from it.
+func (t *service) putSharedReplicators(id thread.ID, shared []pb.ProtoPeerID) error {
+	pids := make([]peer.ID, 0, len(shared))
+	for _, p := range shared {
+		if p.ID != t.host.ID() {
+			pids = append(pids, p.ID)
+		}
+	}
+	return t.addReplicator(id, pids...)
+}
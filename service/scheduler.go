@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// PullPolicy configures automatic thread pulls.
+// Threads with new records since their last pull are pulled every MinInterval.
+// Idle threads back off, doubling their interval up to MaxInterval.
+type PullPolicy struct {
+	// MinInterval is the pull interval of active threads.
+	MinInterval time.Duration
+
+	// MaxInterval is the pull interval of idle threads.
+	MaxInterval time.Duration
+
+	// Jitter randomly shortens or lengthens intervals by up to this fraction.
+	Jitter float64
+
+	// MaxConcurrent is the maximum number of threads pulled at once.
+	MaxConcurrent int
+}
+
+// DefaultPullPolicy returns the default pull policy.
+func DefaultPullPolicy() PullPolicy {
+	return PullPolicy{
+		MinInterval:   time.Second * 2,
+		MaxInterval:   time.Minute * 5,
+		Jitter:        0.2,
+		MaxConcurrent: 16,
+	}
+}
+
+// withDefaults returns the policy with zero values replaced by defaults.
+func (p PullPolicy) withDefaults() PullPolicy {
+	def := DefaultPullPolicy()
+	if p.MinInterval <= 0 {
+		p.MinInterval = def.MinInterval
+	}
+	if p.MaxInterval <= 0 {
+		p.MaxInterval = def.MaxInterval
+	}
+	if p.MaxInterval < p.MinInterval {
+		p.MaxInterval = p.MinInterval
+	}
+	if p.Jitter < 0 || p.Jitter >= 1 {
+		p.Jitter = def.Jitter
+	}
+	if p.MaxConcurrent <= 0 {
+		p.MaxConcurrent = def.MaxConcurrent
+	}
+	return p
+}
+
+// pullState is the schedule of a thread.
+type pullState struct {
+	next     time.Time
+	interval time.Duration
+	heads    string
+	pulling  bool
+	hinted   bool
+}
+
+// pullScheduler pulls each thread on its own schedule.
+type pullScheduler struct {
+	policy PullPolicy
+	pull   func(thread.ID) error
+	heads  func(thread.ID) (string, error)
+	now    func() time.Time // Replaced in tests
+
+	lock    sync.Mutex
+	threads map[thread.ID]*pullState
+	wake    chan struct{}
+	sem     chan struct{}
+}
+
+// newPullScheduler returns a scheduler that calls pull for each thread.
+// Heads returns a fingerprint of a thread's heads, which tells if a thread
+// was active since its last pull.
+func newPullScheduler(
+	policy PullPolicy,
+	pull func(thread.ID) error,
+	heads func(thread.ID) (string, error),
+) *pullScheduler {
+	policy = policy.withDefaults()
+	return &pullScheduler{
+		policy:  policy,
+		pull:    pull,
+		heads:   heads,
+		now:     time.Now,
+		threads: make(map[thread.ID]*pullState),
+		wake:    make(chan struct{}, 1),
+		sem:     make(chan struct{}, policy.MaxConcurrent),
+	}
+}
+
+// add schedules a thread's first pull after InitialPullInterval.
+// It's a no-op if the thread is already scheduled.
+func (s *pullScheduler) add(id thread.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.threads[id]; ok {
+		return
+	}
+	interval := s.clamp(PullInterval)
+	s.threads[id] = &pullState{
+		next:     s.now().Add(s.jitter(InitialPullInterval)),
+		interval: interval,
+	}
+	s.signal()
+}
+
+// remove stops pulling a thread.
+func (s *pullScheduler) remove(id thread.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.threads, id)
+}
+
+// hint pulls a thread as soon as possible, e.g., when a peer announces
+// records that aren't available locally. Threads that weren't added, or
+// were removed, are ignored.
+func (s *pullScheduler) hint(id thread.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st, ok := s.threads[id]
+	if !ok {
+		return
+	}
+	if st.pulling {
+		st.hinted = true
+		return
+	}
+	st.next = s.now()
+	s.signal()
+}
+
+// run pulls threads when they're due until ctx is done.
+func (s *pullScheduler) run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		wait := s.startDue(ctx)
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-timer.C:
+		case <-s.wake:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// startDue starts pulls of due threads and returns the wait until the next one.
+func (s *pullScheduler) startDue(ctx context.Context) time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	wait := s.policy.MaxInterval
+	for id, st := range s.threads {
+		if st.pulling {
+			continue
+		}
+		if st.next.After(now) {
+			if d := st.next.Sub(now); d < wait {
+				wait = d
+			}
+			continue
+		}
+		st.pulling = true
+		go s.pullThread(ctx, id)
+	}
+	return wait
+}
+
+// pullThread pulls a thread and schedules its next pull.
+func (s *pullScheduler) pullThread(ctx context.Context, id thread.ID) {
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return
+	}
+	if err := s.pull(id); err != nil {
+		log.Errorf("error pulling thread %s: %s", id.String(), err)
+	}
+	<-s.sem
+
+	heads, err := s.heads(id)
+	if err != nil {
+		log.Errorf("error getting heads of thread %s: %s", id.String(), err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st, ok := s.threads[id]
+	if !ok {
+		return
+	}
+	if err == nil && heads != st.heads {
+		st.interval = s.policy.MinInterval
+	} else {
+		st.interval = s.clamp(st.interval * 2)
+	}
+	st.heads = heads
+	st.pulling = false
+	if st.hinted {
+		st.hinted = false
+		st.next = s.now()
+	} else {
+		st.next = s.now().Add(s.jitter(st.interval))
+	}
+	s.signal()
+}
+
+// clamp returns d bounded by the policy's intervals.
+func (s *pullScheduler) clamp(d time.Duration) time.Duration {
+	if d < s.policy.MinInterval {
+		return s.policy.MinInterval
+	}
+	if d > s.policy.MaxInterval {
+		return s.policy.MaxInterval
+	}
+	return d
+}
+
+// jitter randomly shortens or lengthens d by up to the policy's jitter.
+func (s *pullScheduler) jitter(d time.Duration) time.Duration {
+	if s.policy.Jitter == 0 {
+		return d
+	}
+	f := 1 + s.policy.Jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(d) * f)
+}
+
+// signal wakes the run loop.
+func (s *pullScheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// threadHeads returns a fingerprint of the heads of a thread's logs.
+func (t *service) threadHeads(id thread.ID) (string, error) {
+	info, err := t.store.ThreadInfo(id)
+	if err != nil {
+		return "", err
+	}
+	var heads []string
+	for _, lg := range info.Logs {
+		for _, h := range lg.Heads {
+			heads = append(heads, lg.ID.String()+"/"+h.String())
+		}
+	}
+	sort.Strings(heads)
+	return strings.Join(heads, ","), nil
+}
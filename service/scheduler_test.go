@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestPullScheduler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("test adaptive intervals", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		active := thread.NewIDV1(thread.Raw, 32)
+		idle := thread.NewIDV1(thread.Raw, 32)
+		var lock sync.Mutex
+		pulls := make(map[thread.ID]int)
+		s := newPullScheduler(PullPolicy{
+			MinInterval:   time.Millisecond * 10,
+			MaxInterval:   time.Millisecond * 80,
+			MaxConcurrent: 1,
+		}, func(id thread.ID) error {
+			lock.Lock()
+			defer lock.Unlock()
+			pulls[id]++
+			return nil
+		}, func(id thread.ID) (string, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			if id == active {
+				return strconv.Itoa(pulls[id]), nil
+			}
+			return "", nil
+		})
+		clock := newTestClock(s)
+
+		s.add(active)
+		s.add(idle)
+		s.hint(active)
+		s.hint(idle)
+		for i := 0; i < 50; i++ {
+			s.startDue(ctx)
+			waitPulled(t, s)
+			clock.advance(s.policy.MinInterval)
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		if pulls[active] <= pulls[idle] {
+			t.Fatalf("expected active thread to be pulled more often, got %d and %d", pulls[active], pulls[idle])
+		}
+		if pulls[idle] == 0 {
+			t.Fatal("expected idle thread to be pulled")
+		}
+		s.lock.Lock()
+		interval := s.threads[idle].interval
+		s.lock.Unlock()
+		if interval != s.policy.MaxInterval {
+			t.Fatalf("expected idle thread to back off to %s, got %s", s.policy.MaxInterval, interval)
+		}
+	})
+
+	t.Run("test concurrency limit", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var lock sync.Mutex
+		var running, maxRunning int
+		release := make(chan struct{})
+		s := newPullScheduler(PullPolicy{
+			MinInterval:   time.Millisecond * 10,
+			MaxInterval:   time.Second,
+			MaxConcurrent: 2,
+		}, func(id thread.ID) error {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+			<-release
+			lock.Lock()
+			running--
+			lock.Unlock()
+			return nil
+		}, func(id thread.ID) (string, error) {
+			return "", nil
+		})
+		newTestClock(s)
+
+		for i := 0; i < 6; i++ {
+			id := thread.NewIDV1(thread.Raw, 32)
+			s.add(id)
+			s.hint(id)
+		}
+		s.startDue(ctx)
+		waitFor(t, func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return running == 2
+		})
+		close(release)
+		waitPulled(t, s)
+
+		lock.Lock()
+		defer lock.Unlock()
+		if maxRunning != 2 {
+			t.Fatalf("expected 2 concurrent pulls, got %d", maxRunning)
+		}
+	})
+
+	t.Run("test hint unknown thread", func(t *testing.T) {
+		s := newPullScheduler(PullPolicy{}, func(id thread.ID) error {
+			return nil
+		}, func(id thread.ID) (string, error) {
+			return "", nil
+		})
+
+		s.hint(thread.NewIDV1(thread.Raw, 32))
+		removed := thread.NewIDV1(thread.Raw, 32)
+		s.add(removed)
+		s.remove(removed)
+		s.hint(removed)
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if len(s.threads) != 0 {
+			t.Fatalf("expected hints to not schedule threads, got %d", len(s.threads))
+		}
+	})
+}
+
+// testClock is a scheduler clock that only moves when advanced.
+type testClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+// newTestClock replaces the clock of a scheduler.
+func newTestClock(s *pullScheduler) *testClock {
+	c := &testClock{now: time.Now()}
+	s.now = func() time.Time {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		return c.now
+	}
+	return c
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// waitPulled waits for the pulls started by a scheduler to finish.
+func waitPulled(t *testing.T, s *pullScheduler) {
+	waitFor(t, func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		for _, st := range s.threads {
+			if st.pulling {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// waitFor waits until cond is true.
+func waitFor(t *testing.T, cond func() bool) {
+	deadline := time.Now().Add(time.Second * 5)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -3,25 +3,34 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/status"
 	"github.com/ipfs/go-cid"
-	ic "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/service"
 	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/metrics"
 	pb "github.com/textileio/go-threads/service/pb"
+	"github.com/textileio/go-threads/tracing"
 	"google.golang.org/grpc/codes"
 )
 
 // server implements the service gRPC server.
 type server struct {
+	sync.Mutex
 	threads *service
 	pubsub  *pubsub.PubSub
+	topics  map[thread.ID]*pubsub.Topic
+	subs    map[thread.ID]*pubsub.Subscription
 }
 
 // newServer creates a new service network server.
@@ -29,8 +38,8 @@ func newServer(t *service) (*server, error) {
 	ps, err := pubsub.NewGossipSub(
 		t.ctx,
 		t.host,
-		pubsub.WithMessageSigning(false),
-		pubsub.WithStrictSignatureVerification(false))
+		pubsub.WithMessageSigning(true),
+		pubsub.WithStrictSignatureVerification(true))
 	if err != nil {
 		return nil, err
 	}
@@ -38,35 +47,43 @@ func newServer(t *service) (*server, error) {
 	s := &server{
 		threads: t,
 		pubsub:  ps,
+		topics:  make(map[thread.ID]*pubsub.Topic),
+		subs:    make(map[thread.ID]*pubsub.Subscription),
 	}
 
-	// @todo: clean up pubsub handling (we need to track the new-style topic handles)
-	//ts, err := t.store.Threads()
-	//if err != nil {
-	//	return nil, err
-	//}
-	//for _, id := range ts {
-	//	go s.subscribe(id)
-	//}
-
-	// @todo: ts.pubsub.RegisterTopicValidator()
+	ts, err := t.store.Threads()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ts {
+		s.subscribe(id)
+	}
 
 	return s, nil
 }
 
 // GetLogs receives a get logs request.
-// @todo: Verification
-func (s *server) GetLogs(_ context.Context, req *pb.GetLogsRequest) (*pb.GetLogsReply, error) {
-	if req.Header == nil {
-		return nil, status.Error(codes.FailedPrecondition, "request header is required")
+func (s *server) GetLogs(ctx context.Context, req *pb.GetLogsRequest) (*pb.GetLogsReply, error) {
+	ctx, span := tracing.StartServerSpan(ctx, tracer, "GetLogs")
+	defer span.End()
+
+	from, err := verifyRequest(ctx, req)
+	if err != nil {
+		return nil, err
 	}
-	log.Debugf("received get logs request from %s", req.Header.From.ID.String())
+	log.Debugf("received get logs request from %s", from.String())
 
 	pblgs := &pb.GetLogsReply{}
 
+	if err := s.checkPeer(req.ThreadID.ID, from); err != nil {
+		return pblgs, err
+	}
 	if err := s.checkFollowKey(req.ThreadID.ID, req.FollowKey); err != nil {
 		return pblgs, err
 	}
+	if err := s.checkACL(req.ThreadID.ID, thread.Reader, from); err != nil {
+		return pblgs, err
+	}
 
 	info, err := s.threads.store.ThreadInfo(req.ThreadID.ID) // Safe since putRecord will change head when fully-available
 	if err != nil {
@@ -76,68 +93,161 @@ func (s *server) GetLogs(_ context.Context, req *pb.GetLogsRequest) (*pb.GetLogs
 	pblgs.Logs = make([]*pb.Log, len(info.Logs))
 	for i, l := range info.Logs {
 		pblgs.Logs[i] = logToProto(l)
+		if err = signLog(req.ThreadID.ID, l, pblgs.Logs[i]); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	creator, err := s.threads.getCreator(req.ThreadID.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if creator != "" {
+		pblgs.Creator = &pb.ProtoPeerID{ID: creator}
+	}
+	if pblgs.Replicators, err = s.threads.sharedReplicators(req.ThreadID.ID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	log.Debugf("sending %d logs to %s", len(info.Logs), req.Header.From.ID.String())
+	log.Debugf("sending %d logs to %s", len(info.Logs), from.String())
 
 	return pblgs, nil
 }
 
 // PushLog receives a push log request.
-// @todo: Verification
-// @todo: Don't overwrite info from non-owners
-func (s *server) PushLog(_ context.Context, req *pb.PushLogRequest) (*pb.PushLogReply, error) {
-	if req.Header == nil {
-		return nil, status.Error(codes.FailedPrecondition, "request header is required")
+// Only the log's owner, who signs the log with its key, can change the
+// addresses of a known log. Logs relayed by other peers are only added if
+// they're unknown, and without addresses.
+func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushLogReply, error) {
+	ctx, span := tracing.StartServerSpan(ctx, tracer, "PushLog")
+	defer span.End()
+
+	from, err := verifyRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("received push log request from %s", from.String())
+
+	if err = s.checkPeer(req.ThreadID.ID, from); err != nil {
+		return nil, err
 	}
-	log.Debugf("received push log request from %s", req.Header.From.ID.String())
 
-	// Pick up missing keys
+	// Pick up missing or rotated keys
 	info, err := s.threads.store.ThreadInfo(req.ThreadID.ID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	if info.FollowKey == nil {
-		if req.FollowKey != nil && req.FollowKey.Key != nil {
-			if err = s.threads.store.AddFollowKey(req.ThreadID.ID, req.FollowKey.Key); err != nil {
-				return nil, status.Error(codes.Internal, err.Error())
-			}
-		} else {
-			return nil, status.Error(codes.NotFound, "thread not found")
+	var fk, rk *sym.Key
+	if req.SealedKeys != nil {
+		var epoch uint64
+		fk, rk, epoch, err = cbor.OpenKeys(req.SealedKeys, s.threads.getPrivKey())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if epoch != req.KeyEpoch {
+			return nil, status.Error(codes.InvalidArgument, "sealed key epoch does not match request")
+		}
+	} else if req.FollowKey != nil || req.ReadKey != nil {
+		if !s.threads.plaintextKeys {
+			return nil, status.Error(codes.InvalidArgument, "plaintext keys are not accepted")
+		}
+		log.Warnf("received plaintext keys from %s", from.String())
+		if req.FollowKey != nil {
+			fk = req.FollowKey.Key
+		}
+		if req.ReadKey != nil {
+			rk = req.ReadKey.Key
 		}
 	}
-	if info.ReadKey == nil {
-		if req.ReadKey != nil && req.ReadKey.Key != nil {
-			if err = s.threads.store.AddReadKey(req.ThreadID.ID, req.ReadKey.Key); err != nil {
-				return nil, status.Error(codes.Internal, err.Error())
-			}
+	if s.threads.replicator && rk != nil {
+		log.Debugf("replicator dropped read-key from %s", from.String())
+		rk = nil
+	}
+	if info.FollowKey == nil && fk == nil {
+		return nil, status.Error(codes.NotFound, "thread not found")
+	}
+	lg := logFromProto(req.Log)
+	if lg.PubKey == nil || !lg.ID.MatchesPublicKey(lg.PubKey) {
+		return nil, status.Error(codes.InvalidArgument, "log ID doesn't match its public key")
+	}
+	owner := from == lg.ID || verifyLog(req.ThreadID.ID, req.Log)
+	ids := []peer.ID{from}
+	if owner {
+		ids = append(ids, lg.ID)
+	}
+
+	if info.FollowKey == nil && req.Creator != nil {
+		if err = s.threads.putCreator(req.ThreadID.ID, req.Creator.ID); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	if fk != nil && info.FollowKey != nil && req.KeyEpoch > info.KeyEpoch {
+		if err = s.checkRotation(req, from, info.KeyEpoch, ids...); err != nil {
+			return nil, err
+		}
+	}
+	if fk != nil && (info.FollowKey == nil || req.KeyEpoch > info.KeyEpoch) {
+		removed := make([]peer.ID, len(req.Removed))
+		for i, r := range req.Removed {
+			removed[i] = r.ID
+		}
+		if err = s.threads.addRemoved(req.ThreadID.ID, removed...); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if err = s.threads.store.AddKeysAt(req.ThreadID.ID, req.KeyEpoch, fk, rk); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		s.subscribe(req.ThreadID.ID)
+		s.threads.puller.add(req.ThreadID.ID)
+	} else if rk != nil && info.ReadKey == nil && req.KeyEpoch == info.KeyEpoch {
+		if err = s.threads.store.AddReadKey(req.ThreadID.ID, rk); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
 		}
 	}
 
-	lg := logFromProto(req.Log)
-	err = s.threads.createExternalLogIfNotExist(req.ThreadID.ID, lg.ID, lg.PubKey, lg.PrivKey, lg.Addrs)
-	if err != nil {
+	if err = s.checkACL(req.ThreadID.ID, thread.Reader, ids...); err != nil {
+		return nil, err
+	}
+	if err = s.threads.putSharedReplicators(req.ThreadID.ID, req.Replicators); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err = s.threads.putExternalLog(req.ThreadID.ID, lg, owner); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	go s.threads.updateRecordsFromLog(req.ThreadID.ID, lg.ID)
+	go s.threads.updateRecordsFromLog(req.ThreadID.ID, lg.ID, from)
+
+	// Announced heads we don't have mean the thread is behind
+	if s.hasUnknown(lg.Heads) {
+		s.threads.puller.hint(req.ThreadID.ID)
+	}
 
 	return &pb.PushLogReply{}, nil
 }
 
 // GetRecords receives a get records request.
-// @todo: Verification
+// Records of each log are returned oldest first, up to the requested limit. Logs
+// with more records are marked, and can be continued from the last record.
 func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb.GetRecordsReply, error) {
-	if req.Header == nil {
-		return nil, status.Error(codes.FailedPrecondition, "request header is required")
+	ctx, span := tracing.StartServerSpan(ctx, tracer, "GetRecords")
+	defer span.End()
+
+	from, err := verifyRequest(ctx, req)
+	if err != nil {
+		return nil, err
 	}
-	log.Debugf("received get records request from %s", req.Header.From.ID.String())
+	log.Debugf("received get records request from %s", from.String())
 
 	pbrecs := &pb.GetRecordsReply{}
 
+	if err := s.checkPeer(req.ThreadID.ID, from); err != nil {
+		return pbrecs, err
+	}
 	if err := s.checkFollowKey(req.ThreadID.ID, req.FollowKey); err != nil {
 		return pbrecs, err
 	}
+	if err := s.checkACL(req.ThreadID.ID, thread.Reader, from); err != nil {
+		return pbrecs, err
+	}
 
 	reqd := make(map[peer.ID]*pb.GetRecordsRequest_LogEntry)
 	for _, l := range req.Logs {
@@ -160,8 +270,11 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 			offset = cid.Undef
 			limit = MaxPullLimit
 			pblg = logToProto(lg)
+			if err = signLog(req.ThreadID.ID, lg, pblg); err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
 		}
-		recs, err := s.threads.getLocalRecords(
+		recs, more, err := s.threads.getLocalRecords(
 			ctx,
 			req.ThreadID.ID,
 			lg.ID,
@@ -175,6 +288,7 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 			LogID:   &pb.ProtoPeerID{ID: lg.ID},
 			Records: make([]*pb.Log_Record, len(recs)),
 			Log:     pblg,
+			More:    more,
 		}
 		for j, r := range recs {
 			entry.Records[j], err = cbor.RecordToProto(ctx, s.threads, r)
@@ -184,27 +298,116 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 		}
 		pbrecs.Logs[i] = entry
 
-		log.Debugf("sending %d records in log %s to %s", len(recs), lg.ID.String(), req.Header.From.ID.String())
+		log.Debugf("sending %d records in log %s to %s", len(recs), lg.ID.String(), from.String())
 	}
 
 	return pbrecs, nil
 }
 
-// PushRecord receives a push record request.
-func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb.PushRecordReply, error) {
-	if req.Header == nil {
-		return nil, status.Error(codes.FailedPrecondition, "request header is required")
+// StreamRecords receives a stream records request.
+// Records of each log are sent oldest first in chunks of up to MaxStreamChunkSize.
+// Each chunk holds a cursor that can be used as an offset to resume the log.
+func (s *server) StreamRecords(req *pb.StreamRecordsRequest, stream pb.Service_StreamRecordsServer) error {
+	ctx, span := tracing.StartServerSpan(stream.Context(), tracer, "StreamRecords")
+	defer span.End()
+
+	from, err := verifyRequest(ctx, req)
+	if err != nil {
+		return err
 	}
-	log.Debugf("received push record request from %s", req.Header.From.ID.String())
+	log.Debugf("received stream records request from %s", from.String())
 
-	// Verify the request
-	reqpk, err := requestPubKey(req)
+	if err := s.checkPeer(req.ThreadID.ID, from); err != nil {
+		return err
+	}
+	if err := s.checkFollowKey(req.ThreadID.ID, req.FollowKey); err != nil {
+		return err
+	}
+	if err := s.checkACL(req.ThreadID.ID, thread.Reader, from); err != nil {
+		return err
+	}
+
+	size := int(req.ChunkSize)
+	if size <= 0 || size > MaxStreamChunkSize {
+		size = MaxStreamChunkSize
+	}
+	reqd := make(map[peer.ID]cid.Cid)
+	for _, l := range req.Logs {
+		reqd[l.LogID.ID] = l.Offset.Cid
+	}
+	info, err := s.threads.store.ThreadInfo(req.ThreadID.ID)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return status.Error(codes.Internal, err.Error())
 	}
-	err = verifyRequestSignature(req.Record, reqpk, req.Header.Signature)
+
+	for _, lg := range info.Logs {
+		offset, ok := reqd[lg.ID]
+		var pblg *pb.Log
+		if !ok {
+			pblg = logToProto(lg)
+			if err = signLog(req.ThreadID.ID, lg, pblg); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+		// Only record IDs are held, records are loaded a chunk at a time
+		ids, err := s.threads.getRecordIDsSince(ctx, req.ThreadID.ID, lg, offset)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if len(ids) == 0 && pblg == nil {
+			continue
+		}
+
+		log.Debugf("streaming %d records in log %s to %s", len(ids), lg.ID.String(), from.String())
+
+		for start := 0; start == 0 || start < len(ids); start += size {
+			end := start + size
+			if end > len(ids) {
+				end = len(ids)
+			}
+			recs, err := s.threads.loadRecords(ctx, req.ThreadID.ID, ids[start:end])
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			reply := &pb.StreamRecordsReply{
+				LogID:   &pb.ProtoPeerID{ID: lg.ID},
+				Records: make([]*pb.Log_Record, len(recs)),
+				Log:     pblg,
+				Cursor:  &pb.ProtoCid{Cid: offset},
+			}
+			for j, r := range recs {
+				reply.Records[j], err = cbor.RecordToProto(ctx, s.threads, r)
+				if err != nil {
+					return status.Error(codes.Internal, err.Error())
+				}
+			}
+			if end > start {
+				reply.Cursor.Cid = ids[end-1]
+			}
+			if err = stream.Send(reply); err != nil {
+				return err
+			}
+			pblg = nil
+		}
+	}
+	return nil
+}
+
+// PushRecord receives a push record request.
+func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb.PushRecordReply, error) {
+	ctx, span := tracing.StartServerSpan(ctx, tracer, "PushRecord")
+	defer span.End()
+
+	from, err := verifyRequest(ctx, req)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
+	}
+	log.Debugf("received push record request from %s", from.String())
+	metrics.RecordsReceived.Inc()
+
+	if err = s.checkPeer(req.ThreadID.ID, from); err != nil {
+		metrics.RecordsRejected.WithLabelValues("peer").Inc()
+		return nil, err
 	}
 
 	// A log is required to accept new records
@@ -215,12 +418,12 @@ func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb
 	if logpk == nil {
 		return nil, status.Error(codes.NotFound, "log not found")
 	}
-
-	key, err := s.threads.store.FollowKey(req.ThreadID.ID)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if err = s.checkACL(req.ThreadID.ID, thread.Writer, req.LogID.ID); err != nil {
+		metrics.RecordsRejected.WithLabelValues("unauthorized").Inc()
+		return nil, err
 	}
-	rec, err := cbor.RecordFromProto(req.Record, key)
+
+	rec, err := cbor.RecordFromProto(req.Record, s.threads.followKeys(req.ThreadID.ID))
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -234,24 +437,52 @@ func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb
 
 	// Verify node
 	if err = rec.Verify(logpk); err != nil {
+		metrics.RecordsRejected.WithLabelValues("signature").Inc()
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	// Unknown ancestors mean the thread is behind
+	if s.hasUnknown(rec.PrevIDs()) {
+		s.threads.puller.hint(req.ThreadID.ID)
+	}
+
 	if err = s.threads.PutRecord(ctx, req.ThreadID.ID, req.LogID.ID, rec); err != nil {
+		if errors.Is(err, core.ErrUnauthorized) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, core.ErrInvalidRecord) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &pb.PushRecordReply{}, nil
 }
 
-// subscribe to a thread for updates.
+// subscribe to a thread for updates. It's a no-op if already subscribed.
 func (s *server) subscribe(id thread.ID) {
-	sub, err := s.pubsub.Subscribe(id.String())
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.subs[id]; ok {
+		return
+	}
+	topic, err := s.joinTopic(id)
 	if err != nil {
 		log.Error(err)
 		return
 	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	s.subs[id] = sub
 
+	go s.receive(sub)
+}
+
+// receive handles push record requests from a thread subscription.
+func (s *server) receive(sub *pubsub.Subscription) {
 	for {
 		msg, err := sub.Next(s.threads.ctx)
 		if err != nil {
@@ -284,6 +515,116 @@ func (s *server) subscribe(id thread.ID) {
 	}
 }
 
+// unsubscribe from a thread's updates.
+func (s *server) unsubscribe(id thread.ID) {
+	s.Lock()
+	defer s.Unlock()
+	if sub, ok := s.subs[id]; ok {
+		sub.Cancel()
+		delete(s.subs, id)
+	}
+	if topic, ok := s.topics[id]; ok {
+		if err := topic.Close(); err != nil {
+			log.Warnf("closing topic %s: %s", id.String(), err)
+		}
+		if err := s.pubsub.UnregisterTopicValidator(id.String()); err != nil {
+			log.Warnf("unregistering validator for %s: %s", id.String(), err)
+		}
+		delete(s.topics, id)
+	}
+}
+
+// joinTopic returns the topic of a thread, joining it if needed.
+// Messages on the topic are checked with validateMessage before they're relayed.
+// The caller must hold the server lock.
+func (s *server) joinTopic(id thread.ID) (*pubsub.Topic, error) {
+	if topic, ok := s.topics[id]; ok {
+		return topic, nil
+	}
+	if err := s.pubsub.RegisterTopicValidator(id.String(), s.validateMessage(id)); err != nil {
+		return nil, err
+	}
+	topic, err := s.pubsub.Join(id.String())
+	if err != nil {
+		_ = s.pubsub.UnregisterTopicValidator(id.String())
+		return nil, err
+	}
+	s.topics[id] = topic
+	return topic, nil
+}
+
+// validateMessage returns a topic validator for a thread.
+// A message is accepted if it holds a push record request for the thread
+// that is signed by the message author, and a record signed by a known log.
+// The author must be allowed by the thread's peer policy.
+func (s *server) validateMessage(id thread.ID) pubsub.Validator {
+	return func(_ context.Context, _ peer.ID, msg *pubsub.Message) bool {
+		if err := s.checkMessage(id, msg); err != nil {
+			log.Debugf("rejecting message on %s: %s", id.String(), err)
+			metrics.PubsubMessages.WithLabelValues("rejected").Inc()
+			return false
+		}
+		metrics.PubsubMessages.WithLabelValues("accepted").Inc()
+		return true
+	}
+}
+
+// checkMessage returns an error if a message is not a valid push record request.
+func (s *server) checkMessage(id thread.ID, msg *pubsub.Message) error {
+	req := new(pb.PushRecordRequest)
+	if err := proto.Unmarshal(msg.Data, req); err != nil {
+		return err
+	}
+	if req.Header == nil || req.Header.From == nil || req.ThreadID == nil || req.LogID == nil || req.Record == nil {
+		return fmt.Errorf("incomplete request")
+	}
+	if !req.ThreadID.ID.Equals(id) {
+		return fmt.Errorf("request is for thread %s", req.ThreadID.ID.String())
+	}
+	author, err := peer.IDFromBytes(msg.From)
+	if err != nil {
+		return err
+	}
+	if author != req.Header.From.ID {
+		return fmt.Errorf("request is not from message author %s", author.String())
+	}
+	if err = s.threads.checkPeer(id, author); err != nil {
+		return err
+	}
+
+	// Verify the request
+	if _, err = verifyRequest(context.Background(), req); err != nil {
+		return err
+	}
+
+	// Verify the record with the log key
+	logpk, err := s.threads.store.PubKey(id, req.LogID.ID)
+	if err != nil {
+		return err
+	}
+	if logpk == nil {
+		return fmt.Errorf("log %s not found", req.LogID.ID.String())
+	}
+	rec, err := cbor.RecordFromProto(req.Record, s.threads.followKeys(id))
+	if err != nil {
+		return err
+	}
+	return rec.Verify(logpk)
+}
+
+// hasUnknown returns whether any of cids is not available locally.
+func (s *server) hasUnknown(cids []cid.Cid) bool {
+	for _, c := range cids {
+		if !c.Defined() {
+			continue
+		}
+		if has, err := s.threads.bstore.Has(c); err != nil || !has {
+			return true
+		}
+	}
+	return false
+}
+
 // checkFollowKey compares a key with the one stored under thread.
 func (s *server) checkFollowKey(id thread.ID, pfk *pb.ProtoKey) error {
 	if pfk == nil || pfk.Key == nil {
@@ -302,40 +643,54 @@ func (s *server) checkFollowKey(id thread.ID, pfk *pb.ProtoKey) error {
 	return nil
 }
 
-// requestPubKey returns the key associated with a request.
-func requestPubKey(r *pb.PushRecordRequest) (ic.PubKey, error) {
-	var pubk ic.PubKey
-	var err error
-	if r.Header.Key == nil {
-		// No attached key, it must be extractable from the source ID
-		pubk, err = r.Header.From.ID.ExtractPublicKey()
-		if err != nil {
-			return nil, fmt.Errorf("cannot extract signing key: %s", err)
-		}
-		if pubk == nil {
-			return nil, fmt.Errorf("cannot extract signing key")
-		}
-	} else {
-		pubk = r.Header.Key.PubKey
-		// Verify that the source ID matches the attached key
-		if !r.Header.From.ID.MatchesPublicKey(r.Header.Key.PubKey) {
-			return nil, fmt.Errorf(
-				"bad signing key; source ID %s doesn't match key",
-				r.Header.From.ID)
+// checkRotation returns an error if a push with keys of a newer epoch doesn't
+// prove knowledge of the follow-key of the epoch the host holds, or isn't from
+// a peer that can rotate keys. Hosts that missed rotations catch up this way.
+func (s *server) checkRotation(req *pb.PushLogRequest, from peer.ID, held uint64, ids ...peer.ID) error {
+	id := req.ThreadID.ID
+	prev, err := s.threads.store.FollowKeyAt(id, held)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if prev == nil {
+		return status.Errorf(codes.FailedPrecondition, "follow-key for epoch %d not found", held)
+	}
+	var proof []byte
+	if held < uint64(len(req.KeyProofs)) {
+		proof = req.KeyProofs[held]
+	} else if held == req.KeyEpoch-1 {
+		proof = req.KeyProof
+	}
+	if !hmac.Equal(proof, keyProof(prev, id, req.KeyEpoch, from)) {
+		return status.Error(codes.PermissionDenied, "invalid key proof")
+	}
+	if err = s.threads.checkRotator(id, ids...); err != nil {
+		if errors.Is(err, core.ErrUnauthorized) {
+			return status.Error(codes.PermissionDenied, err.Error())
 		}
+		return status.Error(codes.Internal, err.Error())
 	}
-	return pubk, nil
+	return nil
 }
 
-// verifyRequestSignature verifies that the signature assocated with a request is valid.
-func verifyRequestSignature(rec *pb.Log_Record, pk ic.PubKey, sig []byte) error {
-	payload, err := rec.Marshal()
-	if err != nil {
-		return err
+// checkACL returns an error if none of ids has at least role in the thread's access control list.
+func (s *server) checkACL(id thread.ID, role thread.Role, ids ...peer.ID) error {
+	if err := s.threads.checkACL(id, role, ids...); err != nil {
+		if errors.Is(err, core.ErrUnauthorized) {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
 	}
-	ok, err := pk.Verify(payload, sig)
-	if !ok || err != nil {
-		return fmt.Errorf("bad signature")
+	return nil
+}
+
+// checkPeer returns an error if the thread's peer policy doesn't allow pid.
+func (s *server) checkPeer(id thread.ID, pid peer.ID) error {
+	if err := s.threads.checkPeer(id, pid); err != nil {
+		if errors.Is(err, core.ErrPeerNotAllowed) {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
 	}
 	return nil
 }
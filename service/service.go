@@ -3,20 +3,27 @@ package service
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/ipfs/go-cid"
 	bs "github.com/ipfs/go-ipfs-blockstore"
+	cbornode "github.com/ipfs/go-ipld-cbor"
 	format "github.com/ipfs/go-ipld-format"
 	logging "github.com/ipfs/go-log"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/libp2p/go-libp2p-core/routing"
 	gostream "github.com/libp2p/go-libp2p-gostream"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/broadcast"
@@ -24,28 +31,94 @@ import (
 	lstore "github.com/textileio/go-threads/core/logstore"
 	core "github.com/textileio/go-threads/core/service"
 	"github.com/textileio/go-threads/core/thread"
+	tcrypto "github.com/textileio/go-threads/crypto"
 	sym "github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/metrics"
 	pb "github.com/textileio/go-threads/service/pb"
+	"github.com/textileio/go-threads/tracing"
 	"github.com/textileio/go-threads/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 )
 
 var (
-	log = logging.Logger("threadservice")
+	log    = logging.Logger("threadservice")
+	tracer = tracing.Tracer("threadservice")
 
 	// MaxPullLimit is the maximum page size for pulling records.
 	MaxPullLimit = 10000
 
-	// InitialPullInterval is the interval between automatic log pulls.
+	// MaxStreamChunkSize is the maximum number of records in a streamed chunk.
+	MaxStreamChunkSize = 100
+
+	// InitialPullInterval is the delay before the first automatic pull of a thread.
 	InitialPullInterval = time.Second
 
-	// PullInterval is the interval between automatic log pulls.
+	// PullInterval is the interval between automatic pulls of a new thread,
+	// which then adapts to the thread's activity within the PullPolicy bounds.
 	PullInterval = time.Second * 10
 
+	// PushRetryInterval is the interval between checks for undelivered records.
+	PushRetryInterval = time.Second * 5
+
+	// MinPushBackoff is the delay before the first retry of an undelivered record.
+	MinPushBackoff = time.Second * 10
+
+	// MaxPushBackoff is the maximum delay between retries of an undelivered record.
+	MaxPushBackoff = time.Hour
+
+	// MaxConcurrentPushes is the maximum number of undelivered records retried at once.
+	MaxConcurrentPushes = 16
+
+	// MaxPushAttempts is the number of failed deliveries after which an
+	// undelivered record is dropped from the outbox.
+	MaxPushAttempts = 20
+
+	// OutboxTTL is the duration after the first failed delivery after which
+	// an undelivered record is dropped from the outbox.
+	OutboxTTL = time.Hour * 24 * 7
+
+	// MaxOutboxPeerEntries is the maximum number of undelivered records kept
+	// for a peer across threads. The oldest are dropped first.
+	MaxOutboxPeerEntries = 1000
+
+	// MaxOutboxEntries is the maximum number of undelivered records kept.
+	// The oldest are dropped first.
+	MaxOutboxEntries = 10000
+
+	// MaxCausalPending is the maximum number of records a causal subscription
+	// holds back while waiting for the records they depend on.
+	MaxCausalPending = 1000
+
+	// MaxCausalWait is the maximum duration a causal subscription holds back a
+	// record. Dependencies that never arrive, e.g., records that were rejected
+	// or belong to removed logs, don't hold up later records for longer.
+	MaxCausalWait = time.Minute
+
+	// WalkCacheSize is the maximum number of record ancestries and log
+	// listings that are cached, so that pulls and pages don't walk the whole
+	// history of a log each time.
+	WalkCacheSize = 256
+
+	// DefaultInviteTTL is the lifetime of an invite created without a ttl.
+	DefaultInviteTTL = time.Hour * 24 * 7
+
 	// notifyTimeout is the duration to wait for a subscriber to read a new record.
 	notifyTimeout = time.Second * 5
 )
 
+const (
+	// aclKey is the thread metadata key of the current access control list.
+	aclKey = "acl"
+
+	// creatorKey is the thread metadata key of the ID of the creator's log.
+	creatorKey = "creator"
+
+	// removedKey is the thread metadata key of the logs removed by key rotations.
+	removedKey = "removed"
+)
+
 // service is an implementation of core.Service.
 type service struct {
 	format.DAGService
@@ -63,11 +136,73 @@ type service struct {
 
 	pullLock  sync.Mutex
 	pullLocks map[thread.ID]chan struct{}
+
+	subsLock sync.Mutex
+	subs     map[thread.ID]int
+
+	// gcLock is read-held while blocks are written and linked from logs,
+	// and held by the garbage collector while it lists blocks.
+	gcLock    sync.RWMutex
+	gcRunLock sync.Mutex
+
+	// gcWrites holds the blocks written while a collection runs, which it
+	// won't remove. It's nil while no collection runs.
+	gcWritesLock sync.Mutex
+	gcWrites     map[cid.Cid]struct{}
+
+	connected chan peer.ID
+	notifiee  network.Notifiee
+	puller    *pullScheduler
+
+	validators       []core.RecordValidator
+	threadValidators sync.Map // thread.ID -> core.RecordValidator
+
+	headClocks sync.Map // thread.ID -> map[cid.Cid]uint64
+
+	ancestors *lru.ARCCache // cid.Cid -> map[cid.Cid]struct{}
+	listings  *lru.ARCCache // listingKey -> []cid.Cid
+
+	replicator    bool
+	plaintextKeys bool
+	algorithm     sym.Algorithm
+
+	routing routing.ContentRouting
 }
 
 // Config is used to specify thread instance options.
 type Config struct {
 	Debug bool
+
+	// RecordValidators are applied to records from other peers on all threads.
+	RecordValidators []core.RecordValidator
+
+	// PullPolicy controls automatic thread pulls. Zero values use defaults
+	// from DefaultPullPolicy.
+	PullPolicy PullPolicy
+
+	// GCInterval is the interval between blockstore garbage collections.
+	// Zero disables scheduled collections.
+	GCInterval time.Duration
+
+	// Replicator stores and serves threads without read-keys. Read-keys
+	// given to a replicator are refused or dropped.
+	Replicator bool
+
+	// PlaintextKeys allows sending thread keys in plaintext to peers whose
+	// keys can't be sealed to, e.g., peers without Ed25519 keys, and accepting
+	// them from such peers. Plaintext keys can be read by anyone who can read
+	// the connection. Without it, keys are only exchanged sealed.
+	PlaintextKeys bool
+
+	// Algorithm is the cipher records are encrypted with, unless a thread
+	// sets its own. Zero uses symmetric.DefaultAlgorithm. Peers that predate
+	// cipher envelopes can't read records.
+	Algorithm sym.Algorithm
+
+	// Routing is used to announce the threads the host follows and to find
+	// their peers when all known addresses fail, e.g., a DHT. Nil disables
+	// discovery.
+	Routing routing.ContentRouting
 }
 
 // NewService creates an instance of service from the given host and thread store.
@@ -81,6 +216,9 @@ func NewService(
 	opts ...grpc.ServerOption,
 ) (core.Service, error) {
 	var err error
+	if err = checkAlgorithm(conf.Algorithm); err != nil {
+		return nil, err
+	}
 	if conf.Debug {
 		if err = util.SetLogLevels(map[string]logging.LogLevel{
 			"threadservice": logging.LevelDebug,
@@ -90,18 +228,36 @@ func NewService(
 		}
 	}
 
+	metrics.RegisterService()
+
 	ctx, cancel := context.WithCancel(ctx)
 	t := &service{
-		DAGService: ds,
-		host:       h,
-		bstore:     bstore,
-		store:      ls,
-		rpc:        grpc.NewServer(opts...),
-		bus:        broadcast.NewBroadcaster(0),
-		ctx:        ctx,
-		cancel:     cancel,
-		pullLocks:  make(map[thread.ID]chan struct{}),
+		DAGService:    ds,
+		host:          h,
+		bstore:        bstore,
+		store:         ls,
+		rpc:           grpc.NewServer(opts...),
+		bus:           broadcast.NewBroadcaster(0),
+		ctx:           ctx,
+		cancel:        cancel,
+		pullLocks:     make(map[thread.ID]chan struct{}),
+		subs:          make(map[thread.ID]int),
+		connected:     make(chan peer.ID, 16),
+		validators:    conf.RecordValidators,
+		replicator:    conf.Replicator,
+		plaintextKeys: conf.PlaintextKeys,
+		algorithm:     conf.Algorithm,
+		routing:       conf.Routing,
+	}
+	if t.ancestors, err = lru.NewARC(WalkCacheSize); err != nil {
+		return nil, err
+	}
+	if t.listings, err = lru.NewARC(WalkCacheSize); err != nil {
+		return nil, err
 	}
+	t.puller = newPullScheduler(conf.PullPolicy, func(id thread.ID) error {
+		return t.PullThread(t.ctx, id)
+	}, t.threadHeads)
 	t.server, err = newServer(t)
 	if err != nil {
 		return nil, err
@@ -116,7 +272,24 @@ func NewService(
 		t.rpc.Serve(listener)
 	}()
 
-	go t.startPulling()
+	ts, err := ls.Threads()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ts {
+		t.puller.add(id)
+	}
+	go t.puller.run(t.ctx)
+
+	t.notifiee = t.notifyConnected()
+	h.Network().Notify(t.notifiee)
+	go t.startPushing()
+	if conf.GCInterval > 0 {
+		go t.startGC(conf.GCInterval)
+	}
+	if conf.Routing != nil {
+		go t.startProviding()
+	}
 
 	return t, nil
 }
@@ -124,6 +297,7 @@ func NewService(
 // Close the service instance.
 func (t *service) Close() (err error) {
 	t.rpc.GracefulStop()
+	t.host.Network().StopNotify(t.notifiee)
 
 	var errs []error
 	weakClose := func(name string, c interface{}) {
@@ -160,6 +334,14 @@ func (t *service) Host() host.Host {
 	return t.host
 }
 
+// Get fetches a node from the DAG, which may fetch its block from peers.
+func (t *service) Get(ctx context.Context, c cid.Cid) (format.Node, error) {
+	ctx, span := tracer.Start(ctx, "dag.Get", trace.WithAttributes(attribute.String("cid", c.String())))
+	n, err := t.DAGService.Get(ctx, c)
+	tracing.End(span, err)
+	return n, err
+}
+
 // Store returns the threadstore.
 func (t *service) Store() lstore.Logstore {
 	return t.store
@@ -171,11 +353,19 @@ func (t *service) GetHostID(context.Context) (peer.ID, error) {
 }
 
 // CreateThread with id.
-func (t *service) CreateThread(_ context.Context, id thread.ID, opts ...core.KeyOption) (info thread.Info, err error) {
+// Access controlled threads start with an access control list that makes
+// the host and its log admins.
+func (t *service) CreateThread(ctx context.Context, id thread.ID, opts ...core.KeyOption) (info thread.Info, err error) {
 	args := &core.KeyOptions{}
 	for _, opt := range opts {
 		opt(args)
 	}
+	if err = t.checkReadKey(args.ReadKey); err != nil {
+		return
+	}
+	if err = checkAlgorithm(args.Algorithm); err != nil {
+		return
+	}
 
 	info = thread.Info{
 		ID:        id,
@@ -198,10 +388,32 @@ func (t *service) CreateThread(_ context.Context, id thread.ID, opts ...core.Key
 	if err = t.store.AddLog(id, linfo); err != nil {
 		return
 	}
+	if err = t.putCreator(id, linfo.ID); err != nil {
+		return
+	}
+	if err = t.setupAlgorithm(id, args.Algorithm); err != nil {
+		return
+	}
+	if err = t.setupDiscovery(id, args.NoDiscovery); err != nil {
+		return
+	}
+	t.server.subscribe(id)
+	t.puller.add(id)
+	if id.Variant() == thread.AccessControlled {
+		if _, err = t.createACLRecord(ctx, id, linfo, thread.ACL{
+			Roles: map[peer.ID]thread.Role{
+				linfo.ID:    thread.Admin,
+				t.host.ID(): thread.Admin,
+			},
+		}); err != nil {
+			return
+		}
+	}
 	return t.store.ThreadInfo(id)
 }
 
-// AddThread from a multiaddress.
+// AddThread from a multiaddress. Keys of earlier epochs given with PastKeys
+// are kept, so that records written before the last rotation can be read.
 func (t *service) AddThread(
 	ctx context.Context,
 	addr ma.Multiaddr,
@@ -211,6 +423,17 @@ func (t *service) AddThread(
 	for _, opt := range opts {
 		opt(args)
 	}
+	if err = t.checkReadKey(args.ReadKey); err != nil {
+		return
+	}
+	for _, keys := range args.PastKeys {
+		if err = t.checkReadKey(keys.ReadKey); err != nil {
+			return
+		}
+	}
+	if err = checkAlgorithm(args.Algorithm); err != nil {
+		return
+	}
 
 	idstr, err := addr.ValueForProtocol(thread.Code)
 	if err != nil {
@@ -236,9 +459,18 @@ func (t *service) AddThread(
 		ID:        id,
 		FollowKey: args.FollowKey,
 		ReadKey:   args.ReadKey,
+		KeyEpoch:  args.KeyEpoch,
 	}); err != nil {
 		return
 	}
+	for epoch, keys := range args.PastKeys {
+		if epoch >= args.KeyEpoch || keys.FollowKey == nil {
+			continue
+		}
+		if err = t.store.AddKeysAt(id, epoch, keys.FollowKey, keys.ReadKey); err != nil {
+			return
+		}
+	}
 
 	threadMultiaddr, err := ma.NewComponent("thread", idstr)
 	if err != nil {
@@ -252,10 +484,13 @@ func (t *service) AddThread(
 	if err = t.Host().Connect(ctx, *addri); err != nil {
 		return
 	}
-	lgs, err := t.server.getLogs(ctx, id, pid)
+	lgs, creator, err := t.server.getLogs(ctx, id, pid)
 	if err != nil {
 		return
 	}
+	if err = t.putCreator(id, creator); err != nil {
+		return
+	}
 
 	// @todo: ensure not overwrite with newer info from owner?
 	for _, l := range lgs {
@@ -263,12 +498,15 @@ func (t *service) AddThread(
 			return
 		}
 	}
-
-	go func() {
-		if err := t.PullThread(t.ctx, id); err != nil {
-			log.Errorf("error pulling thread %s: %s", id.String(), err)
-		}
-	}()
+	if err = t.setupAlgorithm(id, args.Algorithm); err != nil {
+		return
+	}
+	if err = t.setupDiscovery(id, args.NoDiscovery); err != nil {
+		return
+	}
+	t.server.subscribe(id)
+	t.puller.add(id)
+	t.puller.hint(id)
 
 	return t.store.ThreadInfo(id)
 }
@@ -299,7 +537,10 @@ func (t *service) PullThread(ctx context.Context, id thread.ID) error {
 	ptl := t.getThreadSemaphore(id)
 	select {
 	case ptl <- struct{}{}:
+		start := time.Now()
 		err := t.pullThread(ctx, id)
+		metrics.ObserveSince(metrics.PullDuration, start)
+		metrics.Pulls.WithLabelValues(metrics.Result(err)).Inc()
 		if err != nil {
 			<-ptl
 			return err
@@ -313,7 +554,10 @@ func (t *service) PullThread(ctx context.Context, id thread.ID) error {
 
 // pullThread for new records. It's internal and *not* thread-safe,
 // it assumes we currently own the thread-lock.
-func (t *service) pullThread(ctx context.Context, id thread.ID) error {
+func (t *service) pullThread(ctx context.Context, id thread.ID) (err error) {
+	ctx, span := tracer.Start(ctx, "pullThread", trace.WithAttributes(attribute.String("thread", id.String())))
+	defer func() { tracing.End(span, err) }()
+
 	info, err := t.store.ThreadInfo(id)
 	if err != nil {
 		return err
@@ -333,155 +577,497 @@ func (t *service) pullThread(ctx context.Context, id thread.ID) error {
 			}
 		}
 	}
-	var fetchedRcs []map[peer.ID][]core.Record
+
+	// Records from all logs are streamed from each log's addresses
+	skipped := make(map[peer.ID]struct{})
+	put := func(lid peer.ID, recs []core.Record) error {
+		if _, ok := skipped[lid]; ok {
+			return nil
+		}
+		for _, r := range recs {
+			if err := t.putRecord(ctx, id, lid, r); err != nil {
+				if errors.Is(err, core.ErrUnauthorized) || errors.Is(err, core.ErrInvalidRecord) {
+					log.Warnf("skipping records from log %s: %s", lid, err)
+					skipped[lid] = struct{}{}
+					return nil
+				}
+				return err
+			}
+		}
+		return nil
+	}
+	if t.streamLogs(ctx, id, info.Logs, offsets, put) || len(t.knownPeers(info.Logs)) == 0 {
+		return nil
+	}
+
+	// Every known address failed, look for other peers of the thread
+	found, err := t.discover(ctx, id)
+	if err != nil {
+		log.Warnf("error discovering peers of thread %s: %s", id, err)
+		return nil
+	}
+	if found > 0 {
+		t.streamLogs(ctx, id, info.Logs, offsets, put)
+	}
+	return nil
+}
+
+// streamLogs streams records from the addresses of each log to put.
+// It returns whether or not any address could be streamed from.
+func (t *service) streamLogs(
+	ctx context.Context,
+	id thread.ID,
+	lgs []thread.LogInfo,
+	offsets map[peer.ID]cid.Cid,
+	put func(lid peer.ID, recs []core.Record) error,
+) bool {
+	var lock sync.Mutex
+	var reached bool
 	wg := sync.WaitGroup{}
-	for _, lg := range info.Logs {
+	for _, lg := range lgs {
 		wg.Add(1)
 		go func(lg thread.LogInfo) {
 			defer wg.Done()
-			// Pull from addresses
-			recs, err := t.server.getRecords(
-				ctx,
-				id,
-				lg.ID,
-				offsets,
-				MaxPullLimit)
+			ok, err := t.server.streamRecords(ctx, id, lg.ID, offsets, func(lid peer.ID, recs []core.Record) error {
+				lock.Lock()
+				defer lock.Unlock()
+				return put(lid, recs)
+			})
 			if err != nil {
 				log.Error(err)
-				return
 			}
-			fetchedRcs = append(fetchedRcs, recs)
+			lock.Lock()
+			reached = reached || ok
+			lock.Unlock()
 		}(lg)
 	}
 	wg.Wait()
-	for _, recs := range fetchedRcs {
-		for lid, rs := range recs {
-			for _, r := range rs {
-				if err = t.putRecord(ctx, id, lid, r); err != nil {
-					log.Error(err)
-					return err
-				}
-			}
+	return reached
+}
+
+// DeleteThread with id.
+// Threads with active subscriptions cannot be deleted.
+func (t *service) DeleteThread(ctx context.Context, id thread.ID, opts ...core.DeleteOption) error {
+	args := &core.DeleteOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	// Wait for any pulls or puts on this thread to finish
+	tsph := t.getThreadSemaphore(id)
+	tsph <- struct{}{}
+	defer func() { <-tsph }()
+
+	// Hold off new subscriptions until the thread is gone
+	t.subsLock.Lock()
+	defer t.subsLock.Unlock()
+	if t.subs[id] > 0 {
+		return core.ErrThreadInUse
+	}
+
+	info, err := t.store.ThreadInfo(id)
+	if err != nil {
+		return err
+	}
+	if info.FollowKey == nil {
+		return fmt.Errorf("thread not found")
+	}
+
+	log.Debugf("deleting thread %s...", id.String())
+
+	t.server.unsubscribe(id)
+	t.puller.remove(id)
+
+	// Blocks must be collected while the keys are still available
+	if args.Blocks {
+		if err = t.deleteBlocks(ctx, info); err != nil {
+			return err
 		}
 	}
+	if err = t.store.DeleteThread(id); err != nil {
+		return err
+	}
+	t.headClocks.Delete(id)
 
+	t.pullLock.Lock()
+	delete(t.pullLocks, id)
+	t.pullLock.Unlock()
 	return nil
 }
 
-// Delete a thread (@todo).
-func (t *service) DeleteThread(context.Context, thread.ID) error {
-	panic("implement me")
+// deleteBlocks removes all locally available record, event, header, and body
+// blocks from the thread's logs.
+func (t *service) deleteBlocks(ctx context.Context, info thread.Info) error {
+	var cids []cid.Cid
+	for _, lg := range info.Logs {
+		recs, err := t.walkLog(ctx, info.ID, lg.Heads, t.missing, 0)
+		if err != nil {
+			return err
+		}
+		for _, r := range recs {
+			rcids, err := t.localRecordBlocks(ctx, r)
+			if err != nil {
+				return err
+			}
+			cids = append(cids, rcids...)
+		}
+	}
+	return t.RemoveMany(ctx, cids)
 }
 
-// AddFollower to a thread.
-func (t *service) AddFollower(ctx context.Context, id thread.ID, paddr ma.Multiaddr) (pid peer.ID, err error) {
-	info, err := t.store.ThreadInfo(id)
+// GetKeysAt returns the follow and read keys of a thread at a key epoch.
+func (t *service) GetKeysAt(_ context.Context, id thread.ID, epoch uint64) (*sym.Key, *sym.Key, error) {
+	fk, err := t.store.FollowKeyAt(id, epoch)
 	if err != nil {
-		return
+		return nil, nil, err
 	}
-	if info.FollowKey == nil {
-		return pid, fmt.Errorf("thread not found")
+	rk, err := t.store.ReadKeyAt(id, epoch)
+	if err != nil {
+		return nil, nil, err
 	}
+	return fk, rk, nil
+}
 
-	// Extract peer portion
-	p2p, err := paddr.ValueForProtocol(ma.P_P2P)
+// RotateKeys replaces the follow and read keys of a thread with new keys
+// under a new key epoch, and sends them to the peers of the remaining logs.
+// Removed logs lose their addresses, as do the peers that host them, so they
+// won't receive the new keys or any new records. Replicators only receive
+// the new follow-key. Peers that can't be reached get the keys of the latest
+// epoch from the outbox later.
+func (t *service) RotateKeys(ctx context.Context, id thread.ID, opts ...core.RotateOption) (info thread.Info, err error) {
+	args := &core.RotateOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	tsph := t.getThreadSemaphore(id)
+	tsph <- struct{}{}
+	defer func() { <-tsph }()
+
+	info, err = t.store.ThreadInfo(id)
 	if err != nil {
 		return
 	}
-	pid, err = peer.Decode(p2p)
-	if err != nil {
+	if info.FollowKey == nil {
+		return info, fmt.Errorf("thread not found")
+	}
+	if info.ReadKey == nil {
+		return info, fmt.Errorf("a read-key is required to rotate keys")
+	}
+	ids := []peer.ID{t.host.ID()}
+	if own := info.GetOwnLog(); own != nil {
+		ids = append(ids, own.ID)
+	}
+	if err = t.checkRotator(id, ids...); err != nil {
 		return
 	}
 
-	// Update local addresses
-	addr, err := ma.NewMultiaddr("/" + ma.ProtocolWithCode(ma.P_P2P).Name + "/" + p2p)
-	if err != nil {
-		return
+	// Collect the peers hosting removed logs
+	removed := make(map[peer.ID]struct{})
+	for _, lid := range args.Remove {
+		removed[lid] = struct{}{}
 	}
-	ownlg, err := t.getOwnLog(id)
+	removedPeers := make(map[peer.ID]struct{})
+	for _, lg := range info.Logs {
+		if _, ok := removed[lg.ID]; !ok {
+			continue
+		}
+		if lg.PrivKey != nil {
+			return info, fmt.Errorf("cannot remove own log %s", lg.ID)
+		}
+		for _, pid := range addrPeers(lg.Addrs) {
+			removedPeers[pid] = struct{}{}
+		}
+	}
+	delete(removedPeers, t.host.ID())
+
+	fk, err := sym.CreateKey()
 	if err != nil {
 		return
 	}
-	if err = t.store.AddAddr(id, ownlg.ID, addr, pstore.PermanentAddrTTL); err != nil {
+	rk, err := sym.CreateKey()
+	if err != nil {
 		return
 	}
-	info, err = t.store.ThreadInfo(id) // Update info
-	if err != nil {
+	epoch := info.KeyEpoch + 1
+	if err = t.store.AddKeysAt(id, epoch, fk, rk); err != nil {
 		return
 	}
 
-	// Update peerstore address
-	dialable, err := getDialable(paddr)
-	if err == nil {
-		t.host.Peerstore().AddAddr(pid, dialable, pstore.PermanentAddrTTL)
+	log.Debugf("rotated keys of thread %s to epoch %d", id, epoch)
+
+	if err = t.addRemoved(id, args.Remove...); err != nil {
+		return
 	}
 
-	// Send all logs to the new follower
-	for _, l := range info.Logs {
-		if err = t.server.pushLog(ctx, id, l, pid, info.FollowKey, nil); err != nil {
-			if err := t.store.SetAddrs(id, ownlg.ID, ownlg.Addrs, pstore.PermanentAddrTTL); err != nil {
-				log.Errorf("error rolling back log address change: %s", err)
+	// Drop addresses of removed logs and peers
+	for _, lg := range info.Logs {
+		if _, ok := removed[lg.ID]; ok {
+			if err = t.store.ClearAddrs(id, lg.ID); err != nil {
+				return
+			}
+			continue
+		}
+		addrs := make([]ma.Multiaddr, 0, len(lg.Addrs))
+		for _, addr := range lg.Addrs {
+			pids := addrPeers([]ma.Multiaddr{addr})
+			if len(pids) > 0 {
+				if _, ok := removedPeers[pids[0]]; ok {
+					continue
+				}
+			}
+			addrs = append(addrs, addr)
+		}
+		if len(addrs) != len(lg.Addrs) {
+			if err = t.store.SetAddrs(id, lg.ID, addrs, pstore.PermanentAddrTTL); err != nil {
+				return
 			}
-			return
 		}
 	}
+	if err = t.dropPushes(id, removedPeers); err != nil {
+		return
+	}
 
-	// Send the updated log to peers
+	// Send the new keys to the remaining peers, without the read-key for replicators
+	ownlg, err := t.getOrCreateOwnLog(id)
+	if err != nil {
+		return
+	}
+	info, err = t.store.ThreadInfo(id)
+	if err != nil {
+		return
+	}
+	replicators, err := t.getReplicators(id)
+	if err != nil {
+		return
+	}
 	var addrs []ma.Multiaddr
 	for _, l := range info.Logs {
 		addrs = append(addrs, l.Addrs...)
 	}
-
 	wg := sync.WaitGroup{}
-	for _, addr := range addrs {
+	for _, pid := range addrPeers(addrs) {
+		if pid.String() == t.host.ID().String() {
+			continue
+		}
+		prk := rk
+		if _, ok := replicators[pid]; ok {
+			prk = nil
+		}
 		wg.Add(1)
-		go func(addr ma.Multiaddr) {
+		go func(pid peer.ID, rk *sym.Key) {
 			defer wg.Done()
-			p, err := addr.ValueForProtocol(ma.P_P2P)
-			if err != nil {
-				log.Error(err)
-				return
-			}
-			pid, err := peer.Decode(p)
-			if err != nil {
-				log.Error(err)
-				return
-			}
-			if pid.String() == t.host.ID().String() {
-				return
-			}
-
-			if err = t.server.pushLog(ctx, id, ownlg, pid, nil, nil); err != nil {
-				log.Errorf("error pushing log %s to %s", ownlg.ID, p)
+			if err := t.server.pushLog(ctx, id, ownlg, pid, fk, rk, epoch); err != nil {
+				log.Errorf("error pushing rotated keys to %s: %s", pid, err)
+				if err := t.enqueuePush(id, ownlg.ID, cid.Undef, pid, err); err != nil {
+					log.Errorf("error queuing rotated keys for %s: %s", pid, err)
+				}
 			}
-		}(addr)
+		}(pid, prk)
 	}
-
 	wg.Wait()
-	return pid, nil
-}
 
-func getDialable(addr ma.Multiaddr) (ma.Multiaddr, error) {
-	parts := strings.Split(addr.String(), "/"+ma.ProtocolWithCode(ma.P_P2P).Name)
-	return ma.NewMultiaddr(parts[0])
+	return info, nil
 }
 
-// CreateRecord with body.
-func (t *service) CreateRecord(ctx context.Context, id thread.ID, body format.Node) (r core.ThreadRecord, err error) {
-	// Get or create a log for the new node
-	lg, err := t.getOrCreateOwnLog(id)
-	if err != nil {
-		return
-	}
-
-	// Write a record locally
-	rec, err := t.createRecord(ctx, id, lg, body)
-	if err != nil {
-		return
+// getRemoved returns the logs removed from a thread by key rotations.
+func (t *service) getRemoved(id thread.ID) (map[peer.ID]struct{}, error) {
+	removed := make(map[peer.ID]struct{})
+	data, err := t.store.GetBytes(id, removedKey)
+	if err != nil || data == nil {
+		return removed, err
 	}
-
-	// Update head
-	if err = t.store.SetHead(id, lg.ID, rec.Cid()); err != nil {
+	var lids []string
+	if err = cbornode.DecodeInto(*data, &lids); err != nil {
+		return nil, err
+	}
+	for _, l := range lids {
+		lid, err := peer.Decode(l)
+		if err != nil {
+			return nil, err
+		}
+		removed[lid] = struct{}{}
+	}
+	return removed, nil
+}
+
+// addRemoved remembers logs as removed from a thread. New records from
+// removed logs are rejected.
+func (t *service) addRemoved(id thread.ID, lids ...peer.ID) error {
+	if len(lids) == 0 {
+		return nil
+	}
+	removed, err := t.getRemoved(id)
+	if err != nil {
+		return err
+	}
+	for _, lid := range lids {
+		removed[lid] = struct{}{}
+	}
+	strs := make([]string, 0, len(removed))
+	for l := range removed {
+		strs = append(strs, l.String())
+	}
+	data, err := cbornode.DumpObject(strs)
+	if err != nil {
+		return err
+	}
+	return t.store.PutBytes(id, removedKey, data)
+}
+
+// addrPeers returns the unique peer IDs found in addrs.
+func addrPeers(addrs []ma.Multiaddr) []peer.ID {
+	set := make(map[peer.ID]struct{})
+	var pids []peer.ID
+	for _, addr := range addrs {
+		p, err := addr.ValueForProtocol(ma.P_P2P)
+		if err != nil {
+			continue
+		}
+		pid, err := peer.Decode(p)
+		if err != nil {
+			continue
+		}
+		if _, ok := set[pid]; !ok {
+			set[pid] = struct{}{}
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+// AddFollower to a thread.
+func (t *service) AddFollower(ctx context.Context, id thread.ID, paddr ma.Multiaddr) (peer.ID, error) {
+	return t.addFollower(ctx, id, paddr, false)
+}
+
+// addFollower sends the thread's logs and follow-key to a peer, and adds the
+// peer's address to the own log. Replicators are remembered so that they're
+// never sent read-keys.
+func (t *service) addFollower(ctx context.Context, id thread.ID, paddr ma.Multiaddr, replicator bool) (pid peer.ID, err error) {
+	info, err := t.store.ThreadInfo(id)
+	if err != nil {
+		return
+	}
+	if info.FollowKey == nil {
+		return pid, fmt.Errorf("thread not found")
+	}
+
+	// Extract peer portion
+	p2p, err := paddr.ValueForProtocol(ma.P_P2P)
+	if err != nil {
+		return
+	}
+	pid, err = peer.Decode(p2p)
+	if err != nil {
+		return
+	}
+
+	if replicator {
+		if err = t.addReplicator(id, pid); err != nil {
+			return
+		}
+	}
+
+	// Update local addresses
+	addr, err := ma.NewMultiaddr("/" + ma.ProtocolWithCode(ma.P_P2P).Name + "/" + p2p)
+	if err != nil {
+		return
+	}
+	ownlg, err := t.getOwnLog(id)
+	if err != nil {
+		return
+	}
+	if err = t.store.AddAddr(id, ownlg.ID, addr, pstore.PermanentAddrTTL); err != nil {
+		return
+	}
+	info, err = t.store.ThreadInfo(id) // Update info
+	if err != nil {
+		return
+	}
+
+	// Update peerstore address
+	dialable, err := getDialable(paddr)
+	if err == nil {
+		t.host.Peerstore().AddAddr(pid, dialable, pstore.PermanentAddrTTL)
+	}
+
+	// Send all logs to the new follower
+	for _, l := range info.Logs {
+		if err = t.server.pushLog(ctx, id, l, pid, info.FollowKey, nil, info.KeyEpoch); err != nil {
+			if err := t.store.SetAddrs(id, ownlg.ID, ownlg.Addrs, pstore.PermanentAddrTTL); err != nil {
+				log.Errorf("error rolling back log address change: %s", err)
+			}
+			return
+		}
+	}
+
+	// Send the updated log to peers
+	var addrs []ma.Multiaddr
+	for _, l := range info.Logs {
+		addrs = append(addrs, l.Addrs...)
+	}
+
+	wg := sync.WaitGroup{}
+	for _, addr := range addrs {
+		wg.Add(1)
+		go func(addr ma.Multiaddr) {
+			defer wg.Done()
+			p, err := addr.ValueForProtocol(ma.P_P2P)
+			if err != nil {
+				log.Error(err)
+				return
+			}
+			pid, err := peer.Decode(p)
+			if err != nil {
+				log.Error(err)
+				return
+			}
+			if pid.String() == t.host.ID().String() {
+				return
+			}
+
+			if err = t.server.pushLog(ctx, id, ownlg, pid, nil, nil, 0); err != nil {
+				log.Errorf("error pushing log %s to %s", ownlg.ID, p)
+			}
+		}(addr)
+	}
+
+	wg.Wait()
+	return pid, nil
+}
+
+func getDialable(addr ma.Multiaddr) (ma.Multiaddr, error) {
+	parts := strings.Split(addr.String(), "/"+ma.ProtocolWithCode(ma.P_P2P).Name)
+	return ma.NewMultiaddr(parts[0])
+}
+
+// CreateRecord with body.
+func (t *service) CreateRecord(
+	ctx context.Context,
+	id thread.ID,
+	body format.Node,
+	opts ...core.EventOption,
+) (r core.ThreadRecord, err error) {
+	// Get or create a log for the new node
+	lg, err := t.getOrCreateOwnLog(id)
+	if err != nil {
+		return
+	}
+	if err = t.checkACL(id, thread.Writer, lg.ID); err != nil {
+		return nil, err
+	}
+
+	// Write a record locally and update heads before the blocks can be collected
+	unprotect := t.protectBlocks()
+	rec, err := t.createRecord(ctx, id, lg, body, opts...)
+	if err != nil {
+		unprotect()
+		return
+	}
+	err = t.advanceHeads(id, lg.ID, rec)
+	unprotect()
+	if err != nil {
 		return nil, err
 	}
 
@@ -489,7 +1075,7 @@ func (t *service) CreateRecord(ctx context.Context, id thread.ID, body format.No
 
 	// Notify local listeners
 	r = NewRecord(rec, id, lg.ID)
-	if err = t.bus.SendWithTimeout(r, notifyTimeout); err != nil {
+	if err = t.notify(r); err != nil {
 		return
 	}
 
@@ -536,7 +1122,165 @@ func (t *service) GetRecord(ctx context.Context, id thread.ID, rid cid.Cid) (cor
 	if fk == nil {
 		return nil, fmt.Errorf("a follow-key is required to get records")
 	}
-	return cbor.GetRecord(ctx, t, rid, fk)
+	return cbor.GetRecord(ctx, t, rid, t.followKeys(id))
+}
+
+// ListRecords returns up to limit records from a log, newest first.
+// A limit less than one defaults to MaxPullLimit.
+func (t *service) ListRecords(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	from, to cid.Cid,
+	limit int,
+) ([]core.Record, cid.Cid, error) {
+	lg, err := t.store.LogInfo(id, lid)
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	if lg.PubKey == nil {
+		return nil, cid.Undef, fmt.Errorf("log not found")
+	}
+	if limit <= 0 {
+		limit = MaxPullLimit
+	}
+
+	// Logs with several heads are listed in reverse topological order. The
+	// order only changes with the heads, so pages reuse the walk.
+	key := listingKey{thread: id, log: lid, heads: cidsKey(lg.Heads), to: to}
+	var all []cid.Cid
+	if v, ok := t.listings.Get(key); ok {
+		all = v.([]cid.Cid)
+	} else {
+		stop, err := t.ancestorStop(ctx, id, to)
+		if err != nil {
+			return nil, cid.Undef, err
+		}
+		if all, err = t.walkLogIDs(ctx, id, lg.Heads, stop); err != nil {
+			return nil, cid.Undef, err
+		}
+		t.listings.Add(key, all)
+	}
+	start := len(all) - 1
+	if from.Defined() {
+		for start >= 0 && !all[start].Equals(from) {
+			start--
+		}
+		if start < 0 {
+			return nil, cid.Undef, fmt.Errorf("record %s not found in log", from)
+		}
+	}
+	var ids []cid.Cid
+	for i := start; i >= 0 && len(ids) < limit; i-- {
+		ids = append(ids, all[i])
+	}
+	next := cid.Undef
+	if end := start - len(ids); end >= 0 {
+		next = all[end]
+	}
+	recs, err := t.loadRecords(ctx, id, ids)
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	return recs, next, nil
+}
+
+// ListThreadRecords returns up to limit records from all of a thread's logs.
+// A limit less than one defaults to MaxPullLimit.
+func (t *service) ListThreadRecords(
+	ctx context.Context,
+	id thread.ID,
+	token string,
+	limit int,
+) ([]core.ThreadRecord, string, error) {
+	var start peer.ID
+	from := cid.Undef
+	if token != "" {
+		var err error
+		start, from, err = decodeRecordsToken(token)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if limit <= 0 {
+		limit = MaxPullLimit
+	}
+	lids, err := t.store.LogsWithKeys(id)
+	if err != nil {
+		return nil, "", err
+	}
+	sort.Slice(lids, func(i, j int) bool {
+		return lids[i] < lids[j]
+	})
+
+	var recs []core.ThreadRecord
+	for i, lid := range lids {
+		if lid < start {
+			continue
+		}
+		offset := cid.Undef
+		if lid == start {
+			offset = from
+		}
+		page, next, err := t.ListRecords(ctx, id, lid, offset, cid.Undef, limit-len(recs))
+		if err != nil {
+			return nil, "", err
+		}
+		for _, r := range page {
+			recs = append(recs, NewRecord(r, id, lid))
+		}
+		if next.Defined() {
+			return recs, encodeRecordsToken(lid, next), nil
+		}
+		if len(recs) == limit {
+			if i+1 < len(lids) {
+				return recs, encodeRecordsToken(lids[i+1], cid.Undef), nil
+			}
+			break
+		}
+	}
+	return recs, "", nil
+}
+
+// encodeRecordsToken returns a token that continues a thread record listing
+// in log lid at record rid. An undefined rid starts at the log head.
+func encodeRecordsToken(lid peer.ID, rid cid.Cid) string {
+	token := lid.String() + "/"
+	if rid.Defined() {
+		token += rid.String()
+	}
+	return token
+}
+
+// decodeRecordsToken returns the log and record IDs of a token.
+func decodeRecordsToken(token string) (lid peer.ID, rid cid.Cid, err error) {
+	parts := strings.SplitN(token, "/", 2)
+	if len(parts) != 2 {
+		return lid, rid, fmt.Errorf("invalid token")
+	}
+	if lid, err = peer.Decode(parts[0]); err != nil {
+		return lid, rid, fmt.Errorf("invalid token: %w", err)
+	}
+	if parts[1] != "" {
+		if rid, err = cid.Decode(parts[1]); err != nil {
+			return lid, rid, fmt.Errorf("invalid token: %w", err)
+		}
+	}
+	return lid, rid, nil
+}
+
+// followKeys returns a key ring with the follow-keys of all key epochs of a thread.
+func (t *service) followKeys(id thread.ID) tcrypto.KeyRing {
+	return tcrypto.KeyRingFunc(func(epoch uint64) (tcrypto.DecryptionKey, error) {
+		fk, err := t.store.FollowKeyAt(id, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if fk == nil {
+			return nil, fmt.Errorf("follow-key for epoch %d not found", epoch)
+		}
+		return fk, nil
+	})
 }
 
 // Record wraps a core.Record within a thread and log context.
@@ -551,144 +1295,816 @@ func NewRecord(r core.Record, id thread.ID, lid peer.ID) core.ThreadRecord {
 	return &Record{Record: r, threadID: id, logID: lid}
 }
 
-// Value returns the underlying record.
-func (r *Record) Value() core.Record {
-	return r
+// notify sends a record to local subscribers, counting the subscribers that
+// didn't receive it in time.
+func (t *service) notify(r core.ThreadRecord) error {
+	err := t.bus.SendWithTimeout(r, notifyTimeout)
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		metrics.SubscriberDrops.Add(float64(len(merr.Errors)))
+	}
+	return err
+}
+
+// Value returns the underlying record.
+func (r *Record) Value() core.Record {
+	return r
+}
+
+// ThreadID returns the record's thread ID.
+func (r *Record) ThreadID() thread.ID {
+	return r.threadID
+}
+
+// LogID returns the record's log ID.
+func (r *Record) LogID() peer.ID {
+	return r.logID
+}
+
+// Subscribe returns a read-only channel of records.
+// With a cursor, local records added after the cursor are replayed before
+// new records are delivered. Causal subscriptions replay records in causal
+// order and hold back new records until their dependencies were delivered.
+// Concurrent new records keep their arrival order.
+func (t *service) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan core.ThreadRecord, error) {
+	args := &core.SubOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	filter := make(map[thread.ID]struct{})
+	for _, id := range args.ThreadIDs {
+		if id.Defined() {
+			filter[id] = struct{}{}
+		}
+	}
+	t.subsLock.Lock()
+	for id := range filter {
+		t.subs[id]++
+	}
+	t.subsLock.Unlock()
+
+	// Listen before replaying so that no record falls between the two
+	listener := t.bus.Listen()
+	channel := make(chan core.ThreadRecord)
+	go func() {
+		defer close(channel)
+		defer func() {
+			t.subsLock.Lock()
+			defer t.subsLock.Unlock()
+			for id := range filter {
+				if t.subs[id]--; t.subs[id] <= 0 {
+					delete(t.subs, id)
+				}
+			}
+		}()
+		defer listener.Discard()
+
+		match := func(i interface{}) *Record {
+			rec, ok := i.(*Record)
+			if !ok {
+				log.Warn("listener received a non-record value")
+				return nil
+			}
+			if len(filter) > 0 {
+				if _, ok := filter[rec.threadID]; !ok {
+					return nil
+				}
+			}
+			return rec
+		}
+
+		send := func(rec *Record) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case channel <- rec:
+				return true
+			}
+		}
+
+		// late holds replayed records that may still arrive on the bus
+		var late map[cid.Cid]struct{}
+		if args.Cursor != nil {
+			// Drain the bus while replaying so that writers aren't blocked
+			var pending []*Record
+			stop := make(chan struct{})
+			drained := make(chan struct{})
+			go func() {
+				defer close(drained)
+				for {
+					select {
+					case <-stop:
+						return
+					case i, ok := <-listener.Channel():
+						if !ok {
+							return
+						}
+						if r := match(i); r != nil {
+							pending = append(pending, r)
+						}
+					}
+				}
+			}()
+			var stopped bool
+			stopDrain := func() {
+				if !stopped {
+					stopped = true
+					close(stop)
+					<-drained
+				}
+			}
+			defer stopDrain()
+
+			ids := args.ThreadIDs
+			if len(ids) == 0 {
+				var err error
+				ids, err = t.store.Threads()
+				if err != nil {
+					log.Errorf("error getting threads for replay: %v", err)
+					return
+				}
+			}
+			replayed := make(map[cid.Cid]struct{})
+			late = make(map[cid.Cid]struct{})
+			for _, id := range ids {
+				info, err := t.store.ThreadInfo(id)
+				if err != nil {
+					log.Errorf("error getting thread %s for replay: %v", id, err)
+					continue
+				}
+				var recs []core.ThreadRecord
+				for _, lg := range info.Logs {
+					lrecs, err := t.getRecordsSince(ctx, id, lg, args.Cursor[lg.ID])
+					if err != nil {
+						log.Errorf("error replaying log %s: %v", lg.ID, err)
+						continue
+					}
+					for _, r := range lrecs {
+						recs = append(recs, &Record{Record: r, threadID: id, logID: lg.ID})
+					}
+					// Heads may be notified after they were read
+					for _, h := range lg.Heads {
+						late[h] = struct{}{}
+					}
+				}
+				if args.Causal {
+					recs = t.sortCausal(ctx, recs)
+				}
+				for _, r := range recs {
+					replayed[r.Value().Cid()] = struct{}{}
+					if !send(r.(*Record)) {
+						return
+					}
+				}
+			}
+			stopDrain()
+			for _, rec := range pending {
+				if _, ok := replayed[rec.Cid()]; ok {
+					delete(late, rec.Cid())
+					continue
+				}
+				if !send(rec) {
+					return
+				}
+			}
+			pending = nil
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case i, ok := <-listener.Channel():
+				if !ok {
+					return
+				}
+				rec := match(i)
+				if rec == nil {
+					continue
+				}
+				if _, ok := late[rec.Cid()]; ok {
+					delete(late, rec.Cid())
+					continue
+				}
+				if !send(rec) {
+					return
+				}
+			}
+		}
+	}()
+	if args.Causal {
+		return t.deliverCausal(ctx, channel), nil
+	}
+	return channel, nil
+}
+
+// PutRecord adds an existing record. This method is thread-safe
+func (t *service) PutRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core.Record) error {
+	tsph := t.getThreadSemaphore(id)
+	tsph <- struct{}{}
+	defer func() { <-tsph }()
+	return t.putRecord(ctx, id, lid, rec)
+}
+
+// putRecord adds an existing record. See PutOption for more.This method
+// *should be thread-guarded*
+func (t *service) putRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core.Record) (err error) {
+	ctx, span := tracer.Start(ctx, "putRecord", trace.WithAttributes(
+		attribute.String("thread", id.String()),
+		attribute.String("log", lid.String()),
+		attribute.String("record", rec.Cid().String())))
+	defer func() { tracing.End(span, err) }()
+	defer metrics.ObserveSince(metrics.PutRecordDuration, time.Now())
+
+	known, err := t.bstore.Has(rec.Cid())
+	if err != nil {
+		return err
+	}
+	if known {
+		return nil
+	}
+	removed, err := t.getRemoved(id)
+	if err != nil {
+		return err
+	}
+	if _, ok := removed[lid]; ok {
+		metrics.RecordsRejected.WithLabelValues("unauthorized").Inc()
+		return fmt.Errorf("%w: log %s was removed from thread %s", core.ErrUnauthorized, lid, id)
+	}
+	// Collect unknown ancestors, which are added first
+	unknownRecords, err := t.walkLog(ctx, id, rec.PrevIDs(), t.bstore.Has, 0)
+	if err != nil {
+		return err
+	}
+	unknownRecords = append(unknownRecords, rec)
+	// Get or create a log for the new rec
+	lg, err := t.getLog(id, lid)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range unknownRecords {
+		// Save the record locally
+		// Note: These get methods will return cached nodes.
+		block, err := r.GetBlock(ctx, t)
+		if err != nil {
+			return err
+		}
+		event, ok := block.(*cbor.Event)
+		if !ok {
+			event, err = cbor.EventFromNode(block)
+			if err != nil {
+				return fmt.Errorf("invalid event: %v", err)
+			}
+		}
+		var list *thread.ACL
+		if id.Variant() == thread.AccessControlled {
+			if list, err = t.authorizeRecord(ctx, id, lg.ID, event); err != nil {
+				metrics.RecordsRejected.WithLabelValues("unauthorized").Inc()
+				return err
+			}
+		}
+		if err = t.checkAuthor(ctx, id, lg.ID, event); err != nil {
+			metrics.RecordsRejected.WithLabelValues("invalid").Inc()
+			return err
+		}
+		// Blocks that were fetched for a rejected record are left to the
+		// garbage collector
+		if err = t.validateRecord(ctx, id, lg.ID, r, event); err != nil {
+			metrics.RecordsRejected.WithLabelValues("invalid").Inc()
+			return err
+		}
+		header, err := event.GetHeader(ctx, t, nil)
+		if err != nil {
+			return err
+		}
+		body, err := event.GetBody(ctx, t, nil)
+		if err != nil {
+			return err
+		}
+		nodes := []format.Node{r, event, header, body}
+		if t.replicator {
+			// Replicators serve bodies in full, including their chunks
+			for _, l := range body.Links() {
+				n, err := t.Get(ctx, l.Cid)
+				if err != nil {
+					return err
+				}
+				nodes = append(nodes, n)
+			}
+		}
+		if err = t.saveRecord(ctx, id, lg.ID, r, nodes, list); err != nil {
+			return err
+		}
+
+		log.Debugf("put record %s (thread=%s, log=%s)", r.Cid().String(), id, lg.ID)
+
+		// Notify local listeners
+		if err = t.notify(NewRecord(r, id, lg.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveRecord writes the nodes of a validated record, the access control list
+// it holds, if any, and advances the log's heads. Blocks are only protected
+// from the garbage collector while they're written and linked, so records
+// are fetched and validated beforehand.
+func (t *service) saveRecord(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	rec core.Record,
+	nodes []format.Node,
+	list *thread.ACL,
+) error {
+	defer t.protectBlocks()()
+	if err := t.AddMany(ctx, nodes); err != nil {
+		return err
+	}
+	if list != nil {
+		if err := t.putACL(id, *list); err != nil {
+			return err
+		}
+	}
+	return t.advanceHeads(id, lid, rec)
+}
+
+// SetRecordValidator validates records added to a thread in addition to
+// the validators in the service config. A nil validator removes it.
+func (t *service) SetRecordValidator(id thread.ID, v core.RecordValidator) {
+	if v == nil {
+		t.threadValidators.Delete(id)
+	} else {
+		t.threadValidators.Store(id, v)
+	}
+}
+
+// validateRecord runs the global and thread validators on a record.
+// Rejected records return an error wrapping core.ErrInvalidRecord.
+func (t *service) validateRecord(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	rec core.Record,
+	event *cbor.Event,
+) error {
+	validators := t.validators
+	if v, ok := t.threadValidators.Load(id); ok {
+		validators = append(append([]core.RecordValidator{}, validators...), v.(core.RecordValidator))
+	}
+	if len(validators) == 0 {
+		return nil
+	}
+
+	// Access control list events are encrypted with the follow-key
+	var k *sym.Key
+	var err error
+	if event.IsACL() {
+		k, err = t.store.FollowKeyAt(id, event.KeyEpoch())
+	} else {
+		k, err = t.store.ReadKeyAt(id, event.KeyEpoch())
+	}
+	if err != nil {
+		return err
+	}
+	var key tcrypto.DecryptionKey
+	if k != nil {
+		key = k
+	}
+	header, err := event.GetHeader(ctx, t, key)
+	if err != nil {
+		return err
+	}
+	body, err := event.GetBody(ctx, t, key)
+	if err != nil {
+		return err
+	}
+	for _, v := range validators {
+		if err = v.Validate(ctx, id, lid, rec, header, body); err != nil {
+			return fmt.Errorf("%w: %s", core.ErrInvalidRecord, err)
+		}
+	}
+	return nil
+}
+
+// checkAuthor returns an error if the author of an event isn't the log whose
+// key signed its record. Hosts without the key of the event header can't
+// check it, but can't read the author either.
+func (t *service) checkAuthor(ctx context.Context, id thread.ID, lid peer.ID, event *cbor.Event) error {
+	// Access control list events are encrypted with the follow-key
+	var k *sym.Key
+	var err error
+	if event.IsACL() {
+		k, err = t.store.FollowKeyAt(id, event.KeyEpoch())
+	} else {
+		k, err = t.store.ReadKeyAt(id, event.KeyEpoch())
+	}
+	if err != nil || k == nil {
+		return err
+	}
+	header, err := event.GetHeader(ctx, t, k)
+	if err != nil {
+		return err
+	}
+	author, err := header.Author()
+	if err != nil {
+		return err
+	}
+	if author != "" && author != lid {
+		return fmt.Errorf("%w: author %s did not sign the record of log %s", core.ErrInvalidRecord, author, lid)
+	}
+	return nil
+}
+
+// advanceHeads makes rec a head of the log, replacing the heads it links to.
+// Records that don't link to the current heads fork the log, which then has
+// several heads until a merge record links to all of them.
+func (t *service) advanceHeads(id thread.ID, lid peer.ID, rec core.Record) error {
+	heads, err := t.store.Heads(id, lid)
+	if err != nil {
+		return err
+	}
+	prevs := make(map[cid.Cid]struct{})
+	for _, p := range rec.PrevIDs() {
+		prevs[p] = struct{}{}
+	}
+	next := []cid.Cid{rec.Cid()}
+	for _, h := range heads {
+		if _, ok := prevs[h]; ok || h.Equals(rec.Cid()) {
+			continue
+		}
+		next = append(next, h)
+	}
+	return t.store.SetHeads(id, lid, next)
+}
+
+// walkLog returns the records reachable from heads in topological order,
+// i.e., each record comes after the records it links to. The walk does not
+// go past records for which stop returns true. With a limit greater than zero,
+// only the limit records closest to heads are returned.
+func (t *service) walkLog(
+	ctx context.Context,
+	id thread.ID,
+	heads []cid.Cid,
+	stop func(cid.Cid) (bool, error),
+	limit int,
+) ([]core.Record, error) {
+	recs := make(map[cid.Cid]core.Record)
+	sorted, err := t.walkLinks(ctx, id, heads, stop, limit, func(r core.Record) {
+		recs[r.Cid()] = r
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]core.Record, len(sorted))
+	for i, c := range sorted {
+		res[i] = recs[c]
+	}
+	return res, nil
+}
+
+// walkLogIDs is like walkLog, but only returns the IDs of the records. Only
+// the links between records are held in memory, so that long logs can be
+// loaded a part at a time.
+func (t *service) walkLogIDs(
+	ctx context.Context,
+	id thread.ID,
+	heads []cid.Cid,
+	stop func(cid.Cid) (bool, error),
+) ([]cid.Cid, error) {
+	return t.walkLinks(ctx, id, heads, stop, 0, nil)
+}
+
+// walkLinks walks a log from heads like walkLog, calls visit with each
+// record, if it's given, and returns the record IDs in topological order.
+func (t *service) walkLinks(
+	ctx context.Context,
+	id thread.ID,
+	heads []cid.Cid,
+	stop func(cid.Cid) (bool, error),
+	limit int,
+	visit func(core.Record),
+) ([]cid.Cid, error) {
+	keys := t.followKeys(id)
+	seen := make(map[cid.Cid]struct{})
+	prevs := make(map[cid.Cid][]cid.Cid)
+	var order []cid.Cid // Newest first
+	queue := append([]cid.Cid{}, heads...)
+	for len(queue) > 0 && (limit <= 0 || len(order) < limit) {
+		c := queue[0]
+		queue = queue[1:]
+		if !c.Defined() {
+			continue
+		}
+		if _, ok := seen[c]; ok {
+			continue
+		}
+		seen[c] = struct{}{}
+		if stop != nil {
+			done, err := stop(c)
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				continue
+			}
+		}
+		r, err := cbor.GetRecord(ctx, t, c, keys)
+		if err != nil {
+			return nil, err
+		}
+		if visit != nil {
+			visit(r)
+		}
+		prevs[c] = r.PrevIDs()
+		order = append(order, c)
+		queue = append(queue, r.PrevIDs()...)
+	}
+
+	// Sort so that records come after the records they link to
+	pending := make(map[cid.Cid]int)
+	children := make(map[cid.Cid][]cid.Cid)
+	for _, c := range order {
+		for _, p := range prevs[c] {
+			if _, ok := prevs[p]; ok {
+				pending[c]++
+				children[p] = append(children[p], c)
+			}
+		}
+	}
+	var ready []cid.Cid
+	for i := len(order) - 1; i >= 0; i-- {
+		if pending[order[i]] == 0 {
+			ready = append(ready, order[i])
+		}
+	}
+	sorted := make([]cid.Cid, 0, len(order))
+	for len(ready) > 0 {
+		c := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, c)
+		for _, ch := range children[c] {
+			if pending[ch]--; pending[ch] == 0 {
+				ready = append(ready, ch)
+			}
+		}
+	}
+	return sorted, nil
+}
+
+// GetACL returns the access control list of an access controlled thread.
+func (t *service) GetACL(_ context.Context, id thread.ID) (list thread.ACL, err error) {
+	if id.Variant() != thread.AccessControlled {
+		return list, fmt.Errorf("thread %s is not access controlled", id)
+	}
+	l, err := t.getACL(id)
+	if err != nil {
+		return
+	}
+	if l == nil {
+		return list, fmt.Errorf("access control list not found")
+	}
+	return *l, nil
+}
+
+// UpdateACL replaces the access control list of an access controlled thread.
+func (t *service) UpdateACL(
+	ctx context.Context,
+	id thread.ID,
+	roles map[peer.ID]thread.Role,
+) (core.ThreadRecord, error) {
+	if id.Variant() != thread.AccessControlled {
+		return nil, fmt.Errorf("thread %s is not access controlled", id)
+	}
+	lg, err := t.getOrCreateOwnLog(id)
+	if err != nil {
+		return nil, err
+	}
+	list, err := t.getACL(id)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, fmt.Errorf("access control list not found")
+	}
+	if !list.Allows(thread.Admin, lg.ID) {
+		return nil, core.ErrUnauthorized
+	}
+	return t.createACLRecord(ctx, id, lg, thread.ACL{
+		Version: list.Version + 1,
+		Roles:   roles,
+	})
+}
+
+// createACLRecord creates a record in the given log that holds an access control list.
+// The list becomes the current list of the thread.
+func (t *service) createACLRecord(
+	ctx context.Context,
+	id thread.ID,
+	lg thread.LogInfo,
+	list thread.ACL,
+) (core.ThreadRecord, error) {
+	if lg.PrivKey == nil {
+		return nil, fmt.Errorf("a private-key is required to create records")
+	}
+	defer t.protectBlocks()()
+
+	epoch, err := t.store.KeyEpoch(id)
+	if err != nil {
+		return nil, err
+	}
+	fk, err := t.store.FollowKeyAt(id, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if fk == nil {
+		return nil, fmt.Errorf("a follow-key is required to create records")
+	}
+	seen, err := t.seenHeads(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if fk, err = t.encryptionKey(id, fk); err != nil {
+		return nil, err
+	}
+	event, err := cbor.CreateACLEvent(ctx, t, list, fk, epoch, seen, core.Author(lg.ID))
+	if err != nil {
+		return nil, err
+	}
+	rec, err := cbor.CreateRecord(ctx, t, event, lg.Heads, lg.PrivKey, fk, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.putACL(id, list); err != nil {
+		return nil, err
+	}
+	if err = t.advanceHeads(id, lg.ID, rec); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added acl record %s (thread=%s, log=%s)", rec.Cid().String(), id, lg.ID)
+
+	r := NewRecord(rec, id, lg.ID)
+	if err = t.notify(r); err != nil {
+		return nil, err
+	}
+	if err = t.server.pushRecord(ctx, id, lg.ID, rec); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// getACL returns the current access control list of a thread.
+// The list is nil if the thread is not access controlled or if no list is known yet.
+func (t *service) getACL(id thread.ID) (*thread.ACL, error) {
+	if id.Variant() != thread.AccessControlled {
+		return nil, nil
+	}
+	data, err := t.store.GetBytes(id, aclKey)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	list, err := cbor.UnmarshalACL(*data)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
 }
 
-// ThreadID returns the record's thread ID.
-func (r *Record) ThreadID() thread.ID {
-	return r.threadID
+// putACL stores list as the current access control list of a thread.
+func (t *service) putACL(id thread.ID, list thread.ACL) error {
+	data, err := cbor.MarshalACL(list)
+	if err != nil {
+		return err
+	}
+	return t.store.PutBytes(id, aclKey, data)
 }
 
-// LogID returns the record's log ID.
-func (r *Record) LogID() peer.ID {
-	return r.logID
+// getCreator returns the ID of the log of a thread's creator.
+// The ID is empty if the creator is not known.
+func (t *service) getCreator(id thread.ID) (peer.ID, error) {
+	data, err := t.store.GetBytes(id, creatorKey)
+	if err != nil {
+		return "", err
+	}
+	if data == nil {
+		return "", nil
+	}
+	return peer.IDFromBytes(*data)
 }
 
-// Subscribe returns a read-only channel of records.
-func (t *service) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan core.ThreadRecord, error) {
-	args := &core.SubOptions{}
-	for _, opt := range opts {
-		opt(args)
+// putCreator stores lid as the log of a thread's creator, unless one is
+// already known. The creator is trusted from the peer a thread is added from.
+func (t *service) putCreator(id thread.ID, lid peer.ID) error {
+	if lid == "" {
+		return nil
 	}
-	filter := make(map[thread.ID]struct{})
-	for _, id := range args.ThreadIDs {
-		if id.Defined() {
-			filter[id] = struct{}{}
-		}
+	cur, err := t.getCreator(id)
+	if err != nil {
+		return err
 	}
-	channel := make(chan core.ThreadRecord)
-	go func() {
-		defer close(channel)
-		listener := t.bus.Listen()
-		defer listener.Discard()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case i, ok := <-listener.Channel():
-				if !ok {
-					return
-				}
-				if rec, ok := i.(*Record); ok {
-					if len(filter) > 0 {
-						if _, ok := filter[rec.threadID]; ok {
-							channel <- rec
-						}
-					} else {
-						channel <- rec
-					}
-				} else {
-					log.Warn("listener received a non-record value")
-				}
-			}
-		}
-	}()
-	return channel, nil
+	if cur != "" {
+		return nil
+	}
+	return t.store.PutBytes(id, creatorKey, []byte(lid))
 }
 
-// PutRecord adds an existing record. This method is thread-safe
-func (t *service) PutRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core.Record) error {
-	tsph := t.getThreadSemaphore(id)
-	tsph <- struct{}{}
-	defer func() { <-tsph }()
-	return t.putRecord(ctx, id, lid, rec)
+// currentACL returns the access control list that applies to an access controlled
+// thread. Until a list is known, only the creator's log is granted a role, which
+// lets it publish the initial list. Initial is true if no list is known.
+func (t *service) currentACL(id thread.ID) (list *thread.ACL, initial bool, err error) {
+	list, err = t.getACL(id)
+	if err != nil || list != nil {
+		return
+	}
+	creator, err := t.getCreator(id)
+	if err != nil {
+		return
+	}
+	list = &thread.ACL{Roles: make(map[peer.ID]thread.Role)}
+	if creator != "" {
+		list.Roles[creator] = thread.Admin
+	}
+	return list, true, nil
 }
 
-// putRecord adds an existing record. See PutOption for more.This method
-// *should be thread-guarded*
-func (t *service) putRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core.Record) error {
-	var unknownRecords []core.Record
-	c := rec.Cid()
-	for c.Defined() {
-		exist, err := t.bstore.Has(c)
-		if err != nil {
-			return err
-		}
-		if exist {
-			break
-		}
-		var r core.Record
-		if c.String() != rec.Cid().String() {
-			r, err = t.GetRecord(ctx, id, c)
-			if err != nil {
-				return err
-			}
-		} else {
-			r = rec
-		}
-		unknownRecords = append(unknownRecords, r)
-		c = r.PrevID()
-	}
-	if len(unknownRecords) == 0 {
+// checkACL returns core.ErrUnauthorized if none of ids has at least role in
+// an access controlled thread.
+func (t *service) checkACL(id thread.ID, role thread.Role, ids ...peer.ID) error {
+	if id.Variant() != thread.AccessControlled {
 		return nil
 	}
-	// Get or create a log for the new rec
-	lg, err := t.getLog(id, lid)
+	list, _, err := t.currentACL(id)
 	if err != nil {
 		return err
 	}
+	if !list.Allows(role, ids...) {
+		return fmt.Errorf("%w: requires %s role in thread %s", core.ErrUnauthorized, role, id)
+	}
+	return nil
+}
 
-	for i := len(unknownRecords) - 1; i >= 0; i-- {
-		r := unknownRecords[i]
-		// Save the record locally
-		// Note: These get methods will return cached nodes.
-		block, err := r.GetBlock(ctx, t)
-		if err != nil {
-			return err
-		}
-		event, ok := block.(*cbor.Event)
-		if !ok {
-			event, err = cbor.EventFromNode(block)
-			if err != nil {
-				return fmt.Errorf("invalid event: %v", err)
-			}
-		}
-		header, err := event.GetHeader(ctx, t, nil)
-		if err != nil {
-			return err
-		}
-		body, err := event.GetBody(ctx, t, nil)
-		if err != nil {
-			return err
-		}
-		if err = t.AddMany(ctx, []format.Node{r, event, header, body}); err != nil {
-			return err
+// checkRotator returns core.ErrUnauthorized if none of ids can rotate the keys
+// of a thread. The creator's log can, and so can admins of access controlled threads.
+func (t *service) checkRotator(id thread.ID, ids ...peer.ID) error {
+	creator, err := t.getCreator(id)
+	if err != nil {
+		return err
+	}
+	for _, pid := range ids {
+		if creator != "" && pid == creator {
+			return nil
 		}
+	}
+	if id.Variant() == thread.AccessControlled {
+		return t.checkACL(id, thread.Admin, ids...)
+	}
+	return fmt.Errorf("%w: only the creator can rotate the keys of thread %s", core.ErrUnauthorized, id)
+}
 
-		log.Debugf("put record %s (thread=%s, log=%s)", r.Cid().String(), id, lg.ID)
-
-		// Notify local listeners
-		if err = t.bus.SendWithTimeout(NewRecord(r, id, lg.ID), notifyTimeout); err != nil {
-			return err
-		}
-		// Update head
-		if err = t.store.SetHead(id, lg.ID, r.Cid()); err != nil {
-			return err
+// authorizeRecord checks an event from the given log against the access control list
+// of a thread. Events that hold a newer list from an admin return that list, which
+// should become current once the record is saved. Only the creator's log can
+// publish the initial list of the thread.
+func (t *service) authorizeRecord(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	event *cbor.Event,
+) (*thread.ACL, error) {
+	list, initial, err := t.currentACL(id)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsACL() {
+		if !list.Allows(thread.Writer, lid) {
+			return nil, fmt.Errorf("%w: log %s can't write to thread %s", core.ErrUnauthorized, lid, id)
 		}
+		return nil, nil
 	}
-	return nil
+	if !list.Allows(thread.Admin, lid) {
+		return nil, fmt.Errorf("%w: log %s can't update the acl of thread %s", core.ErrUnauthorized, lid, id)
+	}
+	fk, err := t.store.FollowKeyAt(id, event.KeyEpoch())
+	if err != nil {
+		return nil, err
+	}
+	if fk == nil {
+		return nil, fmt.Errorf("follow-key for epoch %d not found", event.KeyEpoch())
+	}
+	next, err := cbor.ACLFromEvent(ctx, t, event, fk)
+	if err != nil {
+		return nil, err
+	}
+	if !initial && next.Version <= list.Version {
+		return nil, nil // Stale list
+	}
+	return &next, nil
 }
 
 // getPrivKey returns the host's private key.
@@ -697,133 +2113,227 @@ func (t *service) getPrivKey() crypto.PrivKey {
 }
 
 // createRecord creates a new record with the given body as a new event body.
+// The log is the event author, since its key signs the record.
 func (t *service) createRecord(
 	ctx context.Context,
 	id thread.ID,
 	lg thread.LogInfo,
 	body format.Node,
+	opts ...core.EventOption,
 ) (core.Record, error) {
 	if lg.PrivKey == nil {
 		return nil, fmt.Errorf("a private-key is required to create records")
 	}
-	fk, err := t.store.FollowKey(id)
+	epoch, err := t.store.KeyEpoch(id)
+	if err != nil {
+		return nil, err
+	}
+	fk, err := t.store.FollowKeyAt(id, epoch)
 	if err != nil {
 		return nil, err
 	}
 	if fk == nil {
 		return nil, fmt.Errorf("a follow-key is required to create records")
 	}
-	rk, err := t.store.ReadKey(id)
+	rk, err := t.store.ReadKeyAt(id, epoch)
 	if err != nil {
 		return nil, err
 	}
 	if rk == nil {
 		return nil, fmt.Errorf("a read-key is required to create records")
 	}
-	event, err := cbor.CreateEvent(ctx, t, body, rk)
+	seen, err := t.seenHeads(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-
-	prev := cid.Undef
-	if len(lg.Heads) != 0 {
-		prev = lg.Heads[0]
+	args := &core.EventOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if args.Author != "" && args.Author != lg.ID {
+		return nil, fmt.Errorf("author %s is not log %s", args.Author, lg.ID)
+	}
+	opts = append([]core.EventOption{core.Author(lg.ID)}, opts...)
+	if fk, err = t.encryptionKey(id, fk); err != nil {
+		return nil, err
+	}
+	if rk, err = t.encryptionKey(id, rk); err != nil {
+		return nil, err
+	}
+	event, err := cbor.CreateEvent(ctx, t, body, rk, epoch, seen, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return cbor.CreateRecord(ctx, t, event, prev, lg.PrivKey, fk)
+
+	// Link to all heads, merging them if there are several
+	return cbor.CreateRecord(ctx, t, event, lg.Heads, lg.PrivKey, fk, epoch)
 }
 
-// getLocalRecords returns local records from the given thread that are ahead of
-// offset but not farther than limit.
-// It is possible to reach limit before offset, meaning that the caller
-// will be responsible for the remaining traversal.
-func (t *service) getLocalRecords(
+// getRecordsSince returns all local records in a log that are not ancestors
+// of the record at since, in topological order. An undefined since returns
+// the whole log.
+func (t *service) getRecordsSince(
 	ctx context.Context,
 	id thread.ID,
-	lid peer.ID,
-	offset cid.Cid,
-	limit int,
+	lg thread.LogInfo,
+	since cid.Cid,
 ) ([]core.Record, error) {
-	lg, err := t.store.LogInfo(id, lid)
+	stop, err := t.ancestorStop(ctx, id, since)
 	if err != nil {
 		return nil, err
 	}
-	if lg.PubKey == nil {
-		return nil, fmt.Errorf("log not found")
-	}
-	fk, err := t.store.FollowKey(id)
+	return t.walkLog(ctx, id, lg.Heads, stop, 0)
+}
+
+// getRecordIDsSince is like getRecordsSince, but only returns the IDs of the
+// records, so that they can be loaded a part at a time.
+func (t *service) getRecordIDsSince(
+	ctx context.Context,
+	id thread.ID,
+	lg thread.LogInfo,
+	since cid.Cid,
+) ([]cid.Cid, error) {
+	stop, err := t.ancestorStop(ctx, id, since)
 	if err != nil {
 		return nil, err
 	}
-	if fk == nil {
-		return nil, fmt.Errorf("a follow-key is required to get records")
-	}
+	return t.walkLogIDs(ctx, id, lg.Heads, stop)
+}
 
-	var recs []core.Record
-	if limit <= 0 {
-		return recs, nil
+// loadRecords returns the local records with the given IDs.
+func (t *service) loadRecords(ctx context.Context, id thread.ID, ids []cid.Cid) ([]core.Record, error) {
+	keys := t.followKeys(id)
+	recs := make([]core.Record, len(ids))
+	for i, c := range ids {
+		r, err := cbor.GetRecord(ctx, t, c, keys)
+		if err != nil {
+			return nil, err
+		}
+		recs[i] = r
 	}
+	return recs, nil
+}
 
-	if len(lg.Heads) == 0 {
-		return []core.Record{}, nil
+// ancestorStop returns a walkLog stop function that stops on the record at c
+// and all of its local ancestors. Walks from merged heads would otherwise go
+// past c through other branches into its history.
+func (t *service) ancestorStop(ctx context.Context, id thread.ID, c cid.Cid) (func(cid.Cid) (bool, error), error) {
+	if !c.Defined() {
+		return nil, nil
 	}
-
-	if len(lg.Heads) != 1 {
-		return nil, fmt.Errorf("log head must reference exactly one node")
+	ancestors, err := t.getAncestors(ctx, id, c)
+	if err != nil {
+		return nil, err
 	}
-	cursor := lg.Heads[0]
-	for {
-		if !cursor.Defined() || cursor.String() == offset.String() {
-			break
+	return func(c cid.Cid) (bool, error) {
+		_, ok := ancestors[c]
+		return ok, nil
+	}, nil
+}
+
+// getAncestors returns the record at c and all of its local ancestors. The
+// ancestry of a record never changes, so complete ancestries are cached, and
+// the walk stops at records with a cached ancestry. Cursors that move forward
+// only walk the records added since.
+func (t *service) getAncestors(ctx context.Context, id thread.ID, c cid.Cid) (map[cid.Cid]struct{}, error) {
+	if v, ok := t.ancestors.Get(c); ok {
+		return v.(map[cid.Cid]struct{}), nil
+	}
+	keys := t.followKeys(id)
+	ancestors := make(map[cid.Cid]struct{})
+	complete := true
+	queue := []cid.Cid{c}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if _, ok := ancestors[next]; ok || !next.Defined() {
+			continue
 		}
-		r, err := cbor.GetRecord(ctx, t, cursor, fk) // Important invariant: heads are always in blockstore
-		if err != nil {
+		if v, ok := t.ancestors.Get(next); ok {
+			for a := range v.(map[cid.Cid]struct{}) {
+				ancestors[a] = struct{}{}
+			}
+			continue
+		}
+		ancestors[next] = struct{}{}
+		if ok, err := t.bstore.Has(next); err != nil {
 			return nil, err
+		} else if !ok {
+			// Records may arrive later, so the ancestry isn't final
+			complete = false
+			continue
 		}
-		recs = append([]core.Record{r}, recs...)
-		if len(recs) >= MaxPullLimit {
-			break
+		r, err := cbor.GetRecord(ctx, t, next, keys)
+		if err != nil {
+			return nil, err
 		}
-		cursor = r.PrevID()
+		queue = append(queue, r.PrevIDs()...)
+	}
+	if complete {
+		t.ancestors.Add(c, ancestors)
 	}
+	return ancestors, nil
+}
 
-	return recs, nil
+// listingKey identifies a walk of a log from its heads down to a record.
+type listingKey struct {
+	thread thread.ID
+	log    peer.ID
+	heads  string
+	to     cid.Cid
 }
 
-// startPulling periodically pulls on all threads.
-func (t *service) startPulling() {
-	pull := func() {
-		ts, err := t.store.Threads()
-		if err != nil {
-			log.Errorf("error listing threads: %s", err)
-			return
-		}
-		for _, id := range ts {
-			go func(id thread.ID) {
-				if err := t.PullThread(t.ctx, id); err != nil {
-					log.Errorf("error pulling thread %s: %s", id.String(), err)
-				}
-			}(id)
-		}
-	}
-	timer := time.NewTimer(InitialPullInterval)
-	select {
-	case <-timer.C:
-		pull()
-	case <-t.ctx.Done():
-		return
+// cidsKey returns a comparable key of a list of cids.
+func cidsKey(cids []cid.Cid) string {
+	var b strings.Builder
+	for _, c := range cids {
+		b.WriteString(c.KeyString())
 	}
+	return b.String()
+}
 
-	tick := time.NewTicker(PullInterval)
-	defer tick.Stop()
+// getLocalRecords returns local records from the given thread that are ahead of
+// offset, oldest first, but not more than limit. If there are more records, the
+// last record returned can be used as the offset of the next call.
+func (t *service) getLocalRecords(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	offset cid.Cid,
+	limit int,
+) (recs []core.Record, more bool, err error) {
+	lg, err := t.store.LogInfo(id, lid)
+	if err != nil {
+		return nil, false, err
+	}
+	if lg.PubKey == nil {
+		return nil, false, fmt.Errorf("log not found")
+	}
+	fk, err := t.store.FollowKey(id)
+	if err != nil {
+		return nil, false, err
+	}
+	if fk == nil {
+		return nil, false, fmt.Errorf("a follow-key is required to get records")
+	}
 
-	for {
-		select {
-		case <-tick.C:
-			pull()
-		case <-t.ctx.Done():
-			return
-		}
+	if limit <= 0 {
+		return []core.Record{}, false, nil
+	}
+	if limit > MaxPullLimit {
+		limit = MaxPullLimit
 	}
+	// Important invariant: heads are always in blockstore
+	ids, err := t.getRecordIDsSince(ctx, id, lg, offset)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+		more = true
+	}
+	recs, err = t.loadRecords(ctx, id, ids)
+	return recs, more, err
 }
 
 // getLog returns the log with the given thread and log id.
@@ -875,55 +2385,87 @@ func (t *service) getOrCreateOwnLog(id thread.ID) (info thread.LogInfo, err erro
 }
 
 // createExternalLogIfNotExist creates an external log if doesn't exists. The created
-// log will have cid.Undef as the current head. Is thread-safe.
+// log will have cid.Undef as the current head. Known logs are left untouched,
+// even if they have no heads yet. Is thread-safe.
 func (t *service) createExternalLogIfNotExist(tid thread.ID, lid peer.ID, pubKey crypto.PubKey,
 	privKey crypto.PrivKey, addrs []ma.Multiaddr) error {
 	tsph := t.getThreadSemaphore(tid)
 	tsph <- struct{}{}
 	defer func() { <-tsph }()
-	currHeads, err := t.Store().Heads(tid, lid)
+	return t.addLogIfNotExist(tid, thread.LogInfo{
+		ID:      lid,
+		PubKey:  pubKey,
+		PrivKey: privKey,
+		Addrs:   addrs,
+	})
+}
+
+// putExternalLog adds or updates a log pushed by a peer. The addresses of a
+// known log are only replaced if owner is true, i.e., they're set by the log's
+// owner. Unknown logs that are relayed by other peers are added without
+// addresses. Is thread-safe.
+func (t *service) putExternalLog(tid thread.ID, lg thread.LogInfo, owner bool) error {
+	tsph := t.getThreadSemaphore(tid)
+	tsph <- struct{}{}
+	defer func() { <-tsph }()
+	cur, err := t.store.LogInfo(tid, lg.ID)
 	if err != nil {
 		return err
 	}
-	if len(currHeads) == 0 {
-		lginfo := thread.LogInfo{
-			ID:      lid,
-			PubKey:  pubKey,
-			PrivKey: privKey,
-			Addrs:   addrs,
-			Heads:   []cid.Cid{},
-		}
-		if err := t.store.AddLog(tid, lginfo); err != nil {
-			return err
+	if cur.PubKey != nil {
+		if !owner || cur.PrivKey != nil {
+			return nil
 		}
+		return t.store.SetAddrs(tid, lg.ID, lg.Addrs, pstore.PermanentAddrTTL)
 	}
-	return nil
+	if !owner {
+		lg.Addrs = nil
+	}
+	lg.PrivKey = nil
+	return t.addLogIfNotExist(tid, lg)
+}
+
+// addLogIfNotExist adds a log with no heads if it's not known.
+// The caller must hold the thread semaphore.
+func (t *service) addLogIfNotExist(tid thread.ID, lg thread.LogInfo) error {
+	cur, err := t.store.LogInfo(tid, lg.ID)
+	if err != nil {
+		return err
+	}
+	if cur.PubKey != nil {
+		return nil
+	}
+	lg.Heads = []cid.Cid{}
+	return t.store.AddLog(tid, lg)
 }
 
-// updateRecordsFromLog will fetch lid addrs for new logs & records,
-// and will add them in the local peer store. It assumes  Is thread-safe.
-func (t *service) updateRecordsFromLog(tid thread.ID, lid peer.ID) {
+// updateRecordsFromLog fetches the records of a log from the peer that
+// pushed it. Is thread-safe.
+func (t *service) updateRecordsFromLog(tid thread.ID, lid peer.ID, pid peer.ID) {
 	tsph := t.getThreadSemaphore(tid)
 	tsph <- struct{}{}
 	defer func() { <-tsph }()
+	fk, err := t.store.FollowKey(tid)
+	if err != nil || fk == nil {
+		log.Errorf("follow-key for thread %s not found: %v", tid, err)
+		return
+	}
 	// Get log records for this new log
-	recs, err := t.server.getRecords(
+	if _, err := t.server.streamRecordsFrom(
 		t.ctx,
 		tid,
-		lid,
+		fk,
+		pid,
 		map[peer.ID]cid.Cid{lid: cid.Undef},
-		MaxPullLimit)
-	if err != nil {
-		log.Error(err)
-		return
-	}
-	for lid, rs := range recs { // @todo: verify if they're ordered since this will optimize
-		for _, r := range rs {
-			if err = t.putRecord(t.ctx, tid, lid, r); err != nil {
-				log.Error(err)
-				return
+		func(lid peer.ID, recs []core.Record) error {
+			for _, r := range recs {
+				if err := t.putRecord(t.ctx, tid, lid, r); err != nil {
+					return err
+				}
 			}
-		}
+			return nil
+		}); err != nil {
+		log.Error(err)
 	}
 }
 
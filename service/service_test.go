@@ -1,27 +1,47 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/gogo/status"
 	bserv "github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
 	ds "github.com/ipfs/go-datastore"
 	syncds "github.com/ipfs/go-datastore/sync"
 	bstore "github.com/ipfs/go-ipfs-blockstore"
 	offline "github.com/ipfs/go-ipfs-exchange-offline"
 	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
 	dag "github.com/ipfs/go-merkledag"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/peerstore"
+	pt "github.com/libp2p/go-libp2p-core/test"
+	gostream "github.com/libp2p/go-libp2p-gostream"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
+	swarm "github.com/libp2p/go-libp2p-swarm"
 	ma "github.com/multiformats/go-multiaddr"
 	mh "github.com/multiformats/go-multihash"
 	"github.com/textileio/go-threads/cbor"
+	lstore "github.com/textileio/go-threads/core/logstore"
 	core "github.com/textileio/go-threads/core/service"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/crypto/symmetric"
 	tstore "github.com/textileio/go-threads/logstore/lstoremem"
+	pb "github.com/textileio/go-threads/service/pb"
 	"github.com/textileio/go-threads/util"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
 )
 
 func TestService_CreateRecord(t *testing.T) {
@@ -82,6 +102,401 @@ func TestService_CreateRecord(t *testing.T) {
 	})
 }
 
+func TestService_ChunkedBody(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test chunked body", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"data": bytes.Repeat([]byte("howdy"), 100),
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := s.CreateRecord(ctx, info.ID, body, core.ChunkSize(64))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		event, err := cbor.GetEvent(ctx, s, r.Value().BlockID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !event.(*cbor.Event).IsChunked() {
+			t.Fatal("expected body to be chunked")
+		}
+		root, err := event.GetBody(ctx, s, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(root.Links()) != (len(body.RawData())+63)/64 {
+			t.Fatalf("expected %d chunks got %d", (len(body.RawData())+63)/64, len(root.Links()))
+		}
+
+		back, err := event.GetBody(ctx, s, info.ReadKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body.String() != back.String() {
+			t.Fatalf("retrieved body does not equal input body")
+		}
+
+		event, err = cbor.GetEvent(ctx, s, r.Value().BlockID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		br, err := event.BodyReader(ctx, s, info.ReadKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(br)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, body.RawData()) {
+			t.Fatalf("streamed body does not equal input body")
+		}
+	})
+}
+
+func TestService_GC(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test gc", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+
+		var recs []core.ThreadRecord
+		for i := 0; i < 3; i++ {
+			body, err := cbornode.WrapObject(map[string]interface{}{
+				"foo": "bar",
+				"i":   i,
+			}, mh.SHA2_256, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := s.CreateRecord(ctx, info.ID, body, core.ChunkSize(16))
+			if err != nil {
+				t.Fatal(err)
+			}
+			recs = append(recs, r)
+		}
+		child, err := cbornode.WrapObject("child", mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pinned, err := cbornode.WrapObject(map[string]interface{}{
+			"link": child.Cid(),
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unlinked, err := cbornode.WrapObject("unlinked", mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.AddMany(ctx, []format.Node{child, pinned, unlinked}); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.Pin(ctx, info.ID, pinned.Cid()); err != nil {
+			t.Fatal(err)
+		}
+
+		removed, err := s.GC(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if removed != 1 {
+			t.Fatalf("expected 1 removed block got %d", removed)
+		}
+		has := func(c cid.Cid) bool {
+			ok, err := s.(*service).bstore.Has(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return ok
+		}
+		for _, r := range recs {
+			event, err := cbor.GetEvent(ctx, s, r.Value().BlockID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = event.GetBody(ctx, s, info.ReadKey); err != nil {
+				t.Fatalf("body of record %s was collected: %v", r.Value().Cid(), err)
+			}
+		}
+		if !has(pinned.Cid()) || !has(child.Cid()) {
+			t.Fatal("pinned blocks were collected")
+		}
+		if has(unlinked.Cid()) {
+			t.Fatal("unlinked block was not collected")
+		}
+
+		if err = s.Unpin(ctx, info.ID, pinned.Cid()); err != nil {
+			t.Fatal(err)
+		}
+		if removed, err = s.GC(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if removed != 2 {
+			t.Fatalf("expected 2 removed blocks got %d", removed)
+		}
+		if _, err = s.GetRecord(ctx, info.ID, recs[0].Value().Cid()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("test gc keeps blocks written while marking", func(t *testing.T) {
+		ctx := context.Background()
+		ts := s.(*service)
+		unlinked, err := cbornode.WrapObject("written during gc", mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.Add(ctx, unlinked); err != nil {
+			t.Fatal(err)
+		}
+
+		// The block is listed, then written again before it's swept
+		roots, candidates, err := ts.snapshot(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.Add(ctx, unlinked); err != nil {
+			t.Fatal(err)
+		}
+		marked, err := ts.mark(ctx, roots)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = ts.sweep(ctx, candidates, marked); err != nil {
+			t.Fatal(err)
+		}
+		ts.stopTracking()
+		if ok, err := ts.bstore.Has(unlinked.Cid()); err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Fatal("block written during gc was collected")
+		}
+	})
+
+	t.Run("test gc runs while records are validated", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+		info, err = s.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lg := info.GetOwnLog()
+
+		s.SetRecordValidator(info.ID, core.RecordValidatorFunc(func(
+			context.Context,
+			thread.ID,
+			peer.ID,
+			core.Record,
+			core.EventHeader,
+			format.Node,
+		) error {
+			done := make(chan error, 1)
+			go func() {
+				_, err := s.GC(ctx)
+				done <- err
+			}()
+			select {
+			case err := <-done:
+				return err
+			case <-time.After(time.Second * 5):
+				return fmt.Errorf("gc blocked by record validation")
+			}
+		}))
+		defer s.SetRecordValidator(info.ID, nil)
+
+		event, err := cbor.CreateEvent(ctx, nil, body, info.ReadKey, info.KeyEpoch, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := cbor.CreateRecord(ctx, nil, event, lg.Heads, lg.PrivKey, info.FollowKey, info.KeyEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.AddRecord(ctx, info.ID, lg.ID, rec); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.GetRecord(ctx, info.ID, rec.Cid()); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestService_EventMetadata(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test event metadata", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		header := func(r core.ThreadRecord) core.EventHeader {
+			event, err := cbor.GetEvent(ctx, s, r.Value().BlockID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			h, err := event.GetHeader(ctx, s, info.ReadKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return h
+		}
+
+		r1, err := s.CreateRecord(
+			ctx,
+			info.ID,
+			body,
+			core.ContentType("application/cbor"),
+			core.Codec("test"),
+			core.SchemaVersion("1.2.0"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		h := header(r1)
+		if ct, err := h.ContentType(); err != nil || ct != "application/cbor" {
+			t.Fatalf("expected content type application/cbor got %s (%v)", ct, err)
+		}
+		if codec, err := h.Codec(); err != nil || codec != "test" {
+			t.Fatalf("expected codec test got %s (%v)", codec, err)
+		}
+		if v, err := h.SchemaVersion(); err != nil || v != "1.2.0" {
+			t.Fatalf("expected schema version 1.2.0 got %s (%v)", v, err)
+		}
+		if author, err := h.Author(); err != nil || author != r1.LogID() {
+			t.Fatalf("expected log to be the author got %s (%v)", author, err)
+		}
+
+		// Metadata is optional
+		r2, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h = header(r2)
+		if ct, err := h.ContentType(); err != nil || ct != "" {
+			t.Fatalf("expected no content type got %s (%v)", ct, err)
+		}
+		if codec, err := h.Codec(); err != nil || codec != "" {
+			t.Fatalf("expected no codec got %s (%v)", codec, err)
+		}
+	})
+
+	t.Run("test author must sign the record", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.CreateRecord(ctx, info.ID, body, core.Author(s.Host().ID())); err == nil {
+			t.Fatal("expected another author to be refused")
+		}
+
+		// A record signed by another log that names the host's log as author
+		sk, pk, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lid, err := peer.IDFromPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts := s.(*service)
+		if err = ts.createExternalLogIfNotExist(info.ID, lid, pk, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		event, err := cbor.CreateEvent(ctx, s, body, info.ReadKey, info.KeyEpoch, nil, core.Author(info.GetOwnLog().ID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := cbor.CreateRecord(ctx, nil, event, nil, sk, info.FollowKey, info.KeyEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ts.putRecord(ctx, info.ID, lid, rec); !errors.Is(err, core.ErrInvalidRecord) {
+			t.Fatalf("expected forged author to be rejected, got %v", err)
+		}
+	})
+}
+
+func TestService_Algorithm(t *testing.T) {
+	t.Parallel()
+	s := makeServiceWithConfig(t, Config{
+		Debug:     true,
+		Algorithm: symmetric.XChaCha20Poly1305,
+	})
+	defer s.Close()
+
+	// envelope returns the ciphertext of a record block
+	envelope := func(t *testing.T, r core.ThreadRecord) []byte {
+		n, err := s.Get(context.Background(), r.Value().Cid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var coded []byte
+		if err = cbornode.DecodeInto(n.RawData(), &coded); err != nil {
+			t.Fatal(err)
+		}
+		return coded
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test service algorithm", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+		r, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if alg, ok := symmetric.EnvelopeAlgorithm(envelope(t, r)); !ok || alg != symmetric.XChaCha20Poly1305 {
+			t.Fatalf("expected %s envelope got %s", symmetric.XChaCha20Poly1305, alg)
+		}
+		if _, err = s.GetRecord(ctx, info.ID, r.Value().Cid()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("test unknown algorithm", func(t *testing.T) {
+		ctx := context.Background()
+		if _, err := s.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.Algorithm(42)); err == nil {
+			t.Fatal("expected unknown algorithm to be refused")
+		}
+	})
+}
+
 func TestService_AddThread(t *testing.T) {
 	t.Parallel()
 	s1 := makeService(t)
@@ -89,108 +504,2287 @@ func TestService_AddThread(t *testing.T) {
 	s2 := makeService(t)
 	defer s2.Close()
 
-	s1.Host().Peerstore().AddAddrs(s2.Host().ID(), s2.Host().Addrs(), peerstore.PermanentAddrTTL)
-	s2.Host().Peerstore().AddAddrs(s1.Host().ID(), s1.Host().Addrs(), peerstore.PermanentAddrTTL)
+	s1.Host().Peerstore().AddAddrs(s2.Host().ID(), s2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	s2.Host().Peerstore().AddAddrs(s1.Host().ID(), s1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	t.Run("test add thread", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s1.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+
+		addr, err := ma.NewMultiaddr("/p2p/" + s1.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info2, err := s2.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(info2.Logs) != 1 {
+			t.Fatalf("expected 1 log got %d", len(info2.Logs))
+		}
+
+		body2, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo back!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r2, err := s2.CreateRecord(ctx, info2.ID, body2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info3, err := s1.GetThread(context.Background(), info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(info3.Logs) != 2 {
+			t.Fatalf("expected 2 logs got %d", len(info3.Logs))
+		}
+		// The record is pushed again after its log
+		lg, err := s1.(*service).store.LogInfo(info.ID, r2.LogID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lg.Heads) != 1 || !lg.Heads[0].Equals(r2.Value().Cid()) {
+			t.Fatal("expected record to be pushed with its log")
+		}
+	})
+}
+
+func TestService_PushLogOwner(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+	s3 := makeService(t)
+	defer s3.Close()
+
+	for _, a := range []core.Service{s1, s2, s3} {
+		for _, b := range []core.Service{s1, s2, s3} {
+			if a != b {
+				a.Host().Peerstore().AddAddrs(b.Host().ID(), b.Host().Addrs(), peerstore.PermanentAddrTTL)
+			}
+		}
+	}
+
+	t.Run("test non-owner can't change log addresses", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		addr, err := ma.NewMultiaddr("/p2p/" + s1.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range []core.Service{s2, s3} {
+			if _, err = s.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// s2 relays s1's log with its own address in place of s1's
+		lg, err := s2.(*service).store.LogInfo(info.ID, info.Logs[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bogus, err := ma.NewMultiaddr("/p2p/" + s2.Host().ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		lg.Addrs = []ma.Multiaddr{bogus}
+		err = s2.(*service).server.pushLog(ctx, info.ID, lg, s3.Host().ID(), nil, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lg3, err := s3.(*service).store.LogInfo(info.ID, info.Logs[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, pid := range addrPeers(lg3.Addrs) {
+			if pid == s2.Host().ID() {
+				t.Fatal("non-owner changed the log addresses")
+			}
+		}
+		if len(lg3.Addrs) != 1 {
+			t.Fatalf("expected 1 address got %d", len(lg3.Addrs))
+		}
+	})
+}
+
+func TestService_StreamRecords(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+
+	s1.Host().Peerstore().AddAddrs(s2.Host().ID(), s2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	s2.Host().Peerstore().AddAddrs(s1.Host().ID(), s1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	t.Run("test stream records", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		// More records than fit in a chunk
+		n := MaxStreamChunkSize*2 + 10
+		var last core.ThreadRecord
+		for i := 0; i < n; i++ {
+			body, err := cbornode.WrapObject(map[string]interface{}{
+				"n": i,
+			}, mh.SHA2_256, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if last, err = s1.CreateRecord(ctx, info.ID, body); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		addr, err := ma.NewMultiaddr("/p2p/" + s1.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err != nil {
+			t.Fatal(err)
+		}
+
+		deadline := time.Now().Add(time.Second * 20)
+		for {
+			recs, _, err := s2.ListRecords(ctx, info.ID, last.LogID(), cid.Undef, cid.Undef, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(recs) == n {
+				if !recs[0].Cid().Equals(last.Value().Cid()) {
+					t.Fatal("expected the newest record first")
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %d records got %d", n, len(recs))
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+	})
+}
+
+func TestService_GetRecordsPages(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+
+	t.Run("test get records pages", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+		var created []core.ThreadRecord
+		for i := 0; i < 5; i++ {
+			body, err := cbornode.WrapObject(map[string]interface{}{
+				"n": i,
+			}, mh.SHA2_256, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := s1.CreateRecord(ctx, info.ID, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			created = append(created, r)
+		}
+
+		lid := info.GetOwnLog().ID
+		cctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: testAddr(s2.Host().ID())})
+		offset := cid.Undef
+		var got []cid.Cid
+		for {
+			req := &pb.GetRecordsRequest{
+				ThreadID:  &pb.ProtoThreadID{ID: info.ID},
+				FollowKey: &pb.ProtoKey{Key: info.FollowKey},
+				Logs: []*pb.GetRecordsRequest_LogEntry{{
+					LogID:  &pb.ProtoPeerID{ID: lid},
+					Offset: &pb.ProtoCid{Cid: offset},
+					Limit:  2,
+				}},
+			}
+			if err := s2.(*service).server.signRequest(req); err != nil {
+				t.Fatal(err)
+			}
+			reply, err := s1.(*service).server.GetRecords(cctx, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entry := reply.Logs[0]
+			if len(entry.Records) > 2 {
+				t.Fatalf("expected at most 2 records got %d", len(entry.Records))
+			}
+			for _, pr := range entry.Records {
+				r, err := cbor.RecordFromProto(pr, s1.(*service).followKeys(info.ID))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, r.Cid())
+			}
+			if !entry.More {
+				break
+			}
+			offset = got[len(got)-1]
+		}
+		if len(got) != len(created) {
+			t.Fatalf("expected %d records got %d", len(created), len(got))
+		}
+		for i, r := range created {
+			if !got[i].Equals(r.Value().Cid()) {
+				t.Fatalf("expected record %d to be %s got %s", i, r.Value().Cid(), got[i])
+			}
+		}
+
+		// Logs the requester doesn't know are signed by their owner
+		req := &pb.GetRecordsRequest{
+			ThreadID:  &pb.ProtoThreadID{ID: info.ID},
+			FollowKey: &pb.ProtoKey{Key: info.FollowKey},
+		}
+		if err := s2.(*service).server.signRequest(req); err != nil {
+			t.Fatal(err)
+		}
+		reply, err := s1.(*service).server.GetRecords(cctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reply.Logs) != 1 || reply.Logs[0].Log == nil {
+			t.Fatal("expected unknown log in reply")
+		}
+		if !verifyLog(info.ID, reply.Logs[0].Log) {
+			t.Fatal("expected log to be signed")
+		}
+	})
+}
+
+func TestService_AddFollower(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+
+	s1.Host().Peerstore().AddAddrs(s2.Host().ID(), s2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	s2.Host().Peerstore().AddAddrs(s1.Host().ID(), s1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	t.Run("test add follower", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s1.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+
+		addr, err := ma.NewMultiaddr("/p2p/" + s2.Host().ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s1.AddFollower(ctx, info.ID, addr); err != nil {
+			t.Fatal(err)
+		}
+
+		info2, err := s1.GetThread(context.Background(), info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(info2.Logs) != 1 {
+			t.Fatalf("expected 1 log got %d", len(info2.Logs))
+		}
+		if len(info2.Logs[0].Addrs) != 2 {
+			t.Fatalf("expected 2 addresses got %d", len(info2.Logs[0].Addrs))
+		}
+
+		info3, err := s2.GetThread(context.Background(), info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(info3.Logs) != 1 {
+			t.Fatalf("expected 1 log got %d", len(info2.Logs))
+		}
+		if len(info3.Logs[0].Addrs) != 2 {
+			t.Fatalf("expected 2 addresses got %d", len(info3.Logs[0].Addrs))
+		}
+	})
+}
+
+func TestService_AddReplicator(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeServiceWithConfig(t, Config{
+		Debug:      true,
+		Replicator: true,
+	})
+	defer s2.Close()
+
+	s1.Host().Peerstore().AddAddrs(s2.Host().ID(), s2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	s2.Host().Peerstore().AddAddrs(s1.Host().ID(), s1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	t.Run("test add replicator", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := s1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		addr, err := ma.NewMultiaddr("/p2p/" + s2.Host().ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s1.AddReplicator(ctx, info.ID, addr); err != nil {
+			t.Fatal(err)
+		}
+
+		info2, err := s2.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info2.FollowKey == nil {
+			t.Fatal("expected replicator to have the follow-key")
+		}
+		if info2.ReadKey != nil {
+			t.Fatal("expected replicator to not have the read-key")
+		}
+		if err = s2.PullThread(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.GetRecord(ctx, info.ID, r.Value().Cid()); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = s1.RotateKeys(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+		info3, err := s2.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info3.KeyEpoch != 1 {
+			t.Fatalf("expected key epoch 1 got %d", info3.KeyEpoch)
+		}
+		if info3.ReadKey != nil {
+			t.Fatal("expected replicator to not have the rotated read-key")
+		}
+	})
+
+	t.Run("test replicators are shared", func(t *testing.T) {
+		ctx := context.Background()
+		s3 := makeService(t)
+		defer s3.Close()
+		s3.Host().Peerstore().AddAddrs(s1.Host().ID(), s1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+		info := createThread(t, ctx, s1)
+		addr, err := ma.NewMultiaddr("/p2p/" + s2.Host().ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s1.AddReplicator(ctx, info.ID, addr); err != nil {
+			t.Fatal(err)
+		}
+
+		taddr, err := ma.NewMultiaddr("/p2p/" + s1.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s3.AddThread(ctx, taddr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err != nil {
+			t.Fatal(err)
+		}
+		invite, err := s3.CreateInvite(ctx, info.ID, s2.Host().ID(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inv, err := cbor.OpenInvite(invite, s2.(*service).getPrivKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inv.FollowKey == nil {
+			t.Fatal("expected invite to include the follow-key")
+		}
+		if inv.ReadKey != nil {
+			t.Fatal("expected invite to a replicator to not include the read-key")
+		}
+	})
+
+	t.Run("test replicator refuses read-keys", func(t *testing.T) {
+		ctx := context.Background()
+		rk, err := symmetric.CreateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s2.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.ReadKey(rk))
+		if !errors.Is(err, core.ErrReadKeyNotAllowed) {
+			t.Fatalf("expected read-key to be refused, got %v", err)
+		}
+	})
+}
+
+func TestService_Invites(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+	s3 := makeService(t)
+	defer s3.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, s1)
+
+	t.Run("test accept invite", func(t *testing.T) {
+		invite, err := s1.CreateInvite(ctx, info.ID, s2.Host().ID(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(invite, info.FollowKey.Bytes()) || bytes.Contains(invite, info.ReadKey.Bytes()) {
+			t.Fatal("expected invite keys to be sealed")
+		}
+		info2, err := s2.AcceptInvite(ctx, invite)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !info2.ID.Equals(info.ID) {
+			t.Fatalf("expected thread %s got %s", info.ID, info2.ID)
+		}
+		if !bytes.Equal(info2.FollowKey.Bytes(), info.FollowKey.Bytes()) {
+			t.Fatal("expected follow-key to match")
+		}
+		if !bytes.Equal(info2.ReadKey.Bytes(), info.ReadKey.Bytes()) {
+			t.Fatal("expected read-key to match")
+		}
+		if len(info2.Logs) != 1 {
+			t.Fatalf("expected 1 log got %d", len(info2.Logs))
+		}
+	})
+
+	t.Run("test accept invite for another peer", func(t *testing.T) {
+		invite, err := s1.CreateInvite(ctx, info.ID, s2.Host().ID(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s3.AcceptInvite(ctx, invite); err == nil {
+			t.Fatal("expected invite for another peer to be rejected")
+		}
+	})
+
+	t.Run("test accept expired invite", func(t *testing.T) {
+		invite, err := s1.CreateInvite(ctx, info.ID, s3.Host().ID(), time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond * 10)
+		if _, err = s3.AcceptInvite(ctx, invite); !errors.Is(err, core.ErrInviteExpired) {
+			t.Fatalf("expected invite expired error, got %v", err)
+		}
+	})
+
+	t.Run("test accept tampered invite", func(t *testing.T) {
+		invite, err := s1.CreateInvite(ctx, info.ID, s3.Host().ID(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		invite[len(invite)/2] ^= 0xff
+		if _, err = s3.AcceptInvite(ctx, invite); err == nil {
+			t.Fatal("expected tampered invite to be rejected")
+		}
+	})
+
+	t.Run("test accept invite with past keys", func(t *testing.T) {
+		info := createThread(t, ctx, s1)
+		info2, err := s1.RotateKeys(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		invite, err := s1.CreateInvite(ctx, info.ID, s3.Host().ID(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info3, err := s3.AcceptInvite(ctx, invite)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info3.KeyEpoch != info2.KeyEpoch || !bytes.Equal(info3.FollowKey.Bytes(), info2.FollowKey.Bytes()) {
+			t.Fatal("expected keys of the current epoch")
+		}
+		ts3 := s3.(*service)
+		fk, err := ts3.store.FollowKeyAt(info.ID, info.KeyEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rk, err := ts3.store.ReadKeyAt(info.ID, info.KeyEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fk == nil || !bytes.Equal(fk.Bytes(), info.FollowKey.Bytes()) {
+			t.Fatal("expected past follow-key to be stored")
+		}
+		if rk == nil || !bytes.Equal(rk.Bytes(), info.ReadKey.Bytes()) {
+			t.Fatal("expected past read-key to be stored")
+		}
+	})
+
+	t.Run("test accept invite with foreign address", func(t *testing.T) {
+		info := createThread(t, ctx, s1)
+		addr, err := ma.NewMultiaddr("/p2p/" + s2.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, err := s3.(*service).peerPubKey(s3.Host().ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		invite, err := cbor.CreateInvite(thread.Invite{
+			ID:        info.ID,
+			Addrs:     []ma.Multiaddr{addr},
+			FollowKey: info.FollowKey,
+			ReadKey:   info.ReadKey,
+		}, pk, s1.(*service).getPrivKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s3.AcceptInvite(ctx, invite); err == nil {
+			t.Fatal("expected invite with another peer's address to be rejected")
+		}
+	})
+}
+
+func TestService_RotateKeys(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test rotate keys", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r1, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info2, err := s.RotateKeys(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info2.KeyEpoch != info.KeyEpoch+1 {
+			t.Fatalf("expected key epoch %d got %d", info.KeyEpoch+1, info2.KeyEpoch)
+		}
+		if bytes.Equal(info2.ReadKey.Bytes(), info.ReadKey.Bytes()) {
+			t.Fatal("expected read key to change")
+		}
+
+		r2, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range []struct {
+			rec  core.ThreadRecord
+			info thread.Info
+		}{{r1, info}, {r2, info2}} {
+			rec, err := s.GetRecord(ctx, info.ID, c.rec.Value().Cid())
+			if err != nil {
+				t.Fatal(err)
+			}
+			event, err := cbor.GetEvent(ctx, s, rec.BlockID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if event.KeyEpoch() != c.info.KeyEpoch {
+				t.Fatalf("expected event key epoch %d got %d", c.info.KeyEpoch, event.KeyEpoch())
+			}
+			back, err := event.GetBody(ctx, s, c.info.ReadKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if body.String() != back.String() {
+				t.Fatalf("retrieved body does not equal input body")
+			}
+		}
+	})
+}
+
+func TestService_RotateKeysAuth(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+
+	s1.Host().Peerstore().AddAddrs(s2.Host().ID(), s2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	s2.Host().Peerstore().AddAddrs(s1.Host().ID(), s1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	t.Run("test only creator rotates keys", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		addr, err := ma.NewMultiaddr("/p2p/" + s1.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err != nil {
+			t.Fatal(err)
+		}
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = s2.RotateKeys(ctx, info.ID); !errors.Is(err, core.ErrUnauthorized) {
+			t.Fatalf("expected error %v got %v", core.ErrUnauthorized, err)
+		}
+
+		// A follower pushing keys of a new epoch directly is refused
+		ts2 := s2.(*service)
+		info2, err := s2.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fk, err := symmetric.CreateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rk, err := symmetric.CreateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ts2.store.AddKeysAt(info.ID, 1, fk, rk); err != nil {
+			t.Fatal(err)
+		}
+		err = ts2.server.pushLog(ctx, info.ID, *info2.GetOwnLog(), s1.Host().ID(), fk, rk, 1)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected permission denied got %v", err)
+		}
+		info3, err := s1.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info3.KeyEpoch != 0 {
+			t.Fatalf("expected key epoch 0 got %d", info3.KeyEpoch)
+		}
+
+		if _, err = s1.RotateKeys(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("test removed log records are rejected", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		addr, err := ma.NewMultiaddr("/p2p/" + s1.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err != nil {
+			t.Fatal(err)
+		}
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+		info2, err := s2.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lid := info2.GetOwnLog().ID
+
+		if _, err = s1.RotateKeys(ctx, info.ID, core.RemoveLog(lid)); err != nil {
+			t.Fatal(err)
+		}
+
+		// The removed log keeps writing with the old keys
+		r, err := s2.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s1.AddRecord(ctx, info.ID, lid, r.Value()); !errors.Is(err, core.ErrUnauthorized) {
+			t.Fatalf("expected error %v got %v", core.ErrUnauthorized, err)
+		}
+		if _, err = s1.GetRecord(ctx, info.ID, r.Value().Cid()); err == nil {
+			t.Fatal("expected record from removed log to be rejected")
+		}
+	})
+}
+
+func TestService_ACL(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test access control list", func(t *testing.T) {
+		ctx := context.Background()
+		rk, err := symmetric.CreateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := s.CreateThread(ctx, thread.NewIDV1(thread.AccessControlled, 32), core.ReadKey(rk))
+		if err != nil {
+			t.Fatal(err)
+		}
+		lg := info.GetOwnLog()
+		if lg == nil {
+			t.Fatal("own log not found")
+		}
+
+		list, err := s.GetACL(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !list.Allows(thread.Admin, lg.ID) {
+			t.Fatalf("expected own log to be %s", thread.Admin)
+		}
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = s.UpdateACL(ctx, info.ID, map[peer.ID]thread.Role{
+			lg.ID: thread.Reader,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		list, err = s.GetACL(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if list.Version != 1 {
+			t.Fatalf("expected acl version 1 got %d", list.Version)
+		}
+		if _, err = s.CreateRecord(ctx, info.ID, body); !errors.Is(err, core.ErrUnauthorized) {
+			t.Fatalf("expected error %v got %v", core.ErrUnauthorized, err)
+		}
+		if _, err = s.UpdateACL(ctx, info.ID, nil); !errors.Is(err, core.ErrUnauthorized) {
+			t.Fatalf("expected error %v got %v", core.ErrUnauthorized, err)
+		}
+	})
+
+	t.Run("test initial list from creator only", func(t *testing.T) {
+		ctx := context.Background()
+		ts := s.(*service)
+		id := thread.NewIDV1(thread.AccessControlled, 32)
+		fk, err := symmetric.CreateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ts.store.AddThread(thread.Info{ID: id, FollowKey: fk}); err != nil {
+			t.Fatal(err)
+		}
+		creator, err := createLog(ts.host.ID(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		other, err := createLog(ts.host.ID(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		event, err := cbor.CreateACLEvent(ctx, ts, thread.ACL{
+			Roles: map[peer.ID]thread.Role{other.ID: thread.Admin},
+		}, fk, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Without a list, no one has a role until the creator is known
+		if _, err = ts.authorizeRecord(ctx, id, other.ID, event); !errors.Is(err, core.ErrUnauthorized) {
+			t.Fatalf("expected error %v got %v", core.ErrUnauthorized, err)
+		}
+		if err = ts.checkACL(id, thread.Reader, other.ID); !errors.Is(err, core.ErrUnauthorized) {
+			t.Fatalf("expected error %v got %v", core.ErrUnauthorized, err)
+		}
+
+		if err = ts.putCreator(id, creator.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = ts.authorizeRecord(ctx, id, other.ID, event); !errors.Is(err, core.ErrUnauthorized) {
+			t.Fatalf("expected error %v got %v", core.ErrUnauthorized, err)
+		}
+		list, err := ts.authorizeRecord(ctx, id, creator.ID, event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if list == nil || !list.Allows(thread.Admin, other.ID) {
+			t.Fatal("expected initial list from creator")
+		}
+	})
+}
+
+func TestService_PeerPolicy(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+
+	s1.Host().Peerstore().AddAddrs(s2.Host().ID(), s2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	s2.Host().Peerstore().AddAddrs(s1.Host().ID(), s1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	t.Run("test peer policy", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		addr, err := ma.NewMultiaddr("/p2p/" + s1.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = s1.SetPeerPolicy(ctx, info.ID, thread.PeerPolicy{
+			Deny: []peer.ID{s2.Host().ID()},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err == nil {
+			t.Fatal("expected denied peer to not be able to add thread")
+		}
+
+		if err = s1.SetPeerPolicy(ctx, info.ID, thread.PeerPolicy{
+			LogPeersOnly: true,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err == nil {
+			t.Fatal("expected peer without a log to not be able to add thread")
+		}
+
+		if err = s1.SetPeerPolicy(ctx, info.ID, thread.PeerPolicy{
+			Allow: []peer.ID{s2.Host().ID()},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		policy, err := s1.GetPeerPolicy(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(policy.Allow) != 1 || policy.Allow[0] != s2.Host().ID() {
+			t.Fatalf("expected allow list with %s got %v", s2.Host().ID(), policy.Allow)
+		}
+		if _, err = s2.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("test peer policy on sent keys", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+		if err := s1.SetPeerPolicy(ctx, info.ID, thread.PeerPolicy{
+			Deny: []peer.ID{s2.Host().ID()},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		addr, err := ma.NewMultiaddr("/p2p/" + s2.Host().ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s1.AddFollower(ctx, info.ID, addr); !errors.Is(err, core.ErrPeerNotAllowed) {
+			t.Fatalf("expected keys to not be sent to a denied peer, got %v", err)
+		}
+		if info2, _ := s2.GetThread(ctx, info.ID); info2.FollowKey != nil {
+			t.Fatal("expected denied peer to not have the thread")
+		}
+	})
+}
+
+func TestService_Discovery(t *testing.T) {
+	t.Parallel()
+	routing := &mockRouting{providers: make(map[cid.Cid][]peer.AddrInfo)}
+	r1 := routing.router()
+	s1 := makeServiceWithConfig(t, Config{
+		Debug:   true,
+		Routing: r1,
+	})
+	defer s1.Close()
+	r1.setSelf(s1)
+	r2 := routing.router()
+	s2 := makeServiceWithConfig(t, Config{
+		Debug:   true,
+		Routing: r2,
+	})
+	defer s2.Close()
+	r2.setSelf(s2)
+
+	t.Run("test discover peers of a stranded thread", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := s1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		waitForProvider(t, routing, info.ID, s1.Host().ID())
+
+		// s2 only knows a stale dial address of s1
+		addr, err := ma.NewMultiaddr("/p2p/" + s1.Host().ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		s2.Host().Peerstore().AddAddr(s1.Host().ID(), util.MustParseAddr("/ip4/127.0.0.1/tcp/1"), peerstore.PermanentAddrTTL)
+		lg := info.GetOwnLog()
+		if lg == nil {
+			t.Fatal("own log not found")
+		}
+		ls := s2.(*service).store
+		if err = ls.AddThread(thread.Info{
+			ID:        info.ID,
+			FollowKey: info.FollowKey,
+			ReadKey:   info.ReadKey,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err = ls.AddLog(info.ID, thread.LogInfo{
+			ID:     lg.ID,
+			PubKey: lg.PubKey,
+			Addrs:  []ma.Multiaddr{addr},
+			Heads:  []cid.Cid{},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = s2.PullThread(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.GetRecord(ctx, info.ID, r.Value().Cid()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("test ignore providers that are not members", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		// An outsider announces the thread and records any request it gets
+		h, err := libp2p.New(ctx, libp2p.ListenAddrs(util.MustParseAddr("/ip4/127.0.0.1/tcp/0")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer h.Close()
+		contacted := make(chan struct{}, 1)
+		h.SetStreamHandler(thread.Protocol, func(s network.Stream) {
+			select {
+			case contacted <- struct{}{}:
+			default:
+			}
+			_ = s.Reset()
+		})
+		r3 := routing.router()
+		r3.lk.Lock()
+		r3.self = peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()}
+		r3.lk.Unlock()
+		if err = r3.Provide(ctx, threadKey(info.ID), true); err != nil {
+			t.Fatal(err)
+		}
+
+		// The only member s2 knows of is gone
+		sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gone, err := peer.IDFromPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr, err := ma.NewMultiaddr("/p2p/" + gone.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		lg := info.GetOwnLog()
+		ls := s2.(*service).store
+		if err = ls.AddThread(thread.Info{
+			ID:        info.ID,
+			FollowKey: info.FollowKey,
+			ReadKey:   info.ReadKey,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err = ls.AddLog(info.ID, thread.LogInfo{
+			ID:     lg.ID,
+			PubKey: lg.PubKey,
+			Addrs:  []ma.Multiaddr{addr},
+			Heads:  []cid.Cid{},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err = s2.PullThread(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case <-contacted:
+			t.Fatal("expected provider that is not a member to not be contacted")
+		case <-time.After(time.Millisecond * 100):
+		}
+		info2, err := s2.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range info2.Logs {
+			for _, pid := range addrPeers(l.Addrs) {
+				if pid == h.ID() {
+					t.Fatal("expected provider to not be added to log addresses")
+				}
+			}
+		}
+	})
+
+	t.Run("test discovery opt-out", func(t *testing.T) {
+		ctx := context.Background()
+		fk, err := symmetric.CreateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := thread.NewIDV1(thread.Raw, 32)
+		if _, err = s1.CreateThread(ctx, id, core.FollowKey(fk), core.NoDiscovery()); err != nil {
+			t.Fatal(err)
+		}
+		if err = s1.SetDiscovery(ctx, id, true); err != nil {
+			t.Fatal(err)
+		}
+		waitForProvider(t, routing, id, s1.Host().ID())
+
+		id2 := thread.NewIDV1(thread.Raw, 32)
+		if _, err = s2.CreateThread(ctx, id2, core.FollowKey(fk), core.NoDiscovery()); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond * 100)
+		if routing.provides(id2, s2.Host().ID()) {
+			t.Fatal("expected thread to not be announced")
+		}
+	})
+}
+
+func TestService_ListRecords(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test list records", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var created []core.ThreadRecord
+		for i := 0; i < 3; i++ {
+			r, err := s.CreateRecord(ctx, info.ID, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			created = append(created, r)
+		}
+		lid := created[0].LogID()
+
+		page, next, err := s.ListRecords(ctx, info.ID, lid, cid.Undef, cid.Undef, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) != 2 {
+			t.Fatalf("expected 2 records got %d", len(page))
+		}
+		if !page[0].Cid().Equals(created[2].Value().Cid()) {
+			t.Fatal("expected newest record first")
+		}
+		if !next.Equals(created[0].Value().Cid()) {
+			t.Fatalf("expected next to be %s got %s", created[0].Value().Cid(), next)
+		}
+		page, next, err = s.ListRecords(ctx, info.ID, lid, next, cid.Undef, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) != 1 || next.Defined() {
+			t.Fatal("expected last page")
+		}
+
+		it := core.NewRecordIterator(s, info.ID, "", 2)
+		var count int
+		for it.Next(ctx) {
+			count++
+		}
+		if it.Err() != nil {
+			t.Fatal(it.Err())
+		}
+		if count != len(created) {
+			t.Fatalf("expected %d records got %d", len(created), count)
+		}
+	})
+}
+
+func TestService_MultipleHeads(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test fork and merge", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r1, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r2, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Fork the log from r1, as a concurrent writer would
+		info, err = s.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lg := info.GetOwnLog()
+		event, err := cbor.CreateEvent(ctx, nil, body, info.ReadKey, info.KeyEpoch, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fork, err := cbor.CreateRecord(ctx, nil, event, []cid.Cid{r1.Value().Cid()}, lg.PrivKey, info.FollowKey, info.KeyEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.AddRecord(ctx, info.ID, lg.ID, fork); err != nil {
+			t.Fatal(err)
+		}
+		info, err = s.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		heads := info.GetOwnLog().Heads
+		if len(heads) != 2 {
+			t.Fatalf("expected 2 heads got %d", len(heads))
+		}
+		for _, h := range heads {
+			if !h.Equals(r2.Value().Cid()) && !h.Equals(fork.Cid()) {
+				t.Fatalf("unexpected head %s", h)
+			}
+		}
+
+		recs, _, err := s.ListRecords(ctx, info.ID, lg.ID, cid.Undef, cid.Undef, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(recs) != 3 {
+			t.Fatalf("expected 3 records got %d", len(recs))
+		}
+		if !recs[2].Cid().Equals(r1.Value().Cid()) {
+			t.Fatal("expected first record to be listed last")
+		}
+
+		// A new record from the owner merges the heads
+		r3, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prevs := r3.Value().PrevIDs(); len(prevs) != 2 {
+			t.Fatalf("expected merge record with 2 previous records got %d", len(prevs))
+		}
+		info, err = s.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		heads = info.GetOwnLog().Heads
+		if len(heads) != 1 || !heads[0].Equals(r3.Value().Cid()) {
+			t.Fatal("expected merge record to be the only head")
+		}
+
+		// Records since r2 skip its ancestors on the other branch
+		since, err := s.(*service).getRecordsSince(ctx, info.ID, *info.GetOwnLog(), r2.Value().Cid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(since) != 2 {
+			t.Fatalf("expected 2 records got %d", len(since))
+		}
+		if !since[0].Cid().Equals(fork.Cid()) || !since[1].Cid().Equals(r3.Value().Cid()) {
+			t.Fatal("expected the fork and merge records")
+		}
+	})
+
+	t.Run("test cached ancestors", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+		ss := s.(*service)
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var recs []core.ThreadRecord
+		for i := 0; i < 3; i++ {
+			r, err := s.CreateRecord(ctx, info.ID, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			recs = append(recs, r)
+		}
+		if _, err = ss.getAncestors(ctx, info.ID, recs[1].Value().Cid()); err != nil {
+			t.Fatal(err)
+		}
+
+		// The walk from a newer record stops at the cached ancestry, so it
+		// doesn't need the first record's block
+		if err = ss.bstore.DeleteBlock(recs[0].Value().Cid()); err != nil {
+			t.Fatal(err)
+		}
+		ancestors, err := ss.getAncestors(ctx, info.ID, recs[2].Value().Cid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ancestors) != 3 {
+			t.Fatalf("expected 3 ancestors got %d", len(ancestors))
+		}
+		if _, ok := ss.ancestors.Get(recs[2].Value().Cid()); !ok {
+			t.Fatal("expected complete ancestry to be cached")
+		}
+	})
+}
+
+func TestService_RecordValidator(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test record validator", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+		allowed, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r1, err := s.CreateRecord(ctx, info.ID, allowed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err = s.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lg := info.GetOwnLog()
+
+		s.SetRecordValidator(info.ID, core.RecordValidatorFunc(func(
+			_ context.Context,
+			_ thread.ID,
+			_ peer.ID,
+			_ core.Record,
+			_ core.EventHeader,
+			body format.Node,
+		) error {
+			m := make(map[string]interface{})
+			if err := cbornode.DecodeInto(body.RawData(), &m); err != nil {
+				return err
+			}
+			if _, ok := m["forbidden"]; ok {
+				return fmt.Errorf("forbidden field")
+			}
+			return nil
+		}))
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"forbidden": true,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		event, err := cbor.CreateEvent(ctx, nil, body, info.ReadKey, info.KeyEpoch, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := cbor.CreateRecord(ctx, nil, event, lg.Heads, lg.PrivKey, info.FollowKey, info.KeyEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The event was fetched before the record was validated
+		if err = s.Add(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.AddRecord(ctx, info.ID, lg.ID, rec); !errors.Is(err, core.ErrInvalidRecord) {
+			t.Fatalf("expected error %v got %v", core.ErrInvalidRecord, err)
+		}
+		heads, err := s.(*service).store.Heads(info.ID, lg.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(heads) != 1 || !heads[0].Equals(r1.Value().Cid()) {
+			t.Fatal("expected heads to be unchanged")
+		}
+		if _, err = s.GC(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := s.(*service).bstore.Has(event.Cid()); err != nil {
+			t.Fatal(err)
+		} else if ok {
+			t.Fatal("expected fetched block of rejected record to be collected")
+		}
+
+		s.SetRecordValidator(info.ID, nil)
+		if err = s.AddRecord(ctx, info.ID, lg.ID, rec); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestService_ExportImportThread(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+
+	t.Run("test export and import thread", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var last core.ThreadRecord
+		for i := 0; i < 3; i++ {
+			if last, err = s1.CreateRecord(ctx, info.ID, body); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var buf bytes.Buffer
+		if err = s1.ExportThread(ctx, info.ID, &buf); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.ImportThread(ctx, bytes.NewReader(buf.Bytes())); err == nil {
+			t.Fatal("expected import without a follow-key to fail")
+		}
+		info2, err := s2.ImportThread(ctx, &buf, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(info2.Logs) != 1 {
+			t.Fatalf("expected 1 log got %d", len(info2.Logs))
+		}
+		heads := info2.Logs[0].Heads
+		if len(heads) != 1 || !heads[0].Equals(last.Value().Cid()) {
+			t.Fatal("expected imported head to match exported head")
+		}
+		recs, _, err := s2.ListRecords(ctx, info.ID, last.LogID(), cid.Undef, cid.Undef, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(recs) != 3 {
+			t.Fatalf("expected 3 records got %d", len(recs))
+		}
+	})
+
+	t.Run("test import thread with rotated keys", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s1.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+		info2, err := s1.RotateKeys(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		last, err := s1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err = s1.ExportThread(ctx, info.ID, &buf); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+		if _, err = s2.ImportThread(ctx, bytes.NewReader(data), core.FollowKey(info2.FollowKey)); err == nil {
+			t.Fatal("expected import without past keys to fail")
+		}
+		if _, err = s2.ImportThread(
+			ctx,
+			bytes.NewReader(data),
+			core.FollowKey(info2.FollowKey),
+			core.ReadKey(info2.ReadKey),
+			core.PastKeys(info.KeyEpoch, info.FollowKey, info.ReadKey),
+		); err != nil {
+			t.Fatal(err)
+		}
+		recs, _, err := s2.ListRecords(ctx, info.ID, last.LogID(), cid.Undef, cid.Undef, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(recs) != 2 {
+			t.Fatalf("expected 2 records got %d", len(recs))
+		}
+		fk, err := s2.(*service).store.FollowKeyAt(info.ID, info.KeyEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fk == nil || !bytes.Equal(fk.Bytes(), info.FollowKey.Bytes()) {
+			t.Fatal("expected past follow-key to be stored")
+		}
+	})
+
+	t.Run("test import access controlled thread", func(t *testing.T) {
+		ctx := context.Background()
+		rk, err := symmetric.CreateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := s1.CreateThread(ctx, thread.NewIDV1(thread.AccessControlled, 32), core.ReadKey(rk))
+		if err != nil {
+			t.Fatal(err)
+		}
+		creator := info.GetOwnLog().ID
+
+		var buf bytes.Buffer
+		if err = s1.ExportThread(ctx, info.ID, &buf); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+		if _, err = s2.ImportThread(ctx, bytes.NewReader(data), core.FollowKey(info.FollowKey)); err == nil {
+			t.Fatal("expected import without a creator to fail")
+		}
+		if _, err = s2.ImportThread(
+			ctx,
+			bytes.NewReader(data),
+			core.FollowKey(info.FollowKey),
+			core.Creator(s2.Host().ID()),
+		); err == nil {
+			t.Fatal("expected import with another creator to fail")
+		}
+		if _, err = s2.ImportThread(
+			ctx,
+			bytes.NewReader(data),
+			core.FollowKey(info.FollowKey),
+			core.Creator(creator),
+		); err != nil {
+			t.Fatal(err)
+		}
+		list, err := s2.GetACL(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !list.Allows(thread.Admin, creator) {
+			t.Fatalf("expected creator to be %s", thread.Admin)
+		}
+	})
+
+	t.Run("test export and import pins", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+		child, err := cbornode.WrapObject("exported child", mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pinned, err := cbornode.WrapObject(map[string]interface{}{
+			"link": child.Cid(),
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s1.AddMany(ctx, []format.Node{child, pinned}); err != nil {
+			t.Fatal(err)
+		}
+		if err = s1.Pin(ctx, info.ID, pinned.Cid()); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err = s1.ExportThread(ctx, info.ID, &buf); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s2.ImportThread(ctx, &buf, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err != nil {
+			t.Fatal(err)
+		}
+		ts2 := s2.(*service)
+		pins, err := ts2.getPins(info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pins) != 1 || !pins[0].Equals(pinned.Cid()) {
+			t.Fatal("expected pin to be imported")
+		}
+		for _, c := range []cid.Cid{pinned.Cid(), child.Cid()} {
+			if ok, err := ts2.bstore.Has(c); err != nil {
+				t.Fatal(err)
+			} else if !ok {
+				t.Fatalf("expected pinned block %s to be imported", c)
+			}
+		}
+	})
+}
+
+func TestService_SubscribeCursor(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test subscribe with cursor", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		info := createThread(t, ctx, s)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r1, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r2, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sub, err := s.Subscribe(ctx, core.ThreadID(info.ID), core.Cursor(map[peer.ID]cid.Cid{
+			r1.LogID(): r1.Value().Cid(),
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r3, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, want := range []core.ThreadRecord{r2, r3} {
+			select {
+			case got := <-sub:
+				if !got.Value().Cid().Equals(want.Value().Cid()) {
+					t.Fatalf("expected record %s got %s", want.Value().Cid(), got.Value().Cid())
+				}
+			case <-time.After(time.Second * 5):
+				t.Fatal("timed out waiting for record")
+			}
+		}
+	})
+}
+
+func TestService_DeleteThread(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test delete thread", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := s.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sctx, cancel := context.WithCancel(ctx)
+		sub, err := s.Subscribe(sctx, core.ThreadID(info.ID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.DeleteThread(ctx, info.ID); err != core.ErrThreadInUse {
+			t.Fatalf("expected thread in use error, got %v", err)
+		}
+		cancel()
+		for range sub {
+		}
+
+		if err = s.DeleteThread(ctx, info.ID, core.DeleteBlocks(true)); err != nil {
+			t.Fatal(err)
+		}
+		info2, err := s.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(info2.Logs) != 0 {
+			t.Fatalf("expected 0 logs got %d", len(info2.Logs))
+		}
+		if info2.FollowKey != nil || info2.ReadKey != nil {
+			t.Fatal("expected thread keys to be deleted")
+		}
+		if _, err = s.Get(ctx, r.Value().Cid()); err == nil {
+			t.Fatal("expected record block to be deleted")
+		}
+		if err = s.DeleteThread(ctx, info.ID); err == nil {
+			t.Fatal("expected deleting a missing thread to fail")
+		}
+	})
+}
+
+func TestService_Outbox(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+
+	t.Run("test outbox", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+		if _, err := s2.CreateThread(
+			ctx,
+			info.ID,
+			core.FollowKey(info.FollowKey),
+			core.ReadKey(info.ReadKey),
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		// Each service knows the other's log, but the hosts can't reach each other
+		addLogs := func(from, to core.Service) {
+			tinfo, err := from.GetThread(ctx, info.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, lg := range tinfo.Logs {
+				lg.PrivKey = nil
+				if err := to.(*service).store.AddLog(info.ID, lg); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		addLogs(s1, s2)
+		addLogs(s2, s1)
+
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := s1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := s1.GetOutbox(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 outbox entry got %d", len(entries))
+		}
+		if entries[0].Peer != s2.Host().ID() || !entries[0].Record.Equals(r.Value().Cid()) {
+			t.Fatal("outbox entry does not match record")
+		}
+		if entries[0].Attempts != 1 || entries[0].LastError == "" {
+			t.Fatal("expected outbox entry to record the failed attempt")
+		}
 
-	t.Run("test add thread", func(t *testing.T) {
+		// Connecting the hosts triggers a retry
+		if sw, ok := s1.Host().Network().(*swarm.Swarm); ok {
+			sw.Backoff().Clear(s2.Host().ID()) // The failed push backed off the peer
+		}
+		if err = s1.Host().Connect(ctx, peer.AddrInfo{
+			ID:    s2.Host().ID(),
+			Addrs: s2.Host().Addrs(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		deadline := time.Now().Add(time.Second * 10)
+		for {
+			entries, err = s1.GetOutbox(ctx, info.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) == 0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected outbox to be empty, last error: %s", entries[0].LastError)
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+		if _, err = s2.GetRecord(ctx, info.ID, r.Value().Cid()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("test drop pushes", func(t *testing.T) {
 		ctx := context.Background()
 		info := createThread(t, ctx, s1)
-
+		ss := s1.(*service)
 		body, err := cbornode.WrapObject(map[string]interface{}{
-			"msg": "yo!",
+			"foo": "bar",
 		}, mh.SHA2_256, -1)
 		if err != nil {
 			t.Fatal(err)
 		}
-		if _, err = s1.CreateRecord(ctx, info.ID, body); err != nil {
+		for _, pid := range []peer.ID{s2.Host().ID(), ss.host.ID()} {
+			if err := ss.store.PutOutbox(info.ID, lstore.OutboxEntry{
+				Log:         ss.host.ID(),
+				Record:      body.Cid(),
+				Peer:        pid,
+				Attempts:    1,
+				NextAttempt: time.Now().Add(time.Hour),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := ss.dropPushes(info.ID, map[peer.ID]struct{}{s2.Host().ID(): {}}); err != nil {
+			t.Fatal(err)
+		}
+		entries, err := s1.GetOutbox(ctx, info.ID)
+		if err != nil {
 			t.Fatal(err)
 		}
+		if len(entries) != 1 || entries[0].Peer != ss.host.ID() {
+			t.Fatalf("expected only the outbox entry of the remaining peer, got %d", len(entries))
+		}
+	})
 
-		addr, err := ma.NewMultiaddr("/p2p/" + s1.Host().ID().String() + "/thread/" + info.ID.String())
+	t.Run("test outbox limits", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+		ss := s1.(*service)
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lg := info.GetOwnLog()
+		expired := lstore.OutboxEntry{
+			Log:         lg.ID,
+			Record:      body.Cid(),
+			Peer:        s2.Host().ID(),
+			Attempts:    1,
+			NextAttempt: time.Now().Add(time.Hour),
+			Created:     time.Now().Add(-OutboxTTL - time.Hour),
+		}
+		if err = ss.store.PutOutbox(info.ID, expired); err != nil {
+			t.Fatal(err)
+		}
+		ss.retryPushes("")
+		entries, err := s1.GetOutbox(ctx, info.ID)
 		if err != nil {
 			t.Fatal(err)
 		}
+		if len(entries) != 0 {
+			t.Fatalf("expected expired outbox entry to be dropped, got %d", len(entries))
+		}
 
-		info2, err := s2.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey))
+		// The last failed attempt drops the entry
+		r, err := s1.CreateRecord(ctx, info.ID, body)
 		if err != nil {
 			t.Fatal(err)
 		}
-		if len(info2.Logs) != 1 {
-			t.Fatalf("expected 1 log got %d", len(info2.Logs))
+		pid, err := pt.RandPeerID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		last := lstore.OutboxEntry{
+			Log:         lg.ID,
+			Record:      r.Value().Cid(),
+			Peer:        pid,
+			Attempts:    MaxPushAttempts - 1,
+			NextAttempt: time.Now(),
+			Created:     time.Now(),
+		}
+		if err = ss.store.PutOutbox(info.ID, last); err != nil {
+			t.Fatal(err)
+		}
+		if err = ss.retryPush(info.ID, last); err != nil {
+			t.Fatal(err)
+		}
+		if entries, err = s1.GetOutbox(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("expected outbox entry to be dropped after %d attempts", MaxPushAttempts)
 		}
 
-		body2, err := cbornode.WrapObject(map[string]interface{}{
-			"msg": "yo back!",
+		// The oldest entries over the peer limit are dropped
+		items := make([]outboxItem, MaxOutboxPeerEntries+1)
+		for i := range items {
+			items[i] = outboxItem{id: info.ID, e: lstore.OutboxEntry{
+				Log:     lg.ID,
+				Record:  body.Cid(),
+				Peer:    pid,
+				Created: time.Now().Add(time.Duration(i) * time.Second),
+			}}
+		}
+		oldest := items[0].e.Created
+		kept := ss.prunePushes(items)
+		if len(kept) != MaxOutboxPeerEntries {
+			t.Fatalf("expected %d entries got %d", MaxOutboxPeerEntries, len(kept))
+		}
+		for _, item := range kept {
+			if item.e.Created.Equal(oldest) {
+				t.Fatal("expected the oldest entry to be dropped")
+			}
+		}
+	})
+
+	t.Run("test offline peer catches up with rotated keys", func(t *testing.T) {
+		ctx := context.Background()
+		s3 := makeService(t)
+		defer s3.Close()
+		s4 := makeService(t)
+		defer s4.Close()
+		s4.Host().Peerstore().AddAddrs(s3.Host().ID(), s3.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+		info := createThread(t, ctx, s3)
+		addr, err := ma.NewMultiaddr("/p2p/" + s3.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s4.AddThread(ctx, addr, core.FollowKey(info.FollowKey), core.ReadKey(info.ReadKey)); err != nil {
+			t.Fatal(err)
+		}
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
 		}, mh.SHA2_256, -1)
 		if err != nil {
 			t.Fatal(err)
 		}
-		if _, err = s2.CreateRecord(ctx, info2.ID, body2); err != nil {
+
+		if _, err = s4.CreateRecord(ctx, info.ID, body); err != nil {
 			t.Fatal(err)
 		}
 
-		info3, err := s1.GetThread(context.Background(), info.ID)
+		// Take s4 offline for two rotations
+		s3.Host().Peerstore().ClearAddrs(s4.Host().ID())
+		s4.Host().Peerstore().ClearAddrs(s3.Host().ID())
+		if err = s3.Host().Network().ClosePeer(s4.Host().ID()); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			if _, err = s3.RotateKeys(ctx, info.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		entries, err := s3.GetOutbox(ctx, info.ID)
 		if err != nil {
 			t.Fatal(err)
 		}
-		if len(info3.Logs) != 2 {
-			t.Fatalf("expected 2 logs got %d", len(info3.Logs))
+		if len(entries) != 1 || entries[0].Record.Defined() || entries[0].Peer != s4.Host().ID() {
+			t.Fatalf("expected 1 key outbox entry got %d", len(entries))
+		}
+
+		if sw, ok := s3.Host().Network().(*swarm.Swarm); ok {
+			sw.Backoff().Clear(s4.Host().ID())
+		}
+		if err = s3.Host().Connect(ctx, peer.AddrInfo{
+			ID:    s4.Host().ID(),
+			Addrs: s4.Host().Addrs(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		info3, err := s3.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deadline := time.Now().Add(time.Second * 10)
+		for {
+			info4, err := s4.GetThread(ctx, info.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info4.KeyEpoch == 2 {
+				if !bytes.Equal(info4.FollowKey.Bytes(), info3.FollowKey.Bytes()) {
+					t.Fatal("expected follow-key of epoch 2")
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				entries, _ = s3.GetOutbox(ctx, info.ID)
+				if len(entries) > 0 {
+					t.Fatalf("expected key epoch 2 got %d, last error: %s", info4.KeyEpoch, entries[0].LastError)
+				}
+				t.Fatalf("expected key epoch 2 got %d", info4.KeyEpoch)
+			}
+			time.Sleep(time.Millisecond * 100)
 		}
 	})
 }
 
-func TestService_AddFollower(t *testing.T) {
+func TestService_PubsubValidator(t *testing.T) {
 	t.Parallel()
 	s1 := makeService(t)
 	defer s1.Close()
 	s2 := makeService(t)
 	defer s2.Close()
 
-	s1.Host().Peerstore().AddAddrs(s2.Host().ID(), s2.Host().Addrs(), peerstore.PermanentAddrTTL)
-	s2.Host().Peerstore().AddAddrs(s1.Host().ID(), s1.Host().Addrs(), peerstore.PermanentAddrTTL)
+	t.Run("test pubsub validator", func(t *testing.T) {
+		ctx := context.Background()
+		info := createThread(t, ctx, s1)
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := s1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	t.Run("test add follower", func(t *testing.T) {
+		srv := s1.(*service).server
+		req, err := srv.newPushRecordRequest(ctx, info.ID, r.LogID(), r.Value())
+		if err != nil {
+			t.Fatal(err)
+		}
+		message := func(from peer.ID, req *pb.PushRecordRequest) *pubsub.Message {
+			data, err := req.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return &pubsub.Message{Message: &pspb.Message{From: []byte(from), Data: data}}
+		}
+
+		if err = srv.checkMessage(info.ID, message(s1.Host().ID(), req)); err != nil {
+			t.Fatalf("expected message to be valid: %s", err)
+		}
+		if err = srv.checkMessage(info.ID, message(s2.Host().ID(), req)); err == nil {
+			t.Fatal("expected message from another author to be rejected")
+		}
+		other := createThread(t, ctx, s1)
+		if err = srv.checkMessage(other.ID, message(s1.Host().ID(), req)); err == nil {
+			t.Fatal("expected message for another thread to be rejected")
+		}
+		req.Header.Signature = []byte("bad")
+		if err = srv.checkMessage(info.ID, message(s1.Host().ID(), req)); err == nil {
+			t.Fatal("expected message with a bad signature to be rejected")
+		}
+	})
+}
+
+func TestService_SignedRequests(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+
+	t.Run("test signed requests", func(t *testing.T) {
 		ctx := context.Background()
 		info := createThread(t, ctx, s1)
+		srv1 := s1.(*service).server
+		srv2 := s2.(*service).server
+
+		// Requests as received over a libp2p connection from a peer
+		connCtx := func(pid peer.ID) context.Context {
+			return grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: testAddr(pid)})
+		}
+		code := func(err error) codes.Code {
+			return status.Convert(err).Code()
+		}
+
+		req := &pb.GetLogsRequest{
+			ThreadID:  &pb.ProtoThreadID{ID: info.ID},
+			FollowKey: &pb.ProtoKey{Key: info.FollowKey},
+		}
+		if _, err := srv1.GetLogs(connCtx(s2.Host().ID()), req); code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected unsigned request to fail with %s, got %s", codes.FailedPrecondition, err)
+		}
+
+		if err := srv2.signRequest(req); err != nil {
+			t.Fatal(err)
+		}
+		reply, err := srv1.GetLogs(connCtx(s2.Host().ID()), req)
+		if err != nil {
+			t.Fatalf("expected signed request to succeed: %s", err)
+		}
+		if len(reply.Logs) != 1 {
+			t.Fatalf("expected 1 log, got %d", len(reply.Logs))
+		}
+
+		if _, err = srv1.GetLogs(connCtx(s1.Host().ID()), req); code(err) != codes.PermissionDenied {
+			t.Fatalf("expected request from another peer to fail with %s, got %s", codes.PermissionDenied, err)
+		}
+
+		req.ThreadID = &pb.ProtoThreadID{ID: createThread(t, ctx, s1).ID}
+		if _, err = srv1.GetLogs(connCtx(s2.Host().ID()), req); code(err) != codes.Unauthenticated {
+			t.Fatalf("expected altered request to fail with %s, got %s", codes.Unauthenticated, err)
+		}
+	})
+}
+
+func TestService_PlaintextKeys(t *testing.T) {
+	t.Parallel()
+	s1 := makeService(t)
+	defer s1.Close()
+	s2 := makeService(t)
+	defer s2.Close()
+	s3 := makeServiceWithConfig(t, Config{
+		Debug:         true,
+		PlaintextKeys: true,
+	})
+	defer s3.Close()
+
+	t.Run("test plaintext keys", func(t *testing.T) {
+		ctx := grpcpeer.NewContext(context.Background(), &grpcpeer.Peer{Addr: testAddr(s1.Host().ID())})
+		info := createThread(t, ctx, s1)
+		lg := info.GetOwnLog()
+		req := &pb.PushLogRequest{
+			ThreadID:  &pb.ProtoThreadID{ID: info.ID},
+			FollowKey: &pb.ProtoKey{Key: info.FollowKey},
+			Log:       logToProto(*lg),
+		}
+		if err := signLog(info.ID, *lg, req.Log); err != nil {
+			t.Fatal(err)
+		}
+		if err := s1.(*service).server.signRequest(req); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s2.(*service).server.PushLog(ctx, req); status.Convert(err).Code() != codes.InvalidArgument {
+			t.Fatalf("expected plaintext keys to be rejected, got %v", err)
+		}
+		if _, err := s3.(*service).server.PushLog(ctx, req); err != nil {
+			t.Fatalf("expected plaintext keys to be accepted when allowed: %s", err)
+		}
+	})
+}
+
+// testAddr is the address of a libp2p connection to a peer.
+type testAddr peer.ID
+
+func (a testAddr) Network() string { return gostream.Network }
 
+func (a testAddr) String() string { return peer.ID(a).Pretty() }
+
+func TestService_CausalOrder(t *testing.T) {
+	t.Parallel()
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test causal order", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		info := createThread(t, ctx, s)
 		body, err := cbornode.WrapObject(map[string]interface{}{
-			"msg": "yo!",
+			"foo": "bar",
 		}, mh.SHA2_256, -1)
 		if err != nil {
 			t.Fatal(err)
 		}
-		if _, err := s1.CreateRecord(ctx, info.ID, body); err != nil {
+
+		// Records from two other writers, the second depending on the first
+		newLog := func() thread.LogInfo {
+			lg, err := createLog(s.Host().ID(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = s.(*service).store.AddLog(info.ID, lg); err != nil {
+				t.Fatal(err)
+			}
+			return lg
+		}
+		newRecord := func(lg thread.LogInfo, seen map[cid.Cid]uint64) core.Record {
+			event, err := cbor.CreateEvent(ctx, nil, body, info.ReadKey, info.KeyEpoch, seen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec, err := cbor.CreateRecord(ctx, nil, event, nil, lg.PrivKey, info.FollowKey, info.KeyEpoch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return rec
+		}
+		lg1, lg2 := newLog(), newLog()
+		r1 := newRecord(lg1, nil)
+		r2 := newRecord(lg2, map[cid.Cid]uint64{r1.Cid(): 1})
+
+		sub, err := s.Subscribe(ctx, core.ThreadID(info.ID), core.Causal())
+		if err != nil {
 			t.Fatal(err)
 		}
 
-		addr, err := ma.NewMultiaddr("/p2p/" + s2.Host().ID().String())
+		// The dependent record is held until its dependency arrives
+		if err = s.AddRecord(ctx, info.ID, lg2.ID, r2); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case rec := <-sub:
+			t.Fatalf("expected record %s to be held", rec.Value().Cid())
+		case <-time.After(time.Millisecond * 200):
+		}
+		if err = s.AddRecord(ctx, info.ID, lg1.ID, r1); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []cid.Cid{r1.Cid(), r2.Cid()} {
+			select {
+			case rec := <-sub:
+				if !rec.Value().Cid().Equals(want) {
+					t.Fatalf("expected record %s got %s", want, rec.Value().Cid())
+				}
+			case <-time.After(time.Second * 5):
+				t.Fatalf("timed out waiting for record %s", want)
+			}
+		}
+
+		// A new record follows the clocks of all heads
+		r3, err := s.CreateRecord(ctx, info.ID, body)
 		if err != nil {
 			t.Fatal(err)
 		}
-		if _, err = s1.AddFollower(ctx, info.ID, addr); err != nil {
+		clock, deps, err := s.(*service).recordClock(ctx, info.ID, r3.Value())
+		if err != nil {
 			t.Fatal(err)
 		}
+		if clock != 3 {
+			t.Fatalf("expected clock 3 got %d", clock)
+		}
+		if len(deps) != 2 {
+			t.Fatalf("expected 2 dependencies got %d", len(deps))
+		}
 
-		info2, err := s1.GetThread(context.Background(), info.ID)
+		it, err := s.CausalRecords(ctx, info.ID)
 		if err != nil {
 			t.Fatal(err)
 		}
-		if len(info2.Logs) != 1 {
-			t.Fatalf("expected 1 log got %d", len(info2.Logs))
+		var order []cid.Cid
+		for it.Next() {
+			order = append(order, it.Record().Value().Cid())
 		}
-		if len(info2.Logs[0].Addrs) != 2 {
-			t.Fatalf("expected 2 addresses got %d", len(info2.Logs[0].Addrs))
+		want := []cid.Cid{r1.Cid(), r2.Cid(), r3.Value().Cid()}
+		if len(order) != len(want) {
+			t.Fatalf("expected %d records got %d", len(want), len(order))
+		}
+		for i := range want {
+			if !order[i].Equals(want[i]) {
+				t.Fatalf("expected record %d to be %s got %s", i, want[i], order[i])
+			}
 		}
+	})
+}
 
-		info3, err := s2.GetThread(context.Background(), info.ID)
+func TestService_CausalWait(t *testing.T) {
+	// Not parallel, since it changes the wait of causal subscriptions
+	wait := MaxCausalWait
+	MaxCausalWait = time.Millisecond * 200
+	defer func() { MaxCausalWait = wait }()
+
+	s := makeService(t)
+	defer s.Close()
+
+	t.Run("test missing dependency", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		info := createThread(t, ctx, s)
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": "bar",
+		}, mh.SHA2_256, -1)
 		if err != nil {
 			t.Fatal(err)
 		}
-		if len(info3.Logs) != 1 {
-			t.Fatalf("expected 1 log got %d", len(info2.Logs))
+		lg, err := createLog(s.Host().ID(), nil)
+		if err != nil {
+			t.Fatal(err)
 		}
-		if len(info3.Logs[0].Addrs) != 2 {
-			t.Fatalf("expected 2 addresses got %d", len(info3.Logs[0].Addrs))
+		if err = s.(*service).store.AddLog(info.ID, lg); err != nil {
+			t.Fatal(err)
+		}
+
+		// A record that depends on a record that never arrives
+		sum, err := mh.Sum([]byte("missing"), mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		missing := cid.NewCidV1(cid.Raw, sum)
+		event, err := cbor.CreateEvent(ctx, nil, body, info.ReadKey, info.KeyEpoch, map[cid.Cid]uint64{missing: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := cbor.CreateRecord(ctx, nil, event, nil, lg.PrivKey, info.FollowKey, info.KeyEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sub, err := s.Subscribe(ctx, core.ThreadID(info.ID), core.Causal())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.AddRecord(ctx, info.ID, lg.ID, rec); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case r := <-sub:
+			if !r.Value().Cid().Equals(rec.Cid()) {
+				t.Fatalf("expected record %s got %s", rec.Cid(), r.Value().Cid())
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("expected held record to be delivered after the wait")
 		}
 	})
 }
@@ -207,7 +2801,91 @@ func TestClose(t *testing.T) {
 	})
 }
 
+// mockRouting is an in-memory content routing system shared by test hosts.
+type mockRouting struct {
+	sync.Mutex
+	providers map[cid.Cid][]peer.AddrInfo
+}
+
+// router returns a content router that provides as the host set with setSelf.
+func (r *mockRouting) router() *mockRouter {
+	return &mockRouter{routing: r}
+}
+
+// provides returns whether or not pid announced thread id.
+func (r *mockRouting) provides(id thread.ID, pid peer.ID) bool {
+	r.Lock()
+	defer r.Unlock()
+	for _, p := range r.providers[threadKey(id)] {
+		if p.ID == pid {
+			return true
+		}
+	}
+	return false
+}
+
+type mockRouter struct {
+	routing *mockRouting
+	lk      sync.Mutex
+	self    peer.AddrInfo
+}
+
+func (r *mockRouter) setSelf(s core.Service) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.self = peer.AddrInfo{ID: s.Host().ID(), Addrs: s.Host().Addrs()}
+}
+
+func (r *mockRouter) Provide(_ context.Context, c cid.Cid, _ bool) error {
+	r.lk.Lock()
+	self := r.self
+	r.lk.Unlock()
+	if self.ID == "" {
+		return fmt.Errorf("host not set")
+	}
+	r.routing.Lock()
+	defer r.routing.Unlock()
+	for _, p := range r.routing.providers[c] {
+		if p.ID == self.ID {
+			return nil
+		}
+	}
+	r.routing.providers[c] = append(r.routing.providers[c], self)
+	return nil
+}
+
+func (r *mockRouter) FindProvidersAsync(_ context.Context, c cid.Cid, count int) <-chan peer.AddrInfo {
+	r.routing.Lock()
+	defer r.routing.Unlock()
+	ps := r.routing.providers[c]
+	if count > 0 && len(ps) > count {
+		ps = ps[:count]
+	}
+	ch := make(chan peer.AddrInfo, len(ps))
+	for _, p := range ps {
+		ch <- p
+	}
+	close(ch)
+	return ch
+}
+
+func waitForProvider(t *testing.T, r *mockRouting, id thread.ID, pid peer.ID) {
+	for i := 0; i < 50; i++ {
+		if r.provides(id, pid) {
+			return
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	t.Fatalf("expected %s to announce thread %s", pid, id)
+}
+
 func makeService(t *testing.T) core.Service {
+	return makeServiceWithConfig(t, Config{
+		Debug: true,
+	})
+}
+
+func makeServiceWithConfig(t *testing.T, conf Config) core.Service {
 	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
 	if err != nil {
 		t.Fatal(err)
@@ -230,9 +2908,7 @@ func makeService(t *testing.T) core.Service {
 		bsrv.Blockstore(),
 		dag.NewDAGService(bsrv),
 		tstore.NewLogstore(),
-		Config{
-			Debug: true,
-		})
+		conf)
 	if err != nil {
 		t.Fatal(err)
 	}
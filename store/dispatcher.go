@@ -7,10 +7,12 @@ import (
 	"encoding/gob"
 	"strconv"
 	"sync"
+	"time"
 
 	datastore "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	core "github.com/textileio/go-threads/core/store"
+	"github.com/textileio/go-threads/metrics"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -62,6 +64,7 @@ func (d *dispatcher) Register(reducer Reducer) {
 func (d *dispatcher) Dispatch(events []core.Event) error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
+	defer metrics.ObserveSince(metrics.DispatchDuration, time.Now())
 
 	txn, err := d.store.NewTransaction(false)
 	if err != nil {
@@ -69,6 +72,9 @@ func (d *dispatcher) Dispatch(events []core.Event) error {
 	}
 	defer txn.Discard()
 	for _, event := range events {
+		if ce, ok := event.(*codecEvent); ok {
+			event = ce.Event
+		}
 		key, err := getKey(event)
 		if err != nil {
 			return err
@@ -130,8 +130,11 @@ func getStoreConfig(id uuid.UUID, base *Config) *Config {
 		Datastore: wrapTxnDatastore(base.Datastore, kt.PrefixTransform{
 			Prefix: dsStoreManagerBaseKey.ChildString(id.String()),
 		}),
-		EventCodec: base.EventCodec,
-		JsonMode:   base.JsonMode,
-		Debug:      base.Debug,
+		EventCodec:     base.EventCodec,
+		EventCodecName: base.EventCodecName,
+		EventCodecs:    base.EventCodecs,
+		SchemaVersion:  base.SchemaVersion,
+		JsonMode:       base.JsonMode,
+		Debug:          base.Debug,
 	}
 }
@@ -19,11 +19,14 @@ type Option func(*Config) error
 
 // Config has configuration parameters for a store
 type Config struct {
-	RepoPath   string
-	Datastore  ds.TxnDatastore
-	EventCodec core.EventCodec
-	JsonMode   bool
-	Debug      bool
+	RepoPath       string
+	Datastore      ds.TxnDatastore
+	EventCodec     core.EventCodec
+	EventCodecName string
+	EventCodecs    map[string]core.EventCodec
+	SchemaVersion  string
+	JsonMode       bool
+	Debug          bool
 }
 
 func newDefaultEventCodec(jsonMode bool) core.EventCodec {
@@ -68,3 +71,33 @@ func WithEventCodec(ec core.EventCodec) Option {
 		return nil
 	}
 }
+
+// WithEventCodecName names the EventCodec in the headers of
+// created events, so that peers can decode them with the same codec
+func WithEventCodecName(name string) Option {
+	return func(sc *Config) error {
+		sc.EventCodecName = name
+		return nil
+	}
+}
+
+// WithRemoteEventCodec decodes remote events whose header names
+// the codec name with ec, instead of the EventCodec
+func WithRemoteEventCodec(name string, ec core.EventCodec) Option {
+	return func(sc *Config) error {
+		if sc.EventCodecs == nil {
+			sc.EventCodecs = make(map[string]core.EventCodec)
+		}
+		sc.EventCodecs[name] = ec
+		return nil
+	}
+}
+
+// WithSchemaVersion indicates the application schema version
+// written to the headers of created events
+func WithSchemaVersion(v string) Option {
+	return func(sc *Config) error {
+		sc.SchemaVersion = v
+		return nil
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"time"
 
 	dsquery "github.com/ipfs/go-datastore/query"
+	"github.com/textileio/go-threads/metrics"
 )
 
 var (
@@ -87,6 +89,8 @@ func (q *Query) OrderByDesc(field string) *Query {
 // Find executes a query and store the result in res which should be a slice of
 // pointers with the correct model type. If the slice isn't empty, will be emptied.
 func (t *Txn) Find(res interface{}, q *Query) error {
+	defer metrics.ObserveSince(metrics.QueryDuration.WithLabelValues(t.model.name), time.Now())
+
 	valRes := reflect.ValueOf(res)
 	if valRes.Kind() != reflect.Ptr || // Should be a pointer
 		valRes.Elem().Kind() != reflect.Slice || // To a slice
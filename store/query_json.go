@@ -5,6 +5,9 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
+
+	"github.com/textileio/go-threads/metrics"
 )
 
 // JSONQuery is a json-seriable query representation
@@ -192,6 +195,8 @@ func (c *JSONCriterion) createcriterion(op JSONOperation, value interface{}) *JS
 
 // FindJSON queries for entities by JSONQuery
 func (t *Txn) FindJSON(q *JSONQuery) ([]string, error) {
+	defer metrics.ObserveSince(metrics.QueryDuration.WithLabelValues(t.model.name), time.Now())
+
 	txn, err := t.model.store.datastore.NewTransaction(true)
 	if err != nil {
 		return nil, fmt.Errorf("error building internal query: %v", err)
@@ -17,16 +17,25 @@ import (
 	logging "github.com/ipfs/go-log"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/broadcast"
+	"github.com/textileio/go-threads/cbor"
 	service "github.com/textileio/go-threads/core/service"
 	core "github.com/textileio/go-threads/core/store"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/jsonpatcher"
+	"github.com/textileio/go-threads/metrics"
+	"github.com/textileio/go-threads/tracing"
 	"github.com/textileio/go-threads/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	idFieldName = "ID"
 	busTimeout  = time.Second * 10
+
+	// EventContentType is the content type of records holding store events.
+	EventContentType = "application/vnd.threads.store-events"
 )
 
 var (
@@ -38,6 +47,7 @@ var (
 	ErrInvalidModelType = errors.New("the model type should be a non-nil pointer to a struct")
 
 	log             = logging.Logger("store")
+	tracer          = tracing.Tracer("store")
 	dsStorePrefix   = ds.NewKey("/store")
 	dsStoreThreadID = dsStorePrefix.ChildString("threadid")
 	dsStoreSchemas  = dsStorePrefix.ChildString("schema")
@@ -54,11 +64,14 @@ type Store struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	datastore  ds.TxnDatastore
-	dispatcher *dispatcher
-	eventcodec core.EventCodec
-	service    service.Service
-	adapter    *singleThreadAdapter
+	datastore      ds.TxnDatastore
+	dispatcher     *dispatcher
+	eventcodec     core.EventCodec
+	eventcodecName string
+	eventcodecs    map[string]core.EventCodec
+	schemaVersion  string
+	service        service.Service
+	adapter        *singleThreadAdapter
 
 	lock       sync.RWMutex
 	modelNames map[string]*Model
@@ -99,6 +112,8 @@ func NewStore(ts service.Service, opts ...Option) (*Store, error) {
 // newStore is used directly by a store manager to create new stores
 // with the same config.
 func newStore(ts service.Service, config *Config) (*Store, error) {
+	metrics.RegisterStore()
+
 	if config.Datastore == nil {
 		datastore, err := newDefaultDatastore(config.RepoPath)
 		if err != nil {
@@ -108,6 +123,9 @@ func newStore(ts service.Service, config *Config) (*Store, error) {
 	}
 	if config.EventCodec == nil {
 		config.EventCodec = newDefaultEventCodec(config.JsonMode)
+		if config.EventCodecName == "" {
+			config.EventCodecName = jsonpatcher.Name
+		}
 	}
 	if !managedDatastore(config.Datastore) {
 		if config.Debug {
@@ -124,6 +142,9 @@ func newStore(ts service.Service, config *Config) (*Store, error) {
 		datastore:           config.Datastore,
 		dispatcher:          newDispatcher(config.Datastore),
 		eventcodec:          config.EventCodec,
+		eventcodecName:      config.EventCodecName,
+		eventcodecs:         config.EventCodecs,
+		schemaVersion:       config.SchemaVersion,
 		modelNames:          make(map[string]*Model),
 		jsonMode:            config.JsonMode,
 		localEventsBus:      &localEventsBus{bus: broadcast.NewBroadcaster(0)},
@@ -236,6 +257,27 @@ func (s *Store) StartFromAddr(addr ma.Multiaddr, followKey, readKey *symmetric.K
 	return nil
 }
 
+// StartFromInvite should be called immediatelly after registering all schemas
+// and before any operation on them. It pulls the current Store thread from
+// an invite created for this host.
+func (s *Store) StartFromInvite(invite []byte) error {
+	host := s.service.Host()
+	inv, err := cbor.OpenInvite(invite, host.Peerstore().PrivKey(host.ID()))
+	if err != nil {
+		return err
+	}
+	if err := s.datastore.Put(dsStoreThreadID, inv.ID.Bytes()); err != nil {
+		return err
+	}
+	if err = s.Start(); err != nil {
+		return err
+	}
+	if _, err = s.service.AcceptInvite(s.ctx, invite); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Service returns the Service used by the store
 func (s *Store) Service() service.Service {
 	return s.service
@@ -315,8 +357,23 @@ func (s *Store) GetModel(name string) *Model {
 }
 
 // Reduce processes txn events into the models.
+// Remote events are reduced by the codec that decoded them.
 func (s *Store) Reduce(events []core.Event) error {
-	codecActions, err := s.eventcodec.Reduce(
+	defer metrics.ObserveSince(metrics.ReduceDuration, time.Now())
+
+	codec := s.eventcodec
+	if len(events) > 0 {
+		if ce, ok := events[0].(*codecEvent); ok {
+			// Events are dispatched in batches from a single codec
+			codec = ce.codec
+			unwrapped := make([]core.Event, len(events))
+			for i, e := range events {
+				unwrapped[i] = e.(*codecEvent).Event
+			}
+			events = unwrapped
+		}
+	}
+	codecActions, err := codec.Reduce(
 		events,
 		s.datastore,
 		baseKey,
@@ -345,18 +402,38 @@ func (s *Store) Reduce(events []core.Event) error {
 	return nil
 }
 
-// dispatch applies external events to the store. This function guarantee
-// no interference with registered model states, and viceversa.
-func (s *Store) dispatch(events []core.Event) error {
+// dispatch applies external events decoded by codec to the store. This
+// function guarantee no interference with registered model states, and viceversa.
+func (s *Store) dispatch(ctx context.Context, codec core.EventCodec, events []core.Event) (err error) {
+	_, span := tracer.Start(ctx, "Store.dispatch", trace.WithAttributes(attribute.Int("events", len(events))))
+	defer func() { tracing.End(span, err) }()
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	if codec != s.eventcodec {
+		wrapped := make([]core.Event, len(events))
+		for i, e := range events {
+			wrapped[i] = &codecEvent{Event: e, codec: codec}
+		}
+		events = wrapped
+	}
 	return s.dispatcher.Dispatch(events)
 }
 
-// eventFromBytes generates an Event from its binary representation using
-// the underlying EventCodec configured in the Store.
-func (s *Store) eventsFromBytes(data []byte) ([]core.Event, error) {
-	return s.eventcodec.EventsFromBytes(data)
+// eventCodec returns the codec of events whose header names the given codec.
+// Events without a codec name use the EventCodec configured in the Store.
+// It returns nil for unknown codecs.
+func (s *Store) eventCodec(name string) core.EventCodec {
+	if name == "" || name == s.eventcodecName {
+		return s.eventcodec
+	}
+	return s.eventcodecs[name]
+}
+
+// codecEvent is a remote event decoded by a codec other than the Store's EventCodec.
+type codecEvent struct {
+	core.Event
+	codec core.EventCodec
 }
 
 func (s *Store) readTxn(m *Model, f func(txn *Txn) error) error {
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	threadcbor "github.com/textileio/go-threads/cbor"
 	service "github.com/textileio/go-threads/core/service"
+	core "github.com/textileio/go-threads/core/store"
 	"github.com/textileio/go-threads/core/thread"
 )
 
@@ -85,7 +87,8 @@ func (a *singleThreadAdapter) threadToStore(wg *sync.WaitGroup) {
 	defer a.goRoutines.Done()
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	sub, err := a.api.Subscribe(ctx, service.ThreadID(a.threadID))
+	// Records are reduced in causal order so that replicas converge
+	sub, err := a.api.Subscribe(ctx, service.ThreadID(a.threadID), service.Causal())
 	if err != nil {
 		log.Fatalf("error getting thread subscription: %v", err)
 	}
@@ -115,23 +118,37 @@ func (a *singleThreadAdapter) threadToStore(wg *sync.WaitGroup) {
 					log.Fatalf("error when decoding block to event: %v", err)
 				}
 			}
-			info, err := a.api.GetThread(ctx, a.threadID)
+			if event.IsACL() {
+				cancel()
+				continue
+			}
+			_, rk, err := a.api.GetKeysAt(ctx, a.threadID, event.KeyEpoch())
 			if err != nil {
-				log.Fatalf("error when getting info for thread %s: %v", a.threadID, err)
+				log.Fatalf("error when getting keys for thread %s: %v", a.threadID, err)
+			}
+			if rk == nil {
+				log.Fatalf("read key for epoch %d not found for thread %s/%s", event.KeyEpoch(), a.threadID, rec.LogID())
 			}
-			if info.ReadKey == nil {
-				log.Fatalf("read key not found for thread %s/%s", a.threadID, rec.LogID())
+			header, err := event.GetHeader(ctx, a.api, rk)
+			if err != nil {
+				log.Fatalf("error when getting header of event on thread %s/%s: %v", a.threadID, rec.LogID(), err)
 			}
-			node, err := event.GetBody(ctx, a.api, info.ReadKey)
+			codec, err := a.codecFromHeader(header)
+			if err != nil {
+				log.Warnf("skipping record on thread %s/%s: %v", a.threadID, rec.LogID(), err)
+				cancel()
+				continue
+			}
+			node, err := event.GetBody(ctx, a.api, rk)
 			if err != nil {
 				log.Fatalf("error when getting body of event on thread %s/%s: %v", a.threadID, rec.LogID(), err)
 			}
-			storeEvents, err := a.store.eventsFromBytes(node.RawData())
+			storeEvents, err := codec.EventsFromBytes(node.RawData())
 			if err != nil {
 				log.Fatalf("error when unmarshaling event from bytes: %v", err)
 			}
 			log.Debugf("dispatching to store external new record: %s/%s", rec.ThreadID(), rec.LogID())
-			if err := a.store.dispatch(storeEvents); err != nil {
+			if err := a.store.dispatch(ctx, codec, storeEvents); err != nil {
 				log.Fatal(err)
 			}
 			cancel()
@@ -156,7 +173,14 @@ func (a *singleThreadAdapter) storeToThread(wg *sync.WaitGroup) {
 				return
 			}
 			ctx, cancel := context.WithTimeout(context.Background(), addRecordTimeout)
-			if _, err := a.api.CreateRecord(ctx, a.threadID, node); err != nil {
+			if _, err := a.api.CreateRecord(
+				ctx,
+				a.threadID,
+				node,
+				service.ContentType(EventContentType),
+				service.Codec(a.store.eventcodecName),
+				service.SchemaVersion(a.store.schemaVersion),
+			); err != nil {
 				log.Fatalf("error writing record: %v", err)
 			}
 			cancel()
@@ -164,6 +188,27 @@ func (a *singleThreadAdapter) storeToThread(wg *sync.WaitGroup) {
 	}
 }
 
+// codecFromHeader returns the codec that decodes the body of an event.
+// Headers of older events without metadata use the store's EventCodec.
+func (a *singleThreadAdapter) codecFromHeader(header service.EventHeader) (core.EventCodec, error) {
+	ct, err := header.ContentType()
+	if err != nil {
+		return nil, err
+	}
+	if ct != "" && ct != EventContentType {
+		return nil, fmt.Errorf("content type %s doesn't hold store events", ct)
+	}
+	name, err := header.Codec()
+	if err != nil {
+		return nil, err
+	}
+	codec := a.store.eventCodec(name)
+	if codec == nil {
+		return nil, fmt.Errorf("unknown event codec %s", name)
+	}
+	return codec, nil
+}
+
 func (a *singleThreadAdapter) getBlockWithRetry(ctx context.Context, rec service.Record, cantRetries int, backoffTime time.Duration) (format.Node, error) {
 	var err error
 	for i := 1; i <= cantRetries; i++ {
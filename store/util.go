@@ -18,6 +18,7 @@ import (
 	"github.com/libp2p/go-libp2p-peerstore/pstoreds"
 	ma "github.com/multiformats/go-multiaddr"
 	coreservice "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/crypto/symmetric"
 	"github.com/textileio/go-threads/logstore/lstoreds"
 	"github.com/textileio/go-threads/service"
 	util "github.com/textileio/go-threads/util"
@@ -101,7 +102,19 @@ func DefaultService(repoPath string, opts ...ServiceOption) (ServiceBoostrapper,
 		litestore.Close()
 		return nil, err
 	}
-	tstore, err := lstoreds.NewLogstore(ctx, logstore, lstoreds.DefaultOpts())
+	lopts := lstoreds.DefaultOpts()
+	if config.KeyPassphrase != nil {
+		lopts.MasterKey, err = lstoreds.MasterKeyFromPassphrase(logstore, config.KeyPassphrase)
+		if err != nil {
+			cancel()
+			if err := logstore.Close(); err != nil {
+				return nil, err
+			}
+			litestore.Close()
+			return nil, err
+		}
+	}
+	tstore, err := lstoreds.NewLogstore(ctx, logstore, lopts)
 	if err != nil {
 		cancel()
 		if err := logstore.Close(); err != nil {
@@ -113,7 +126,12 @@ func DefaultService(repoPath string, opts ...ServiceOption) (ServiceBoostrapper,
 
 	// Build a service
 	api, err := service.NewService(ctx, h, lite.BlockStore(), lite, tstore, service.Config{
-		Debug: config.Debug,
+		Debug:         config.Debug,
+		GCInterval:    config.GCInterval,
+		Replicator:    config.Replicator,
+		PlaintextKeys: config.PlaintextKeys,
+		Algorithm:     config.Algorithm,
+		Routing:       d,
 	}, config.GRPCOptions...)
 	if err != nil {
 		cancel()
@@ -137,9 +155,14 @@ func DefaultService(repoPath string, opts ...ServiceOption) (ServiceBoostrapper,
 }
 
 type ServiceConfig struct {
-	HostAddr    ma.Multiaddr
-	Debug       bool
-	GRPCOptions []grpc.ServerOption
+	HostAddr      ma.Multiaddr
+	Debug         bool
+	GRPCOptions   []grpc.ServerOption
+	KeyPassphrase []byte
+	GCInterval    time.Duration
+	Replicator    bool
+	PlaintextKeys bool
+	Algorithm     symmetric.Algorithm
 }
 
 type ServiceOption func(c *ServiceConfig) error
@@ -165,6 +188,70 @@ func WithServiceGRPCOptions(opts ...grpc.ServerOption) ServiceOption {
 	}
 }
 
+// WithServiceKeyPassphrase encrypts the logstore keys at rest with a master
+// key derived from passphrase. An encrypted logstore can only be opened with
+// its passphrase.
+func WithServiceKeyPassphrase(passphrase []byte) ServiceOption {
+	return func(c *ServiceConfig) error {
+		c.KeyPassphrase = passphrase
+		return nil
+	}
+}
+
+// WithServiceGCInterval collects the blockstore garbage every interval.
+// Zero disables scheduled collections.
+func WithServiceGCInterval(interval time.Duration) ServiceOption {
+	return func(c *ServiceConfig) error {
+		c.GCInterval = interval
+		return nil
+	}
+}
+
+// WithServiceReplicator runs the service as a replicator, which stores and
+// serves threads without ever holding their read-keys.
+func WithServiceReplicator(enabled bool) ServiceOption {
+	return func(c *ServiceConfig) error {
+		c.Replicator = enabled
+		return nil
+	}
+}
+
+// WithServiceAlgorithm sets the cipher the service encrypts records with.
+func WithServiceAlgorithm(alg symmetric.Algorithm) ServiceOption {
+	return func(c *ServiceConfig) error {
+		c.Algorithm = alg
+		return nil
+	}
+}
+
+// WithServicePlaintextKeys allows exchanging thread keys in plaintext with
+// peers whose keys can't be sealed to.
+func WithServicePlaintextKeys(enabled bool) ServiceOption {
+	return func(c *ServiceConfig) error {
+		c.PlaintextKeys = enabled
+		return nil
+	}
+}
+
+// RotateKeyPassphrase re-encrypts the logstore keys of the repo at repoPath
+// with a master key derived from a new passphrase. The repo must not be in use.
+func RotateKeyPassphrase(repoPath string, oldPassphrase, newPassphrase []byte) error {
+	logstore, err := badger.NewDatastore(filepath.Join(repoPath, defaultLogstorePath), &badger.DefaultOptions)
+	if err != nil {
+		return err
+	}
+	defer logstore.Close()
+	oldKey, err := lstoreds.MasterKeyFromPassphrase(logstore, oldPassphrase)
+	if err != nil {
+		return err
+	}
+	newKey, err := lstoreds.MasterKeyFromPassphrase(logstore, newPassphrase)
+	if err != nil {
+		return err
+	}
+	return lstoreds.RotateMasterKey(logstore, oldKey, newKey)
+}
+
 type servBoostrapper struct {
 	cancel context.CancelFunc
 	coreservice.Service
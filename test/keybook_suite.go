@@ -23,6 +23,8 @@ var keyBookSuite = map[string]func(kb core.KeyBook) func(*testing.T){
 	"LogsWithKeys":          testKeyBookLogs,
 	"ThreadsFromKeys":       testKeyBookThreads,
 	"PubKeyAddedOnRetrieve": testInlinedPubKeyAddedOnRetrieve,
+	"ClearKeys":             testKeyBookClearKeys,
+	"KeyEpochs":             testKeyBookKeyEpochs,
 }
 
 type KeyBookFactory func() (core.KeyBook, func())
@@ -164,6 +166,54 @@ func testKeyBookFollowKey(kb core.KeyBook) func(t *testing.T) {
 	}
 }
 
+func testKeyBookClearKeys(kb core.KeyBook) func(t *testing.T) {
+	return func(t *testing.T) {
+		tid := thread.NewIDV1(thread.Raw, 24)
+
+		priv, _, err := pt.RandTestKeyPair(crypto.RSA, crypto.MinRsaKeyBits)
+		if err != nil {
+			t.Error(err)
+		}
+		id, err := peer.IDFromPrivateKey(priv)
+		if err != nil {
+			t.Error(err)
+		}
+		if err = kb.AddPrivKey(tid, id, priv); err != nil {
+			t.Error(err)
+		}
+		if err = kb.AddPubKey(tid, id, priv.GetPublic()); err != nil {
+			t.Error(err)
+		}
+		key, err := symmetric.CreateKey()
+		if err != nil {
+			t.Error(err)
+		}
+		if err = kb.AddFollowKey(tid, key); err != nil {
+			t.Error(err)
+		}
+		if err = kb.AddReadKey(tid, key); err != nil {
+			t.Error(err)
+		}
+
+		if err = kb.ClearKeys(tid); err != nil {
+			t.Fatalf("clearing keys failed: %v", err)
+		}
+
+		if logs, err := kb.LogsWithKeys(tid); err != nil || len(logs) > 0 {
+			t.Error("expected logs to be empty after clearing keys without errors")
+		}
+		if res, err := kb.PrivKey(tid, id); err != nil || res != nil {
+			t.Error("expected private key to be nil after clearing keys without errors")
+		}
+		if res, err := kb.FollowKey(tid); err != nil || res != nil {
+			t.Error("expected follow key to be nil after clearing keys without errors")
+		}
+		if res, err := kb.ReadKey(tid); err != nil || res != nil {
+			t.Error("expected read key to be nil after clearing keys without errors")
+		}
+	}
+}
+
 func testKeyBookLogs(kb core.KeyBook) func(t *testing.T) {
 	return func(t *testing.T) {
 		tid := thread.NewIDV1(thread.Raw, 24)
@@ -433,3 +483,68 @@ func benchmarkLogsWithKeys(kb core.KeyBook) func(*testing.B) {
 		}
 	}
 }
+
+func testKeyBookKeyEpochs(kb core.KeyBook) func(t *testing.T) {
+	return func(t *testing.T) {
+		tid := thread.NewIDV1(thread.Raw, 24)
+
+		if epoch, err := kb.KeyEpoch(tid); err != nil || epoch != 0 {
+			t.Error("expected key epoch to be zero on init without errors")
+		}
+
+		fk0, err := symmetric.CreateKey()
+		if err != nil {
+			t.Error(err)
+		}
+		rk0, err := symmetric.CreateKey()
+		if err != nil {
+			t.Error(err)
+		}
+		if err = kb.AddFollowKey(tid, fk0); err != nil {
+			t.Error(err)
+		}
+		if err = kb.AddReadKey(tid, rk0); err != nil {
+			t.Error(err)
+		}
+
+		fk1, err := symmetric.CreateKey()
+		if err != nil {
+			t.Error(err)
+		}
+		rk1, err := symmetric.CreateKey()
+		if err != nil {
+			t.Error(err)
+		}
+		if err = kb.AddKeysAt(tid, 1, fk1, rk1); err != nil {
+			t.Error(err)
+		}
+
+		if epoch, err := kb.KeyEpoch(tid); err != nil || epoch != 1 {
+			t.Error("expected key epoch to be one after adding keys without errors")
+		}
+		if res, err := kb.FollowKey(tid); err != nil || !bytes.Equal(res.Bytes(), fk1.Bytes()) {
+			t.Error("retrieved follow key did not match latest follow key without errors")
+		}
+		if res, err := kb.ReadKey(tid); err != nil || !bytes.Equal(res.Bytes(), rk1.Bytes()) {
+			t.Error("retrieved read key did not match latest read key without errors")
+		}
+		if res, err := kb.FollowKeyAt(tid, 0); err != nil || !bytes.Equal(res.Bytes(), fk0.Bytes()) {
+			t.Error("retrieved follow key did not match first follow key without errors")
+		}
+		if res, err := kb.ReadKeyAt(tid, 0); err != nil || !bytes.Equal(res.Bytes(), rk0.Bytes()) {
+			t.Error("retrieved read key did not match first read key without errors")
+		}
+
+		// Adding keys at an older epoch must not change the current epoch
+		if err = kb.AddKeysAt(tid, 0, fk0, nil); err != nil {
+			t.Error(err)
+		}
+		if epoch, err := kb.KeyEpoch(tid); err != nil || epoch != 1 {
+			t.Error("expected key epoch to remain one without errors")
+		}
+
+		if logs, err := kb.LogsWithKeys(tid); err != nil || len(logs) > 0 {
+			t.Error("expected logs to be empty without errors")
+		}
+	}
+}
@@ -21,6 +21,7 @@ var threadstoreSuite = map[string]func(core.Logstore) func(*testing.T){
 	"AddStreamDuplicates":     testAddrStreamDuplicates,
 	"BasicLogstore":           testBasicLogstore,
 	"Metadata":                testMetadata,
+	"DeleteThread":            testDeleteThread,
 }
 
 type LogstoreFactory func() (core.Logstore, func())
@@ -304,6 +305,41 @@ func testMetadata(ts core.Logstore) func(t *testing.T) {
 	}
 }
 
+func testDeleteThread(ts core.Logstore) func(t *testing.T) {
+	return func(t *testing.T) {
+		tid := thread.NewIDV1(thread.Raw, 24)
+		priv, pub, _ := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+		p, _ := peer.IDFromPrivateKey(priv)
+		err := ts.AddLog(tid, thread.LogInfo{
+			ID:      p,
+			PubKey:  pub,
+			PrivKey: priv,
+			Addrs:   getAddrs(t, 1),
+		})
+		check(t, err)
+		check(t, ts.PutString(tid, "foo", "bar"))
+		check(t, ts.PutOutbox(tid, randOutboxEntries(t, 1)[0]))
+
+		check(t, ts.DeleteThread(tid))
+
+		info, err := ts.ThreadInfo(tid)
+		check(t, err)
+		if len(info.Logs) != 0 {
+			t.Fatalf("expected no logs, got %d", len(info.Logs))
+		}
+		v, err := ts.GetString(tid, "foo")
+		check(t, err)
+		if v != nil {
+			t.Fatal("expected metadata to be deleted")
+		}
+		entries, err := ts.Outbox(tid)
+		check(t, err)
+		if len(entries) != 0 {
+			t.Fatal("expected outbox to be deleted")
+		}
+	}
+}
+
 func getAddrs(t *testing.T, n int) []ma.Multiaddr {
 	var addrs []ma.Multiaddr
 	for i := 0; i < n; i++ {
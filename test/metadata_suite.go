@@ -21,6 +21,7 @@ var metadataBookSuite = map[string]func(mb core.ThreadMetadata) func(*testing.T)
 	"String":   testMetadataBookString,
 	"Byte":     testMetadataBookBytes,
 	"NotFound": testMetadataBookNotFound,
+	"Clear":    testMetadataBookClear,
 }
 
 type MetadataBookFactory func() (core.ThreadMetadata, func())
@@ -158,3 +159,23 @@ func testMetadataBookNotFound(mb core.ThreadMetadata) func(*testing.T) {
 		})
 	}
 }
+
+func testMetadataBookClear(mb core.ThreadMetadata) func(*testing.T) {
+	return func(t *testing.T) {
+		t.Run("Put&Clear", func(t *testing.T) {
+			t.Parallel()
+			tid := thread.NewIDV1(thread.Raw, 24)
+
+			key, value := "key1", "textile"
+			if err := mb.PutString(tid, key, value); err != nil {
+				t.Fatalf(errStrPut, key, err)
+			}
+			if err := mb.ClearMetadata(tid); err != nil {
+				t.Fatalf("clear failed: %v", err)
+			}
+			if v, err := mb.GetString(tid, key); v != nil || err != nil {
+				t.Fatalf(errStrNotFoundKey)
+			}
+		})
+	}
+}
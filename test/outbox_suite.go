@@ -0,0 +1,166 @@
+package test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	pt "github.com/libp2p/go-libp2p-core/test"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+var outboxBookSuite = map[string]func(ob core.OutboxBook) func(*testing.T){
+	"PutGetOutbox": testOutboxBookPutOutbox,
+	"RemoveOutbox": testOutboxBookRemoveOutbox,
+	"ClearOutbox":  testOutboxBookClearOutbox,
+}
+
+type OutboxBookFactory func() (core.OutboxBook, func())
+
+func OutboxBookTest(t *testing.T, factory OutboxBookFactory) {
+	for name, test := range outboxBookSuite {
+		// Create a new book.
+		ob, closeFunc := factory()
+
+		// Run the test.
+		t.Run(name, test(ob))
+
+		// Cleanup.
+		if closeFunc != nil {
+			closeFunc()
+		}
+	}
+}
+
+func testOutboxBookPutOutbox(ob core.OutboxBook) func(t *testing.T) {
+	return func(t *testing.T) {
+		tid := thread.NewIDV1(thread.Raw, 24)
+
+		if entries, err := ob.Outbox(tid); err != nil || len(entries) > 0 {
+			t.Error("expected outbox to be empty on init without errors")
+		}
+
+		entries := randOutboxEntries(t, 2)
+		for _, e := range entries {
+			if err := ob.PutOutbox(tid, e); err != nil {
+				t.Fatalf("error when putting outbox entry: %v", err)
+			}
+		}
+
+		// Replace the first entry
+		entries[0].Attempts++
+		entries[0].LastError = "retry failed"
+		if err := ob.PutOutbox(tid, entries[0]); err != nil {
+			t.Fatalf("error when putting outbox entry: %v", err)
+		}
+
+		obEntries, err := ob.Outbox(tid)
+		if err != nil {
+			t.Fatalf("error when getting outbox: %v", err)
+		}
+		if len(obEntries) != len(entries) {
+			t.Fatalf("incorrect outbox length %d", len(obEntries))
+		}
+		for _, e := range entries {
+			var found bool
+			for _, b := range obEntries {
+				if b.Peer == e.Peer && b.Record.Equals(e.Record) {
+					found = true
+					if b.Log != e.Log || b.Attempts != e.Attempts || b.LastError != e.LastError ||
+						!b.NextAttempt.Equal(e.NextAttempt) || !b.Created.Equal(e.Created) {
+						t.Errorf("outbox entry for %s does not match", e.Record)
+					}
+					break
+				}
+			}
+			if !found {
+				t.Errorf("outbox entry for %s not found in book", e.Record)
+			}
+		}
+
+		threads, err := ob.ThreadsFromOutbox()
+		if err != nil {
+			t.Fatalf("error when getting threads from outbox: %v", err)
+		}
+		if len(threads) != 1 || threads[0] != tid {
+			t.Errorf("expected outbox threads to contain %s", tid)
+		}
+	}
+}
+
+func testOutboxBookRemoveOutbox(ob core.OutboxBook) func(t *testing.T) {
+	return func(t *testing.T) {
+		tid := thread.NewIDV1(thread.Raw, 24)
+
+		entries := randOutboxEntries(t, 2)
+		for _, e := range entries {
+			if err := ob.PutOutbox(tid, e); err != nil {
+				t.Fatalf("error when putting outbox entry: %v", err)
+			}
+		}
+		if err := ob.RemoveOutbox(tid, entries[0].Peer, entries[0].Record); err != nil {
+			t.Fatalf("error when removing outbox entry: %v", err)
+		}
+
+		obEntries, err := ob.Outbox(tid)
+		if err != nil {
+			t.Fatalf("error when getting outbox: %v", err)
+		}
+		if len(obEntries) != 1 {
+			t.Fatalf("incorrect outbox length %d", len(obEntries))
+		}
+		if !obEntries[0].Record.Equals(entries[1].Record) {
+			t.Errorf("wrong outbox entry removed")
+		}
+	}
+}
+
+func testOutboxBookClearOutbox(ob core.OutboxBook) func(t *testing.T) {
+	return func(t *testing.T) {
+		tid := thread.NewIDV1(thread.Raw, 24)
+
+		for _, e := range randOutboxEntries(t, 2) {
+			if err := ob.PutOutbox(tid, e); err != nil {
+				t.Fatalf("error when putting outbox entry: %v", err)
+			}
+		}
+		if err := ob.ClearOutbox(tid); err != nil {
+			t.Fatalf("error when clearing outbox: %v", err)
+		}
+
+		entries, err := ob.Outbox(tid)
+		if err != nil {
+			t.Fatalf("error when getting outbox: %v", err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("incorrect outbox length %d", len(entries))
+		}
+	}
+}
+
+func randOutboxEntries(t *testing.T, n int) []core.OutboxEntry {
+	lid, err := pt.RandPeerID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := make([]core.OutboxEntry, n)
+	for i := range entries {
+		p, err := pt.RandPeerID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash, _ := mh.Encode([]byte("foo"+strconv.Itoa(i)), mh.SHA2_256)
+		entries[i] = core.OutboxEntry{
+			Log:         lid,
+			Record:      cid.NewCidV1(cid.DagCBOR, hash),
+			Peer:        p,
+			Attempts:    1,
+			NextAttempt: time.Unix(0, time.Now().Add(time.Minute).UnixNano()),
+			Created:     time.Unix(0, time.Now().UnixNano()),
+		}
+	}
+	return entries
+}
@@ -2,26 +2,69 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	logging "github.com/ipfs/go-log"
+	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/api"
+	core "github.com/textileio/go-threads/core/service"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/logstore/lstoreds"
+	"github.com/textileio/go-threads/metrics"
 	serviceapi "github.com/textileio/go-threads/service/api"
 	"github.com/textileio/go-threads/store"
+	"github.com/textileio/go-threads/tracing"
 	"github.com/textileio/go-threads/util"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 var log = logging.Logger("threadsd")
 
+// Env variables holding passphrases of encrypted logstore keys.
+const (
+	passphraseEnv    = "THREADS_PASSPHRASE"
+	newPassphraseEnv = "THREADS_NEW_PASSPHRASE"
+)
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			exportThread(os.Args[2:])
+			return
+		case "import":
+			importThread(os.Args[2:])
+			return
+		case "rotate-key":
+			rotateKey(os.Args[2:])
+			return
+		case "gc":
+			collectGarbage(os.Args[2:])
+			return
+		}
+	}
+
 	repo := flag.String("repo", ".threads", "repo location")
 	hostAddrStr := flag.String("hostAddr", "/ip4/0.0.0.0/tcp/4006", "Threads host bind address")
 	serviceApiAddrStr := flag.String("serviceApiAddr", "/ip4/127.0.0.1/tcp/5006", "Threads service API bind address")
 	serviceApiProxyAddrStr := flag.String("serviceApiProxyAddr", "/ip4/127.0.0.1/tcp/5007", "Threads service API gRPC proxy bind address")
 	apiAddrStr := flag.String("apiAddr", "/ip4/127.0.0.1/tcp/6006", "API bind address")
 	apiProxyAddrStr := flag.String("apiProxyAddr", "/ip4/127.0.0.1/tcp/6007", "API gRPC proxy bind address")
+	metricsAddrStr := flag.String("metricsAddr", "", "Prometheus metrics HTTP bind address, empty disables metrics")
+	traceExporter := flag.String("trace", "", "Trace exporter, stdout or file, empty disables tracing")
+	traceFile := flag.String("traceFile", "traces.json", "Trace file of the file exporter, relative to the repo")
+	encryptKeys := flag.Bool("encryptKeys", false, "Encrypt logstore keys at rest with a passphrase")
+	gcInterval := flag.Duration("gcInterval", 0, "Interval between blockstore garbage collections, zero disables them")
+	replicator := flag.Bool("replicator", false, "Store and serve threads without read-keys, the store API is disabled")
 	debug := flag.Bool("debug", false, "Enable debug logging")
 	flag.Parse()
 
@@ -46,33 +89,53 @@ func main() {
 		log.Fatal(err)
 	}
 
+	var metricsAddr ma.Multiaddr
+	if *metricsAddrStr != "" {
+		metricsAddr, err = ma.NewMultiaddr(*metricsAddrStr)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	util.SetupDefaultLoggingConfig(*repo)
 	if *debug {
 		if err := logging.SetLogLevel("threadsd", "debug"); err != nil {
 			log.Fatal(err)
 		}
 	}
+	if *traceExporter != "" {
+		defer startTracing(*traceExporter, filepath.Join(*repo, *traceFile))()
+	}
 
-	ts, err := store.DefaultService(
-		*repo,
+	opts := []store.ServiceOption{
 		store.WithServiceHostAddr(hostAddr),
-		store.WithServiceDebug(*debug))
+		store.WithServiceDebug(*debug),
+		store.WithServiceGCInterval(*gcInterval),
+		store.WithServiceReplicator(*replicator),
+	}
+	ts, err := store.DefaultService(*repo, append(opts, unlockKeys(*encryptKeys)...)...)
+	if errors.Is(err, lstoreds.ErrKeyBookLocked) {
+		log.Fatal("logstore keys are encrypted, start with -encryptKeys to unlock them")
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer ts.Close()
 	ts.Bootstrap(util.DefaultBoostrapPeers())
 
-	server, err := api.NewServer(context.Background(), ts, api.Config{
-		RepoPath:  *repo,
-		Addr:      apiAddr,
-		ProxyAddr: apiProxyAddr,
-		Debug:     *debug,
-	})
-	if err != nil {
-		log.Fatal(err)
+	// Stores need read-keys, which replicators don't hold
+	if !*replicator {
+		server, err := api.NewServer(context.Background(), ts, api.Config{
+			RepoPath:  *repo,
+			Addr:      apiAddr,
+			ProxyAddr: apiProxyAddr,
+			Debug:     *debug,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer server.Close()
 	}
-	defer server.Close()
 
 	serviceServer, err := serviceapi.NewServer(context.Background(), ts, serviceapi.Config{
 		Addr:      serviceApiAddr,
@@ -84,10 +147,236 @@ func main() {
 	}
 	defer serviceServer.Close()
 
+	if metricsAddr != nil {
+		metricsServer, err := metrics.NewServer(metricsAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer metricsServer.Close()
+	}
+
 	fmt.Println("Welcome to Threads!")
 	fmt.Println("Your peer ID is " + ts.Host().ID().String())
+	if *replicator {
+		fmt.Println("Running as a replicator")
+	}
 
 	log.Debug("threadsd started")
 
 	select {}
 }
+
+// exportThread writes a thread from the repo to a CAR file.
+func exportThread(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	repo := fs.String("repo", ".threads", "repo location")
+	idStr := fs.String("thread", "", "ID of the thread to export")
+	out := fs.String("out", "", "CAR file path")
+	encryptKeys := fs.Bool("encryptKeys", false, "Unlock encrypted logstore keys with a passphrase")
+	_ = fs.Parse(args)
+	if *idStr == "" || *out == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	id, err := thread.Decode(*idStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = withService(*repo, *encryptKeys, func(ts core.Service) error {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return ts.ExportThread(context.Background(), id, f)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Exported thread %s to %s\n", id, *out)
+}
+
+// importThread adds a thread from a CAR file to the repo.
+func importThread(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	repo := fs.String("repo", ".threads", "repo location")
+	in := fs.String("in", "", "CAR file path")
+	fkStr := fs.String("followKey", "", "Thread follow-key")
+	rkStr := fs.String("readKey", "", "Thread read-key")
+	pastStr := fs.String("pastKeys", "", "Keys of earlier key epochs as epoch:followKey[:readKey], comma-separated")
+	creatorStr := fs.String("creator", "", "Log ID of the thread creator, required for access controlled threads")
+	encryptKeys := fs.Bool("encryptKeys", false, "Unlock encrypted logstore keys with a passphrase")
+	_ = fs.Parse(args)
+	if *in == "" || *fkStr == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	fk, err := util.DecodeKey(*fkStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := []core.KeyOption{core.FollowKey(fk)}
+	if *rkStr != "" {
+		rk, err := util.DecodeKey(*rkStr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, core.ReadKey(rk))
+	}
+	past, err := parsePastKeys(*pastStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts = append(opts, past...)
+	if *creatorStr != "" {
+		creator, err := peer.Decode(*creatorStr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, core.Creator(creator))
+	}
+
+	var info thread.Info
+	err = withService(*repo, *encryptKeys, func(ts core.Service) error {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		info, err = ts.ImportThread(context.Background(), f, opts...)
+		return err
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Imported thread %s with %d logs\n", info.ID, len(info.Logs))
+}
+
+// parsePastKeys parses the keys of earlier key epochs given to import.
+func parsePastKeys(str string) ([]core.KeyOption, error) {
+	if str == "" {
+		return nil, nil
+	}
+	var opts []core.KeyOption
+	for _, part := range strings.Split(str, ",") {
+		fields := strings.Split(part, ":")
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("invalid past keys %s", part)
+		}
+		epoch, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		fk, err := util.DecodeKey(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		var rk *symmetric.Key
+		if len(fields) == 3 {
+			if rk, err = util.DecodeKey(fields[2]); err != nil {
+				return nil, err
+			}
+		}
+		opts = append(opts, core.PastKeys(epoch, fk, rk))
+	}
+	return opts, nil
+}
+
+// collectGarbage removes the blocks of the repo that aren't linked from a
+// thread log or pinned.
+func collectGarbage(args []string) {
+	fs := flag.NewFlagSet("gc", flag.ExitOnError)
+	repo := fs.String("repo", ".threads", "repo location")
+	encryptKeys := fs.Bool("encryptKeys", false, "Unlock encrypted logstore keys with a passphrase")
+	_ = fs.Parse(args)
+
+	var removed int
+	err := withService(*repo, *encryptKeys, func(ts core.Service) (err error) {
+		removed, err = ts.GC(context.Background())
+		return err
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Removed %d blocks\n", removed)
+}
+
+// withService runs f with the service of a repo, and closes the service
+// before returning f's error.
+func withService(repo string, encryptKeys bool, f func(core.Service) error) error {
+	ts, err := store.DefaultService(repo, unlockKeys(encryptKeys)...)
+	if err != nil {
+		return err
+	}
+	defer ts.Close()
+	return f(ts)
+}
+
+// rotateKey re-encrypts the logstore keys of the repo with a new passphrase.
+// Keys that aren't encrypted yet are encrypted.
+func rotateKey(args []string) {
+	fs := flag.NewFlagSet("rotate-key", flag.ExitOnError)
+	repo := fs.String("repo", ".threads", "repo location")
+	_ = fs.Parse(args)
+
+	oldPassphrase, err := readPassphrase("Current passphrase: ", passphraseEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	newPassphrase, err := readPassphrase("New passphrase: ", newPassphraseEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = store.RotateKeyPassphrase(*repo, oldPassphrase, newPassphrase); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Rotated the logstore master key")
+}
+
+// startTracing exports spans with the named exporter. The returned func
+// flushes pending spans.
+func startTracing(exporter, path string) func() {
+	var exp sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "stdout":
+		exp, err = tracing.NewWriterExporter(os.Stdout)
+	case "file":
+		exp, err = tracing.NewFileExporter(path)
+	default:
+		log.Fatalf("unknown trace exporter %s", exporter)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	shutdown := tracing.Start("threadsd", exp)
+	return func() {
+		if err := shutdown(context.Background()); err != nil {
+			log.Errorf("error stopping tracing: %s", err)
+		}
+	}
+}
+
+// unlockKeys returns the options that unlock encrypted logstore keys.
+func unlockKeys(encrypted bool) []store.ServiceOption {
+	if !encrypted {
+		return nil
+	}
+	passphrase, err := readPassphrase("Passphrase: ", passphraseEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return []store.ServiceOption{store.WithServiceKeyPassphrase(passphrase)}
+}
+
+// readPassphrase returns the passphrase in the env variable, or prompts for it.
+func readPassphrase(prompt, env string) ([]byte, error) {
+	if p := os.Getenv(env); p != "" {
+		return []byte(p), nil
+	}
+	fmt.Print(prompt)
+	defer fmt.Println()
+	return terminal.ReadPassword(int(os.Stdin.Fd()))
+}
@@ -0,0 +1,164 @@
+// Package tracing sets up OpenTelemetry tracing and propagates span contexts
+// through gRPC metadata, including gRPC calls tunnelled over libp2p streams.
+package tracing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Tracer returns the named tracer of the global provider. Spans are dropped
+// until Start installs an exporter.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// Start installs a global tracer provider that batches spans to exp.
+// Any span exporter can be plugged in. The returned func flushes pending
+// spans and stops the provider.
+func Start(service string, exp sdktrace.SpanExporter) func(context.Context) error {
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", service))))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tp.Shutdown
+}
+
+// NewWriterExporter returns an exporter that writes spans to w as JSON,
+// for debugging without a collector.
+func NewWriterExporter(w io.Writer) (sdktrace.SpanExporter, error) {
+	return stdouttrace.New(stdouttrace.WithWriter(w))
+}
+
+// NewFileExporter returns an exporter that appends spans to the file at
+// path as JSON. The file is closed when the exporter shuts down.
+func NewFileExporter(path string) (sdktrace.SpanExporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	exp, err := NewWriterExporter(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &fileExporter{SpanExporter: exp, f: f}, nil
+}
+
+// fileExporter closes its file on shutdown.
+type fileExporter struct {
+	sdktrace.SpanExporter
+	f *os.File
+}
+
+// Shutdown flushes and closes the exporter's file.
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if cerr := e.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartServerSpan starts a span for a gRPC handler that continues the
+// span context sent by the caller.
+func StartServerSpan(ctx context.Context, tracer trace.Tracer, name string) (context.Context, trace.Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	}
+	return tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer))
+}
+
+// UnaryClientInterceptor returns a client interceptor that traces calls and
+// sends their span context to the server.
+func UnaryClientInterceptor(tracer trace.Tracer) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) (err error) {
+		ctx, span := tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+		defer func() { End(span, err) }()
+		return invoker(inject(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor that traces stream
+// setup and sends the span context to the server.
+func StreamClientInterceptor(tracer trace.Tracer) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		ctx, span := tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+		stream, err := streamer(inject(ctx), desc, cc, method, opts...)
+		End(span, err)
+		return stream, err
+	}
+}
+
+// inject adds the span context of ctx to its outgoing gRPC metadata.
+func inject(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// metadataCarrier adapts gRPC metadata to a propagation carrier.
+type metadataCarrier metadata.MD
+
+// Get returns the first value of key.
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// Set replaces the values of key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns the metadata keys.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
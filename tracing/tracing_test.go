@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestPropagation(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := NewWriterExporter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := Start("test", exp)
+	tracer := Tracer("test")
+
+	ctx, client := tracer.Start(context.Background(), "client")
+	md, ok := metadata.FromOutgoingContext(inject(ctx))
+	if !ok {
+		t.Fatal("expected outgoing metadata")
+	}
+
+	// The server only sees the metadata
+	sctx := metadata.NewIncomingContext(context.Background(), md)
+	_, server := StartServerSpan(sctx, tracer, "server")
+	if server.SpanContext().TraceID() != client.SpanContext().TraceID() {
+		t.Fatal("expected server span to continue the client trace")
+	}
+	server.End()
+	client.End()
+
+	if err = stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("expected spans to be exported")
+	}
+}